@@ -1,23 +1,148 @@
 package app
 
 import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/cep21/circuit/v4"
+
 	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/domain/types"
 	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/filestore"
+	"github.com/go-api-template/app/gateway/jobs"
+	"github.com/go-api-template/app/gateway/outbox"
 	"github.com/go-api-template/app/gateway/postgres"
 	"github.com/go-api-template/app/gateway/redis"
+	"github.com/go-api-template/app/gateway/twilio"
+	"github.com/go-api-template/app/gateway/webhooks"
+	"github.com/go-api-template/app/library/geoip"
+	"github.com/go-api-template/app/library/signedlink"
+	"github.com/go-api-template/app/library/urlcheck"
+	"github.com/go-api-template/app/library/useragent"
 )
 
 type App struct {
 	UseCase *usecase.UseCase
+	cfg     config.Config
+}
+
+// RegisterJobHandlers registers, on a worker, the use cases handling each
+// types.Job and the periodic ones.
+func (a *App) RegisterJobHandlers(worker *jobs.Worker) {
+	jobs.Register(worker, types.ExportData, a.UseCase.RunExportJob)
+	jobs.Register(worker, types.SendMessage, a.UseCase.SendLinkMessage)
+	jobs.Register(worker, types.DeliverWebhook, a.UseCase.DeliverWebhook)
+	jobs.Register(worker, types.CreateLinks, a.UseCase.RunLinksBulkJob)
+
+	jobs.OnDeadLetter(worker, types.ExportData, a.UseCase.FailExportJob)
+	jobs.OnDeadLetter(worker, types.CreateLinks, a.UseCase.FailLinksBulkJob)
+
+	worker.Schedule("publish-expired-links", a.cfg.Webhooks.ExpiredLinksInterval, a.UseCase.PublishExpiredLinks)
+	worker.Schedule("reconcile-click-counters", a.cfg.ClickCounters.ReconcileInterval, a.UseCase.ReconcileClickCounters)
+	worker.Schedule("delete-expired-exports", a.cfg.Exports.CleanupInterval, a.UseCase.DeleteExpiredExports)
+
+	if a.cfg.Twilio.Enabled() {
+		worker.Schedule("notify-expiring-links", a.cfg.Twilio.ExpiryNoticeInterval, a.UseCase.NotifyExpiringLinks)
+	}
 }
 
-func New(config config.Config, db *postgres.Client, redisClient *redis.Client) (*App, error) { //nolint: revive
+// RegisterOutboxHandlers subscribes, on a relay, the use cases handling each
+// types.Event.
+func (a *App) RegisterOutboxHandlers(relay *outbox.Relay) {
+	outbox.Subscribe(relay, types.EventUserCreated, a.UseCase.HandleUserCreated)
+	outbox.Subscribe(relay, types.EventLinkCreated, a.UseCase.HandleLinkCreated)
+	outbox.Subscribe(relay, types.EventLinkUpdated, a.UseCase.HandleLinkUpdated)
+}
+
+func New(ctx context.Context, config config.Config, db *postgres.Client, cache *redis.LayeredCache, circuitManager *circuit.Manager) (*App, error) { //nolint: revive
+	const operation = "App.New"
+
+	baseURL, err := url.Parse(config.Links.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("%s -> links base url: %w", operation, err)
+	}
+
+	urlChecker, err := urlcheck.New(config.URLCheck, baseURL.Hostname())
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	go urlChecker.Watch(ctx)
+
+	linkSigner, err := signedlink.New(config.LinkSigning)
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	geoLocator, err := geoip.New(config.GeoIP)
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	go geoLocator.Watch(ctx)
+
+	userAgentParser, err := useragent.New(config.UserAgent)
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	clickEvents := redis.NewClickEvents(cache.Client, config.ClickEvents)
+	go clickEvents.Watch(ctx)
+
+	exportStore, err := filestore.New(config.Exports.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
 	useCase := &usecase.UseCase{
-		AppName:         config.App.Name,
-		UsersRepository: postgres.NewUsersRepository(db),
+		AppName:                     config.App.Name,
+		LinkBaseURL:                 config.Links.BaseURL,
+		LinkCodeLength:              config.Links.CodeLength,
+		LinkUnlockMaxAttempts:       config.Links.UnlockMaxAttempts,
+		LinkUnlockAttemptsWindow:    config.Links.UnlockAttemptsWindow,
+		LinkBulkMaxRows:             config.Links.BulkMaxRows,
+		LinkBulkAsyncThreshold:      config.Links.BulkAsyncThreshold,
+		URLChecker:                  urlChecker,
+		LinkSigner:                  linkSigner,
+		ExportAsyncThreshold:        config.Exports.AsyncThreshold,
+		ExportMaxRange:              config.Exports.MaxRange,
+		ExportJobTTL:                config.Exports.JobTTL,
+		ExportStore:                 exportStore,
+		Jobs:                        jobs.NewQueue(postgres.NewJobsRepository(db), config.Jobs),
+		LinkExpiryNoticeBefore:      config.Twilio.ExpiryNoticeBefore,
+		WebhookSender:               webhooks.New(config.Webhooks, config.App.Name, circuitManager),
+		WebhookDisableAfterFailures: config.Webhooks.DisableAfterFailures,
+		WebhookClickSampleRate:      config.Webhooks.ClickSampleRate,
+		WebhookAllowPrivateNetworks: config.Webhooks.AllowPrivateNetworks,
+		ClickCounters:               cache,
+		ClickCountersBatchSize:      config.ClickCounters.ReconcileBatchSize,
+		ClickEvents:                 clickEvents,
+		GeoLocator:                  geoLocator,
+		UserAgentParser:             userAgentParser,
+		TxManager:                   postgres.NewTxManager(db, config.Postgres.TxMaxRetries),
+		UsersRepository:             postgres.NewUsersRepository(db),
+		LinksRepository:             postgres.NewLinksRepository(db),
+		ClicksRepository:            postgres.NewClicksRepository(db),
+		MessagesRepository:          postgres.NewMessagesRepository(db),
+		WebhooksRepository:          postgres.NewWebhooksRepository(db),
+		LinkStatsRepository:         postgres.NewLinkStatsRepository(db),
+		Cache:                       cache,
+	}
+
+	// Messaging stays disabled without Twilio credentials.
+	if config.Twilio.Enabled() {
+		messenger, err := twilio.New(config.Twilio, circuitManager)
+		if err != nil {
+			return nil, fmt.Errorf("%s -> %w", operation, err)
+		}
+
+		useCase.Messenger = messenger
 	}
 
 	return &App{
 		UseCase: useCase,
+		cfg:     config,
 	}, nil
 }
@@ -1,7 +1,10 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"net/netip"
+	"strings"
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
@@ -17,21 +20,73 @@ const (
 	EnvProduction Environment = "production"
 )
 
+// Prefixes are IP networks, comma separated in CIDR notation. Plain
+// addresses are networks of their own.
+type Prefixes []netip.Prefix
+
+func (p *Prefixes) Decode(value string) error {
+	prefixes := make(Prefixes, 0)
+
+	for _, s := range strings.Split(value, ",") {
+		if s = strings.TrimSpace(s); s == "" {
+			continue
+		}
+
+		if addr, err := netip.ParseAddr(s); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		prefixes = append(prefixes, prefix.Masked())
+	}
+
+	*p = prefixes
+
+	return nil
+}
+
 type Config struct {
 	Environment Environment `required:"true" envconfig:"ENVIRONMENT"`
 	Development bool        `required:"true" envconfig:"DEVELOPMENT"`
 
 	App    App
 	Server Server
+	CORS   CORS
+	Admin  Admin
+
+	// Links
+	Links         Links
+	LinkSigning   LinkSigning
+	URLCheck      URLCheck
+	Exports       Exports
+	ClickCounters ClickCounters
+	ClickEvents   ClickEvents
+	GeoIP         GeoIP
+	UserAgent     UserAgent
+
+	// Background jobs
+	Jobs   Jobs
+	Outbox Outbox
+
+	// Messaging
+	Twilio   Twilio
+	Webhooks Webhooks
 
 	// Resilience
 	CircuitBreaker CircuitBreaker
 	Retry          Retry
 
 	// Infra
-	Otel     Otel
-	Postgres Postgres
-	Redis    Redis
+	Otel       Otel
+	Postgres   Postgres
+	Redis      Redis
+	LocalCache LocalCache
 }
 
 type App struct {
@@ -45,6 +100,209 @@ type Server struct {
 	APIAddress   string        `required:"true" envconfig:"SERVER_API_ADDRESS"`
 	ReadTimeout  time.Duration `required:"true" envconfig:"SERVER_READ_TIMEOUT"`
 	WriteTimeout time.Duration `required:"true" envconfig:"SERVER_WRITE_TIMEOUT"`
+
+	// Proxies trusted to tell the client address in the True-Client-Ip,
+	// X-Real-Ip and X-Forwarded-For headers. Without any, the headers are
+	// ignored and the peer address is the client's, so behind a proxy it must
+	// be listed here.
+	TrustedProxies Prefixes `envconfig:"SERVER_TRUSTED_PROXIES"`
+}
+
+type CORS struct {
+	// Origins allowed to make cross-origin requests. Entries may contain a single
+	// wildcard for subdomains (e.g. https://*.example.com) or be "*" for any origin,
+	// which can't be combined with AllowCredentials.
+	AllowedOrigins   []string      `envconfig:"CORS_ALLOWED_ORIGINS"`
+	AllowedMethods   []string      `envconfig:"CORS_ALLOWED_METHODS"   default:"GET,POST,PUT,PATCH,DELETE,HEAD,OPTIONS"`
+	AllowedHeaders   []string      `envconfig:"CORS_ALLOWED_HEADERS"   default:"Accept,Authorization,Content-Type,Origin,Referer,User-Agent,device-generated-id,platform-id,sec-ch-ua,sec-ch-ua-mobile,sec-ch-ua-platform"`
+	ExposedHeaders   []string      `envconfig:"CORS_EXPOSED_HEADERS"   default:"x-request-id,x-ratelimit-limit,x-ratelimit-remaining,x-ratelimit-reset,retry-after"`
+	AllowCredentials bool          `envconfig:"CORS_ALLOW_CREDENTIALS" default:"false"`
+	MaxAge           time.Duration `envconfig:"CORS_MAX_AGE"           default:"10m"`
+}
+
+// validate rejects allowing any origin with credentials, which would let any
+// site make requests with the user's cookies.
+func (c CORS) validate() error {
+	if !c.AllowCredentials {
+		return nil
+	}
+
+	for _, origin := range c.AllowedOrigins {
+		if strings.TrimSpace(origin) == "*" {
+			return errors.New("CORS_ALLOWED_ORIGINS can't include * with CORS_ALLOW_CREDENTIALS")
+		}
+	}
+
+	return nil
+}
+
+type Admin struct {
+	// Bearer token required by the admin API. The admin routes are not mounted when empty.
+	APIToken string `envconfig:"ADMIN_API_TOKEN"`
+}
+
+type Links struct {
+	// Public base URL short codes are appended to, e.g. https://sho.rt
+	BaseURL    string `envconfig:"LINKS_BASE_URL"    default:"http://localhost:5000"`
+	CodeLength int    `envconfig:"LINKS_CODE_LENGTH" default:"7"`
+
+	// Password-protected links
+	UnlockCookieSecret   string        `required:"true"                          envconfig:"LINKS_UNLOCK_COOKIE_SECRET"`
+	UnlockCookieTTL      time.Duration `envconfig:"LINKS_UNLOCK_COOKIE_TTL"      default:"1h"`
+	UnlockMaxAttempts    int           `envconfig:"LINKS_UNLOCK_MAX_ATTEMPTS"    default:"5"`
+	UnlockAttemptsWindow time.Duration `envconfig:"LINKS_UNLOCK_ATTEMPTS_WINDOW" default:"15m"`
+
+	// Bulk creation: requests above BulkAsyncThreshold rows run in the
+	// background and are polled.
+	BulkMaxRows        int `envconfig:"LINKS_BULK_MAX_ROWS"        default:"50000"`
+	BulkAsyncThreshold int `envconfig:"LINKS_BULK_ASYNC_THRESHOLD" default:"500"`
+}
+
+type LinkSigning struct {
+	// Signing keys by key id, e.g. v1:secret1,v2:secret2. New codes are signed
+	// with ActiveKeyID.
+	Keys        map[string]string `envconfig:"LINKS_SIGNING_KEYS"`
+	ActiveKeyID string            `envconfig:"LINKS_SIGNING_ACTIVE_KEY_ID"`
+
+	// Retirement date (YYYY-MM-DD) by key id, e.g. v1:2026-10-01. Codes signed
+	// with a retired key are accepted until GracePeriod after that date.
+	RetiredKeys map[string]string `envconfig:"LINKS_SIGNING_RETIRED_KEYS"`
+	GracePeriod time.Duration     `envconfig:"LINKS_SIGNING_GRACE_PERIOD" default:"720h"`
+}
+
+type URLCheck struct {
+	AllowedSchemes []string `envconfig:"URLCHECK_ALLOWED_SCHEMES" default:"http,https"`
+
+	// File with one blocked domain per line; subdomains of a listed domain are
+	// blocked too. Changes are picked up every BlocklistReloadInterval.
+	BlocklistPath           string        `envconfig:"URLCHECK_BLOCKLIST_PATH"`
+	BlocklistReloadInterval time.Duration `envconfig:"URLCHECK_BLOCKLIST_RELOAD_INTERVAL" default:"30s"`
+
+	// Hosts serving our short links besides the one in LINKS_BASE_URL. Links
+	// pointing to them are rejected as redirect loops.
+	OwnHosts []string `envconfig:"URLCHECK_OWN_HOSTS"`
+
+	// Hostnames are resolved, waiting up to ResolveTimeout, and rejected when
+	// they point to a private address. Disabled with 0.
+	ResolveTimeout time.Duration `envconfig:"URLCHECK_RESOLVE_TIMEOUT" default:"2s"`
+}
+
+type Exports struct {
+	// Directory background exports are written to; it may be a mounted object
+	// storage bucket.
+	Dir string `envconfig:"EXPORTS_DIR" default:"./exports"`
+
+	// Exports with more rows than AsyncThreshold, and all Parquet exports, are
+	// written to a file in the background instead of streamed.
+	AsyncThreshold int64         `envconfig:"EXPORTS_ASYNC_THRESHOLD" default:"100000"`
+	MaxRange       time.Duration `envconfig:"EXPORTS_MAX_RANGE"       default:"8760h"`
+	JobTTL         time.Duration `envconfig:"EXPORTS_JOB_TTL"         default:"24h"`
+
+	// Files of exports whose job expired are deleted every CleanupInterval.
+	CleanupInterval time.Duration `envconfig:"EXPORTS_CLEANUP_INTERVAL" default:"1h"`
+}
+
+// ClickCounters are live click counts kept in Redis, flushed into Postgres
+// every ReconcileInterval, ReconcileBatchSize links at a time.
+type ClickCounters struct {
+	ReconcileInterval  time.Duration `envconfig:"CLICK_COUNTERS_RECONCILE_INTERVAL"   default:"1m"`
+	ReconcileBatchSize int           `envconfig:"CLICK_COUNTERS_RECONCILE_BATCH_SIZE" default:"500"`
+}
+
+// ClickEvents are clicks streamed live to the viewers of a link. The last
+// BufferLength clicks of each link are kept for BufferTTL after its last
+// click, for viewers to resume from after reconnecting.
+type ClickEvents struct {
+	BufferLength int64         `envconfig:"CLICK_EVENTS_BUFFER_LENGTH" default:"1000"`
+	BufferTTL    time.Duration `envconfig:"CLICK_EVENTS_BUFFER_TTL"    default:"1h"`
+
+	// Idle streams get a comment every Heartbeat, so proxies keep them open
+	// and clients gone are noticed.
+	Heartbeat time.Duration `envconfig:"CLICK_EVENTS_HEARTBEAT" default:"15s"`
+}
+
+// GeoIP locates clicks with a MaxMind-format database: GeoLite2 or GeoIP2
+// City, ASN, or one combining both. Changes to the file are picked up every
+// ReloadInterval. Disabled while DatabasePath is empty.
+type GeoIP struct {
+	DatabasePath   string        `envconfig:"GEOIP_DATABASE_PATH"`
+	ReloadInterval time.Duration `envconfig:"GEOIP_RELOAD_INTERVAL" default:"1m"`
+}
+
+// UserAgent tells bots apart by their user agent, with the rules file at
+// RulesPath instead of the bundled one when set (see
+// library/useragent/rules.txt for its format).
+type UserAgent struct {
+	RulesPath string `envconfig:"USERAGENT_RULES_PATH"`
+}
+
+type Jobs struct {
+	// Failed jobs are retried with exponential backoff between BackoffMin and
+	// BackoffMax, and dead-lettered after MaxAttempts.
+	MaxAttempts int           `envconfig:"JOBS_MAX_ATTEMPTS" default:"5"`
+	BackoffMin  time.Duration `envconfig:"JOBS_BACKOFF_MIN"  default:"5s"`
+	BackoffMax  time.Duration `envconfig:"JOBS_BACKOFF_MAX"  default:"1h"`
+
+	// A claimed job is invisible to other workers for VisibilityTimeout, which
+	// also bounds how long it may run.
+	VisibilityTimeout time.Duration `envconfig:"JOBS_VISIBILITY_TIMEOUT" default:"15m"`
+	PollInterval      time.Duration `envconfig:"JOBS_POLL_INTERVAL"      default:"1s"`
+	Concurrency       int           `envconfig:"JOBS_CONCURRENCY"        default:"10"`
+}
+
+type Outbox struct {
+	// Pending events are relayed every RelayInterval, BatchSize at a time.
+	RelayInterval time.Duration `envconfig:"OUTBOX_RELAY_INTERVAL" default:"1s"`
+	BatchSize     int           `envconfig:"OUTBOX_BATCH_SIZE"     default:"100"`
+
+	// An event failing MaxAttempts relays is set aside so it doesn't hold
+	// back the ones after it.
+	MaxAttempts int `envconfig:"OUTBOX_MAX_ATTEMPTS" default:"10"`
+}
+
+type Twilio struct {
+	// Messaging is disabled while AccountSID is empty.
+	AccountSID string `envconfig:"TWILIO_ACCOUNT_SID"`
+	AuthToken  string `envconfig:"TWILIO_AUTH_TOKEN"`
+
+	// Sender numbers in E.164 format, per channel.
+	SMSFrom      string `envconfig:"TWILIO_SMS_FROM"`
+	WhatsAppFrom string `envconfig:"TWILIO_WHATSAPP_FROM"`
+
+	// Public URL of the status callbacks webhook, exactly as Twilio calls it:
+	// request signatures are computed over it.
+	StatusCallbackURL string `envconfig:"TWILIO_STATUS_CALLBACK_URL"`
+
+	BaseURL string        `envconfig:"TWILIO_BASE_URL" default:"https://api.twilio.com"`
+	Timeout time.Duration `envconfig:"TWILIO_TIMEOUT"  default:"10s"`
+
+	// Users are told about their links expiring within ExpiryNoticeBefore;
+	// expiring links are looked up every ExpiryNoticeInterval.
+	ExpiryNoticeBefore   time.Duration `envconfig:"TWILIO_EXPIRY_NOTICE_BEFORE"   default:"24h"`
+	ExpiryNoticeInterval time.Duration `envconfig:"TWILIO_EXPIRY_NOTICE_INTERVAL" default:"5m"`
+}
+
+func (t Twilio) Enabled() bool {
+	return t.AccountSID != ""
+}
+
+type Webhooks struct {
+	// A webhook is disabled after DisableAfterFailures deliveries in a row
+	// failed all their attempts.
+	DisableAfterFailures int `envconfig:"WEBHOOKS_DISABLE_AFTER_FAILURES" default:"5"`
+
+	// Share of the clicks, from 0 to 1, delivered as link.clicked events.
+	ClickSampleRate float64 `envconfig:"WEBHOOKS_CLICK_SAMPLE_RATE" default:"0.1"`
+
+	Timeout time.Duration `envconfig:"WEBHOOKS_TIMEOUT" default:"10s"`
+
+	// Expired links are looked up every ExpiredLinksInterval for link.expired
+	// events.
+	ExpiredLinksInterval time.Duration `envconfig:"WEBHOOKS_EXPIRED_LINKS_INTERVAL" default:"1m"`
+
+	// Lets deliveries reach private and loopback addresses; only meant for
+	// local development.
+	AllowPrivateNetworks bool `envconfig:"WEBHOOKS_ALLOW_PRIVATE_NETWORKS" default:"false"`
 }
 
 type CircuitBreaker struct {
@@ -93,14 +351,63 @@ type Postgres struct {
 	SSLCert               string `envconfig:"DATABASE_SSL_CERT"`
 	SSLKey                string `envconfig:"DATABASE_SSL_KEY"`
 	Hostname              string `envconfig:"HOSTNAME"`
+
+	// Transactions aborted by serialization failures or deadlocks are run
+	// again up to TxMaxRetries times.
+	TxMaxRetries int `envconfig:"DATABASE_TX_MAX_RETRIES" default:"3"`
+
+	// Lookups and stats are read from ReplicaDSNs, round-robin, while they
+	// answer health checks and lag at most ReplicaMaxLag behind the primary.
+	ReplicaDSNs                []string      `envconfig:"DATABASE_REPLICA_DSNS"`
+	ReplicaHealthCheckInterval time.Duration `envconfig:"DATABASE_REPLICA_HEALTHCHECK_INTERVAL" default:"5s"`
+	ReplicaMaxLag              time.Duration `envconfig:"DATABASE_REPLICA_MAX_LAG"              default:"10s"`
+
+	// Reads within ReadYourWritesWindow of a write of the same request or
+	// session go to the primary.
+	ReadYourWritesWindow time.Duration `envconfig:"DATABASE_READ_YOUR_WRITES_WINDOW" default:"5s"`
 }
 
+type RedisMode string
+
+const (
+	RedisModeStandalone RedisMode = "standalone"
+	RedisModeSentinel   RedisMode = "sentinel"
+	RedisModeCluster    RedisMode = "cluster"
+)
+
 type Redis struct {
-	Host     string `required:"true"        envconfig:"REDIS_ADDR"`
-	Port     string `required:"true"        envconfig:"REDIS_PORT"`
+	Mode RedisMode `envconfig:"REDIS_MODE" default:"standalone"`
+
+	// Standalone server.
+	Host string `envconfig:"REDIS_ADDR"`
+	Port string `envconfig:"REDIS_PORT" default:"6379"`
+
+	// Sentinel addresses, with the name of the monitored master, or Cluster
+	// seed nodes, as host:port.
+	Addrs            []string `envconfig:"REDIS_ADDRS"`
+	MasterName       string   `envconfig:"REDIS_MASTER_NAME"`
+	SentinelPassword string   `envconfig:"REDIS_SENTINEL_PASSWORD"`
+
 	User     string `envconfig:"REDIS_USER"`
 	Password string `required:"true"        envconfig:"REDIS_PASSWORD"`
 	UseTLS   bool   `required:"true"        envconfig:"REDIS_USE_TLS"`
+
+	// Values loaded with GetOrLoad are kept StaleFor past their freshness,
+	// to be served while what they are loaded from is unavailable.
+	StaleFor time.Duration `envconfig:"REDIS_STALE_FOR" default:"24h"`
+
+	// Values are written with Codec (json, msgpack or protobuf) and, from
+	// CompressionThreshold bytes, Compression (none, snappy or zstd). Values
+	// written with any of them are always read.
+	Codec                string `envconfig:"REDIS_CODEC"                 default:"json"`
+	Compression          string `envconfig:"REDIS_COMPRESSION"           default:"none"`
+	CompressionThreshold int    `envconfig:"REDIS_COMPRESSION_THRESHOLD" default:"1024"`
+}
+
+// RedisNamespace returns the prefix of the Redis keys of this service and
+// environment.
+func (c Config) RedisNamespace() string {
+	return c.App.Name + ":" + string(c.Environment) + ":"
 }
 
 // Address returns "host:port" string for connection.
@@ -108,6 +415,18 @@ func (r Redis) Address() string {
 	return r.Host + ":" + r.Port
 }
 
+// LocalCache is an in-memory layer in front of Redis for the hottest keys.
+// Instances tell each other about changed keys over Redis pub/sub; TTL bounds
+// how stale an entry gets when such a message is lost.
+type LocalCache struct {
+	// Only keys starting with one of Prefixes are kept in memory. Disabled
+	// with MaxBytes 0.
+	Prefixes            []string      `envconfig:"LOCAL_CACHE_PREFIXES"             default:"link:"`
+	MaxBytes            int64         `envconfig:"LOCAL_CACHE_MAX_BYTES"            default:"67108864"`
+	TTL                 time.Duration `envconfig:"LOCAL_CACHE_TTL"                  default:"1m"`
+	InvalidationChannel string        `envconfig:"LOCAL_CACHE_INVALIDATION_CHANNEL" default:"cache-invalidation"`
+}
+
 func New() (Config, error) {
 	const operation = "Config.New"
 
@@ -118,5 +437,9 @@ func New() (Config, error) {
 		return Config{}, fmt.Errorf("%s -> %w", operation, err)
 	}
 
+	if err := cfg.CORS.validate(); err != nil {
+		return Config{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
 	return cfg, nil
 }
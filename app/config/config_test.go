@@ -0,0 +1,44 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCORSValidate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cors    CORS
+		wantErr bool
+	}{
+		{
+			name: "any origin without credentials",
+			cors: CORS{AllowedOrigins: []string{"*"}},
+		},
+		{
+			name: "listed origins with credentials",
+			cors: CORS{AllowedOrigins: []string{"https://app.example.com", "https://*.example.com"}, AllowCredentials: true},
+		},
+		{
+			name:    "any origin with credentials",
+			cors:    CORS{AllowedOrigins: []string{"https://app.example.com", " * "}, AllowCredentials: true},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := tt.cors.validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
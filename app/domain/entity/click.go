@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+type Click struct {
+	ID        string
+	LinkID    string
+	Code      string
+	ClickedAt time.Time
+	Referrer  string
+	UserAgent string
+
+	// Where the click came from, empty when unknown.
+	Country string
+	Region  string
+	City    string
+	ASN     uint32
+
+	// What the user agent tells about the client, empty when unknown. Bot
+	// clicks are recorded but left out of the stats.
+	Browser string
+	OS      string
+	Device  string
+	Bot     bool
+}
+
+// ClicksFilter selects the clicks of a time range, optionally only the ones
+// of a user's links or of a single link.
+type ClicksFilter struct {
+	UserID string
+	Code   string
+	From   time.Time
+	To     time.Time
+}
+
+// ClickEvent is a click streamed live to the viewers of a link. IDs order
+// the events of a link, so viewers can resume after the last one they got.
+type ClickEvent struct {
+	ID        string
+	Code      string
+	ClickedAt time.Time
+	Referrer  string
+	Country   string
+	Region    string
+	City      string
+	Browser   string
+	OS        string
+	Device    string
+	Bot       bool
+}
@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+type ExportJobStatus string
+
+const (
+	ExportJobStatusRunning   ExportJobStatus = "running"
+	ExportJobStatusSucceeded ExportJobStatus = "succeeded"
+	ExportJobStatusFailed    ExportJobStatus = "failed"
+)
+
+// ExportJob tracks an export written to a file in the background.
+type ExportJob struct {
+	ID     string          `json:"id"`
+	Kind   string          `json:"kind"`
+	Format string          `json:"format"`
+	Status ExportJobStatus `json:"status"`
+
+	// UserID is the user who started the job, the only one who sees it.
+	UserID string `json:"user_id"`
+
+	// FileName is the exported file in the export store, once succeeded.
+	FileName string `json:"file_name,omitempty"`
+	Rows     int64  `json:"rows"`
+
+	// Error describes why the job failed.
+	Error string `json:"error,omitempty"`
+
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
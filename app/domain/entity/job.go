@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/go-api-template/app/domain/types"
+)
+
+type JobStatus string
+
+const (
+	JobStatusQueued  JobStatus = "queued"
+	JobStatusRunning JobStatus = "running"
+	JobStatusDead    JobStatus = "dead"
+)
+
+// Job is a unit of background work in the jobs queue.
+type Job struct {
+	ID      string
+	Type    types.Job
+	Payload json.RawMessage
+
+	// TraceCarrier holds the trace context of the request that enqueued the
+	// job, so its spans continue the same trace.
+	TraceCarrier map[string]string
+
+	Status      JobStatus
+	Attempts    int
+	MaxAttempts int
+	RunAt       time.Time
+	LastError   string
+	CreatedAt   time.Time
+}
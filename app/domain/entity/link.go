@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"time"
+)
+
+type Link struct {
+	ID        string
+	Code      string
+	UserID    string
+	TargetURL string
+	Tags      []string
+	ExpiresAt *time.Time
+
+	// PasswordHash is set on password-protected links (Argon2id or bcrypt)
+	// read from Postgres. It is never cached nor serialized, so links read
+	// from the cache tell they're protected by PasswordFingerprint.
+	PasswordHash string `json:"-"`
+	// PasswordFingerprint identifies the password of protected links, and
+	// changes with it. Set along with PasswordHash by SetPasswordHash.
+	PasswordFingerprint string
+
+	// RequireSignature links only redirect through signed codes.
+	RequireSignature bool
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// IsExpired reports whether the link had an expiry set and it has passed.
+func (l Link) IsExpired(now time.Time) bool {
+	return l.ExpiresAt != nil && !now.Before(*l.ExpiresAt)
+}
+
+func (l Link) IsPasswordProtected() bool {
+	return l.PasswordFingerprint != ""
+}
+
+// SetPasswordHash sets the password hash of the link and its fingerprint. An
+// empty hash removes the password.
+func (l *Link) SetPasswordHash(hash string) {
+	l.PasswordHash = hash
+	l.PasswordFingerprint = ""
+
+	if hash != "" {
+		sum := sha256.Sum256([]byte(hash))
+		l.PasswordFingerprint = base64.RawURLEncoding.EncodeToString(sum[:16])
+	}
+}
+
+// LinksFilter selects the links of a user.
+type LinksFilter struct {
+	UserID string
+}
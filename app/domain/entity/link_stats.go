@@ -0,0 +1,57 @@
+package entity
+
+import "time"
+
+// LinkStats are the click counts of a link flushed into Postgres.
+type LinkStats struct {
+	LinkID string
+
+	// Clicks counted on redirects, and the visitors estimated among them.
+	// Bot clicks are not counted.
+	Clicks         int64
+	UniqueVisitors int64
+
+	// RecordedClicks, not from bots, were in the clicks table when the counts
+	// were last flushed, at ReconciledAt. They drift from Clicks when either store
+	// misses clicks.
+	RecordedClicks int64
+	ReconciledAt   *time.Time
+
+	Breakdown ClickBreakdown
+}
+
+// ClickBreakdown breaks down the recorded clicks of a link by where they came
+// from and the clients they came from, most clicks first. Bot clicks are only
+// counted in BotClicks.
+type ClickBreakdown struct {
+	Countries []LocationClicks
+	Cities    []LocationClicks
+	Browsers  []ValueClicks
+	OSes      []ValueClicks
+	Devices   []ValueClicks
+	BotClicks int64
+}
+
+// LocationClicks are the recorded clicks from a location. Only the fields
+// the clicks are grouped by are set.
+type LocationClicks struct {
+	Country string
+	Region  string
+	City    string
+	Clicks  int64
+}
+
+// ValueClicks are the recorded clicks with a value, e.g. from a browser.
+type ValueClicks struct {
+	Value  string
+	Clicks int64
+}
+
+// ClickCounts are the live click counts of a link kept in Redis: the clicks
+// not flushed into Postgres yet, and the visitors estimated since counting
+// started.
+type ClickCounts struct {
+	LinkID         string
+	Clicks         int64
+	UniqueVisitors int64
+}
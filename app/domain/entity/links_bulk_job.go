@@ -0,0 +1,43 @@
+package entity
+
+import "time"
+
+type LinksBulkJobStatus string
+
+const (
+	LinksBulkJobStatusRunning   LinksBulkJobStatus = "running"
+	LinksBulkJobStatusSucceeded LinksBulkJobStatus = "succeeded"
+	LinksBulkJobStatusFailed    LinksBulkJobStatus = "failed"
+)
+
+// LinksBulkJob tracks a bulk creation that runs in the background.
+type LinksBulkJob struct {
+	ID string `json:"id"`
+
+	// UserID is the user who started the job, the only one who sees it.
+	UserID string `json:"user_id"`
+
+	Status LinksBulkJobStatus `json:"status"`
+	Total  int                `json:"total"`
+
+	// Results are set as rows are done, in the order of the rows; retries of
+	// the job resume after them.
+	Results []LinksBulkResult `json:"results,omitempty"`
+
+	// Error describes why the whole job failed.
+	Error string `json:"error,omitempty"`
+
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+// LinksBulkResult is the outcome of a single row of a bulk creation: either
+// the created link or the error that rejected the row.
+type LinksBulkResult struct {
+	Index int   `json:"index"`
+	Link  *Link `json:"link,omitempty"`
+
+	// Error is the code and message of the domain error of the row.
+	ErrorCode    string `json:"error_code,omitempty"`
+	ErrorMessage string `json:"error_message,omitempty"`
+}
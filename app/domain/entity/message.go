@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/go-api-template/app/domain/types"
+)
+
+type MessageChannel string
+
+const (
+	MessageChannelSMS      MessageChannel = "sms"
+	MessageChannelWhatsApp MessageChannel = "whatsapp"
+)
+
+// MessageStatus follows the delivery statuses reported by Twilio, plus
+// pending for messages not handed to it yet.
+type MessageStatus string
+
+const (
+	MessageStatusPending     MessageStatus = "pending"
+	MessageStatusQueued      MessageStatus = "queued"
+	MessageStatusSending     MessageStatus = "sending"
+	MessageStatusSent        MessageStatus = "sent"
+	MessageStatusUndelivered MessageStatus = "undelivered"
+	MessageStatusFailed      MessageStatus = "failed"
+	MessageStatusDelivered   MessageStatus = "delivered"
+	MessageStatusRead        MessageStatus = "read"
+)
+
+// Message is a templated message sent to a user.
+type Message struct {
+	ID        string
+	UserID    string
+	LinkID    string
+	Channel   MessageChannel
+	To        string
+	Template  types.TwilioTemplate
+	Variables map[string]string
+
+	// ProviderID is the message SID given by Twilio once sent.
+	ProviderID string
+	Status     MessageStatus
+	ErrorCode  string
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,23 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/go-api-template/app/domain/types"
+)
+
+// OutboxEvent is a domain event committed in the same transaction as the
+// change it describes, and relayed to its handlers afterwards.
+type OutboxEvent struct {
+	ID string
+	// Seq orders the events of the outbox.
+	Seq         int64
+	Type        types.Event
+	AggregateID string
+	Payload     json.RawMessage
+
+	// Attempts counts the failed relays of the event.
+	Attempts  int
+	CreatedAt time.Time
+}
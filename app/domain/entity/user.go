@@ -6,6 +6,14 @@ type User struct {
 	ID   string
 	Name string
 
+	// Phone in E.164 format, where short links are sent over MessageChannel.
+	Phone          string
+	MessageChannel MessageChannel
+
+	// APIKeyHash is the hash of the API key the user authenticates with. It
+	// is never cached nor serialized.
+	APIKeyHash string `json:"-"`
+
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
@@ -0,0 +1,70 @@
+package entity
+
+import (
+	"encoding/json"
+	"slices"
+	"time"
+
+	"github.com/go-api-template/app/domain/types"
+)
+
+// Webhook is a user's subscription to link events, delivered to URL and
+// signed with Secret.
+type Webhook struct {
+	ID     string
+	UserID string
+	URL    string
+	Secret string
+	Events []types.WebhookEvent
+
+	// Enabled is cleared after too many failed deliveries in a row.
+	Enabled             bool
+	ConsecutiveFailures int
+	DisabledAt          *time.Time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func (w Webhook) IsSubscribed(event types.WebhookEvent) bool {
+	return slices.Contains(w.Events, event)
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryStatusPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliveryStatusSucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryStatusFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is an event sent, or to be sent, to a webhook.
+type WebhookDelivery struct {
+	ID        string
+	WebhookID string
+	EventID   string
+	EventType types.WebhookEvent
+	Payload   json.RawMessage
+
+	Status   WebhookDeliveryStatus
+	Attempts int
+
+	// Outcome of the last attempt.
+	ResponseStatus int
+	ResponseBody   string
+	Error          string
+
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+	DeliveredAt *time.Time
+}
+
+// WebhookDeliveriesFilter selects the deliveries of a webhook, newest first.
+type WebhookDeliveriesFilter struct {
+	WebhookID string
+	Status    WebhookDeliveryStatus
+
+	// Before is the ID of the last delivery of the previous page.
+	Before string
+	Limit  int
+}
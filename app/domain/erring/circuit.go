@@ -0,0 +1,7 @@
+package erring
+
+var (
+	ErrCircuitNotFound    = NewAppError("circuit:not-found", "circuit not found")
+	ErrCircuitModeInvalid = NewAppError("circuit:mode-invalid", "circuit mode must be one of auto, forced-open or forced-closed")
+	ErrCircuitProtected   = NewAppError("circuit:protected", "circuit mode cannot be changed")
+)
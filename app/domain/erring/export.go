@@ -0,0 +1,8 @@
+package erring
+
+var (
+	ErrExportJobNotFound  = NewAppError("export:job-not-found", "export job not found")
+	ErrExportNotReady     = NewAppError("export:not-ready", "export is not ready yet")
+	ErrExportRangeInvalid = NewAppError("export:range-invalid", "export range is invalid")
+	ErrFileNotFound       = NewAppError("file:not-found", "file not found")
+)
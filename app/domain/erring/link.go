@@ -0,0 +1,14 @@
+package erring
+
+var (
+	ErrLinkNotFound          = NewAppError("link:not-found", "link not found")
+	ErrLinkExpired           = NewAppError("link:expired", "link has expired")
+	ErrLinkCodeAlreadyExists = NewAppError("link:code-already-exists", "link code is already in use")
+	ErrLinkPasswordInvalid   = NewAppError("link:password-invalid", "link password is incorrect")
+	ErrLinkUnlockRateLimited = NewAppError("link:unlock-rate-limited", "too many unlock attempts, try again later")
+	// Forged signed codes look like unknown links, so they can't be probed.
+	ErrLinkSignatureInvalid     = NewAppError("link:signature-invalid", "link not found")
+	ErrLinkSigningNotConfigured = NewAppError("link:signing-not-configured", "link signing is not configured")
+	ErrLinksBulkTooLarge        = NewAppError("link:bulk-too-large", "too many links in a single bulk request")
+	ErrLinksBulkJobNotFound     = NewAppError("link:bulk-job-not-found", "bulk job not found")
+)
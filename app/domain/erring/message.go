@@ -0,0 +1,7 @@
+package erring
+
+var (
+	ErrMessageNotFound         = NewAppError("message:not-found", "message not found")
+	ErrMessageRejected         = NewAppError("message:rejected", "message rejected by the provider")
+	ErrMessageSignatureInvalid = NewAppError("message:signature-invalid", "invalid request signature")
+)
@@ -7,4 +7,5 @@ var (
 	ErrEventInvalid          = NewAppError("event:invalid", "invalid event")
 	ErrRequestInvalid        = NewAppError("request:invalid", "invalid request")
 	ErrClientResponseInvalid = NewAppError("client-response:invalid", "client response invalid")
+	ErrDependencyUnavailable = NewAppError("dependency:unavailable", "dependency temporarily unavailable")
 )
@@ -0,0 +1,10 @@
+package erring
+
+// Destination URLs rejected by the safety checks.
+var (
+	ErrURLInvalid          = NewAppError("url:invalid", "destination url is not a valid absolute url")
+	ErrURLSchemeNotAllowed = NewAppError("url:scheme-not-allowed", "destination url scheme is not allowed")
+	ErrURLHostNotAllowed   = NewAppError("url:host-not-allowed", "destination url points to a private or internal host")
+	ErrURLBlocked          = NewAppError("url:blocked", "destination url domain is blocked")
+	ErrURLRedirectLoop     = NewAppError("url:redirect-loop", "destination url points back to this service")
+)
@@ -1,3 +1,7 @@
 package erring
 
-var ErrUserNotFound = NewAppError("user:not-found", "user not found")
+var (
+	ErrUserNotFound        = NewAppError("user:not-found", "user not found")
+	ErrUserUnauthenticated = NewAppError("user:unauthenticated", "missing or invalid api key")
+	ErrUserForbidden       = NewAppError("user:forbidden", "only the owner can do this")
+)
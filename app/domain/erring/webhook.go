@@ -0,0 +1,6 @@
+package erring
+
+var (
+	ErrWebhookNotFound         = NewAppError("webhook:not-found", "webhook not found")
+	ErrWebhookDeliveryNotFound = NewAppError("webhook:delivery-not-found", "webhook delivery not found")
+)
@@ -0,0 +1,11 @@
+package types
+
+// Event is a domain event written to the outbox along with the change that
+// caused it.
+type Event string
+
+const (
+	EventUserCreated Event = "user.created"
+	EventLinkCreated Event = "link.created"
+	EventLinkUpdated Event = "link.updated"
+)
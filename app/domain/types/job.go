@@ -3,5 +3,8 @@ package types
 type Job string
 
 const (
-	SendMessage Job = "send-message"
+	SendMessage    Job = "send-message"
+	ExportData     Job = "export-data"
+	DeliverWebhook Job = "deliver-webhook"
+	CreateLinks    Job = "create-links"
 )
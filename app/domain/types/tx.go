@@ -0,0 +1,10 @@
+package types
+
+// TxIsolation is the isolation level of a transaction.
+type TxIsolation string
+
+const (
+	ReadCommitted  TxIsolation = "read committed"
+	RepeatableRead TxIsolation = "repeatable read"
+	Serializable   TxIsolation = "serializable"
+)
@@ -0,0 +1,13 @@
+package types
+
+type WebhookEvent string
+
+const (
+	LinkCreated WebhookEvent = "link.created"
+	LinkUpdated WebhookEvent = "link.updated"
+	LinkExpired WebhookEvent = "link.expired"
+	LinkClicked WebhookEvent = "link.clicked"
+)
+
+// WebhookEvents lists the events webhooks can subscribe to.
+var WebhookEvents = []WebhookEvent{LinkCreated, LinkUpdated, LinkExpired, LinkClicked}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/library/password"
+	"github.com/go-api-template/app/library/util"
+)
+
+// How many random codes are tried before giving up on collisions.
+const createLinkMaxAttempts = 5
+
+type CreateLinkInput struct {
+	Link entity.Link
+
+	// Alias is used as the code when set, instead of a random one.
+	Alias string
+
+	// Password protects the link behind an unlock page when set.
+	Password string
+}
+
+type CreateLinkOutput struct {
+	Link entity.Link
+}
+
+func (u *UseCase) CreateLink(ctx context.Context, input CreateLinkInput) (CreateLinkOutput, error) {
+	const operation = "UseCase.CreateLink"
+
+	link, err := u.prepareLink(ctx, input)
+	if err != nil {
+		return CreateLinkOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	for attempt := 1; ; attempt++ {
+		id, err := uuid.NewV7()
+		if err != nil {
+			return CreateLinkOutput{}, fmt.Errorf("%s -> %w", operation, err)
+		}
+
+		link.ID = id.String()
+
+		link.Code, err = u.newLinkCode(input.Alias)
+		if err != nil {
+			return CreateLinkOutput{}, fmt.Errorf("%s -> %w", operation, err)
+		}
+
+		err = u.LinksRepository.Create(ctx, link)
+		if err == nil {
+			break
+		}
+
+		// Random codes may collide; aliases are the caller's choice.
+		if !errors.Is(err, erring.ErrLinkCodeAlreadyExists) || input.Alias != "" || attempt == createLinkMaxAttempts {
+			return CreateLinkOutput{}, fmt.Errorf("%s -> %w", operation, err)
+		}
+	}
+
+	return CreateLinkOutput{
+		Link: link,
+	}, nil
+}
+
+// prepareLink checks the target URL and hashes the password of a new link.
+func (u *UseCase) prepareLink(ctx context.Context, input CreateLinkInput) (entity.Link, error) {
+	targetURL, err := u.URLChecker.Check(ctx, input.Link.TargetURL)
+	if err != nil {
+		return entity.Link{}, err //nolint:wrapcheck
+	}
+
+	link := input.Link
+	link.TargetURL = targetURL
+
+	if input.Password != "" {
+		hash, err := password.Hash(input.Password)
+		if err != nil {
+			return entity.Link{}, err //nolint:wrapcheck
+		}
+
+		link.SetPasswordHash(hash)
+	}
+
+	return link, nil
+}
+
+func (u *UseCase) newLinkCode(alias string) (string, error) {
+	if alias != "" {
+		return alias, nil
+	}
+
+	return util.RandomCode(u.LinkCodeLength) //nolint:wrapcheck
+}
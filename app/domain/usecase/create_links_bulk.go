@@ -0,0 +1,410 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/domain/types"
+	"github.com/go-api-template/app/library/password"
+)
+
+const (
+	linksBulkJobCacheKeyPrefix = "links-bulk-job:"
+	linksBulkJobTTL            = 24 * time.Hour
+
+	// Rows prepared at once, as checking their target URL mostly waits on
+	// DNS.
+	linksBulkPrepareConcurrency = 16
+
+	// Rows of background jobs created per transaction, so each stays well
+	// within the write circuit timeout.
+	linksBulkJobBatchSize = 500
+)
+
+type CreateLinksBulkInput struct {
+	Rows []LinksBulkRow
+
+	// CallerID is the user making the request, empty when anonymous. Only
+	// users can create links in the background, as only the user who started
+	// a job can poll it.
+	CallerID string
+}
+
+// LinksBulkRow is a row of a bulk creation. Rows rejected while parsing the
+// request carry the erring.AppError that rejected them in Err and are only
+// reported back.
+type LinksBulkRow struct {
+	Link CreateLinkInput
+	Err  error
+}
+
+type CreateLinksBulkOutput struct {
+	// Results are set when the rows were created right away.
+	Results []entity.LinksBulkResult
+
+	// Job is set instead when the rows are created in the background; it is
+	// polled with GetLinksBulkJob.
+	Job *entity.LinksBulkJob
+}
+
+// CreateLinksBulk creates many links at once. Every row succeeds or fails on
+// its own, but all links are inserted in a single transaction. Requests with
+// more than LinkBulkAsyncThreshold rows run in the background instead, as a
+// types.CreateLinks job.
+func (u *UseCase) CreateLinksBulk(ctx context.Context, input CreateLinksBulkInput) (CreateLinksBulkOutput, error) {
+	const operation = "UseCase.CreateLinksBulk"
+
+	if len(input.Rows) > u.LinkBulkMaxRows {
+		return CreateLinksBulkOutput{}, fmt.Errorf("%s (%d rows) -> %w", operation, len(input.Rows), erring.ErrLinksBulkTooLarge)
+	}
+
+	if len(input.Rows) <= u.LinkBulkAsyncThreshold {
+		results := make([]entity.LinksBulkResult, len(input.Rows))
+
+		err := u.createLinksBulk(ctx, input.Rows, results, 0, len(input.Rows), nil)
+		if err != nil {
+			return CreateLinksBulkOutput{}, fmt.Errorf("%s -> %w", operation, err)
+		}
+
+		return CreateLinksBulkOutput{
+			Results: results,
+		}, nil
+	}
+
+	if input.CallerID == "" {
+		return CreateLinksBulkOutput{}, fmt.Errorf("%s -> %w", operation, erring.ErrUserUnauthenticated)
+	}
+
+	job, err := u.startLinksBulkJob(ctx, input.Rows, input.CallerID)
+	if err != nil {
+		return CreateLinksBulkOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return CreateLinksBulkOutput{
+		Job: &job,
+	}, nil
+}
+
+func (u *UseCase) startLinksBulkJob(ctx context.Context, rows []LinksBulkRow, userID string) (entity.LinksBulkJob, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return entity.LinksBulkJob{}, err //nolint:wrapcheck
+	}
+
+	job := entity.LinksBulkJob{
+		ID:        id.String(),
+		UserID:    userID,
+		Status:    entity.LinksBulkJobStatusRunning,
+		Total:     len(rows),
+		CreatedAt: time.Now(),
+	}
+
+	// Without Redis the job could never be polled, so don't start it.
+	if err := u.Cache.Set(ctx, linksBulkJobCacheKeyPrefix+job.ID, job, linksBulkJobTTL); err != nil {
+		return entity.LinksBulkJob{}, err //nolint:wrapcheck
+	}
+
+	input := RunLinksBulkJobInput{
+		JobID: job.ID,
+		Rows:  make([]LinksBulkJobRow, len(rows)),
+	}
+
+	for i, row := range rows {
+		input.Rows[i] = LinksBulkJobRow{
+			Link:  row.Link.Link,
+			Alias: row.Link.Alias,
+		}
+
+		if row.Err != nil {
+			var result entity.LinksBulkResult
+			setLinksBulkError(&result, row.Err)
+
+			input.Rows[i].Err = &erring.AppError{Code: result.ErrorCode, Message: result.ErrorMessage}
+
+			continue
+		}
+
+		if row.Link.Password != "" {
+			input.Rows[i].PasswordHash, err = password.Hash(row.Link.Password)
+			if err != nil {
+				_ = u.finishLinksBulkJob(ctx, job, entity.LinksBulkJobStatusFailed, nil)
+
+				return entity.LinksBulkJob{}, err //nolint:wrapcheck
+			}
+		}
+	}
+
+	if err := u.Jobs.Enqueue(ctx, types.CreateLinks, input); err != nil {
+		// Pollers would otherwise see it running until it expires.
+		_ = u.finishLinksBulkJob(ctx, job, entity.LinksBulkJobStatusFailed, nil)
+
+		return entity.LinksBulkJob{}, err //nolint:wrapcheck
+	}
+
+	return job, nil
+}
+
+// RunLinksBulkJobInput is the payload of types.CreateLinks jobs. Passwords
+// are hashed before being queued, as payloads outlive failed jobs.
+type RunLinksBulkJobInput struct {
+	JobID string            `json:"job_id"`
+	Rows  []LinksBulkJobRow `json:"rows"`
+}
+
+// LinksBulkJobRow is a LinksBulkRow as queued.
+type LinksBulkJobRow struct {
+	Link         entity.Link      `json:"link"`
+	Alias        string           `json:"alias,omitempty"`
+	PasswordHash string           `json:"password_hash,omitempty"`
+	Err          *erring.AppError `json:"error,omitempty"`
+}
+
+// RunLinksBulkJob creates the links of a background bulk creation. It runs
+// on the workers, as the handler of types.CreateLinks jobs. Links are
+// inserted in batches of linksBulkJobBatchSize rows, each in a transaction,
+// and the results of the job saved after each, so attempts resume after the
+// last batch saved. Failing to save them creates that batch again.
+func (u *UseCase) RunLinksBulkJob(ctx context.Context, input RunLinksBulkJobInput) error {
+	const operation = "UseCase.RunLinksBulkJob"
+
+	job, err := u.getLinksBulkJob(ctx, input.JobID)
+	if errors.Is(err, erring.ErrLinksBulkJobNotFound) {
+		// Nobody can poll it anymore.
+		slog.WarnContext(ctx, fmt.Sprintf("%s (%s) -> job expired before running", operation, input.JobID))
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, input.JobID, err)
+	}
+
+	rows := make([]LinksBulkRow, len(input.Rows))
+	for i, row := range input.Rows {
+		rows[i].Link = CreateLinkInput{
+			Link:  row.Link,
+			Alias: row.Alias,
+		}
+
+		if row.PasswordHash != "" {
+			rows[i].Link.Link.SetPasswordHash(row.PasswordHash)
+		}
+
+		if row.Err != nil {
+			rows[i].Err = *row.Err
+		}
+	}
+
+	results := make([]entity.LinksBulkResult, len(rows))
+	copy(results, job.Results)
+
+	err = u.createLinksBulk(ctx, rows, results, len(job.Results), linksBulkJobBatchSize, func(done int) error {
+		job.Results = results[:done]
+
+		return u.Cache.Set(ctx, linksBulkJobCacheKeyPrefix+job.ID, job, linksBulkJobTTL) //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, input.JobID, err)
+	}
+
+	if err := u.finishLinksBulkJob(ctx, job, entity.LinksBulkJobStatusSucceeded, results); err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, input.JobID, err)
+	}
+
+	return nil
+}
+
+// FailLinksBulkJob marks a background bulk creation failed. It runs on the
+// workers, once a types.CreateLinks job is dead-lettered: pollers only learn
+// about the failure once there are no retries left.
+func (u *UseCase) FailLinksBulkJob(ctx context.Context, input RunLinksBulkJobInput) error {
+	const operation = "UseCase.FailLinksBulkJob"
+
+	job, err := u.getLinksBulkJob(ctx, input.JobID)
+	if errors.Is(err, erring.ErrLinksBulkJobNotFound) {
+		return nil
+	}
+
+	if err == nil {
+		err = u.finishLinksBulkJob(ctx, job, entity.LinksBulkJobStatusFailed, nil)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, input.JobID, err)
+	}
+
+	return nil
+}
+
+func (u *UseCase) finishLinksBulkJob(ctx context.Context, job entity.LinksBulkJob, status entity.LinksBulkJobStatus, results []entity.LinksBulkResult) error {
+	finishedAt := time.Now()
+	job.FinishedAt = &finishedAt
+	job.Status = status
+	job.Results = results
+
+	if status == entity.LinksBulkJobStatusFailed {
+		job.Error = "links could not be created, try again later"
+	}
+
+	return u.Cache.Set(ctx, linksBulkJobCacheKeyPrefix+job.ID, job, linksBulkJobTTL) //nolint:wrapcheck
+}
+
+// createLinksBulk creates the links of rows[from:], setting their results,
+// in batches of batchSize rows inserted in a transaction each. saved, when
+// set, is called after each batch with the number of rows done.
+func (u *UseCase) createLinksBulk(
+	ctx context.Context,
+	rows []LinksBulkRow,
+	results []entity.LinksBulkResult,
+	from int,
+	batchSize int,
+	saved func(done int) error,
+) error {
+	links := make([]entity.Link, len(rows))
+
+	for start := from; start < len(rows); start += batchSize {
+		end := min(start+batchSize, len(rows))
+
+		pending, err := u.prepareLinksBulk(ctx, rows, links, results, start, end)
+		if err != nil {
+			return err
+		}
+
+		// Rows whose random code collided are retried with a new code. The
+		// retries run in the same transaction, so either all rows of the
+		// batch are created or none.
+		err = u.TxManager.WithinTx(ctx, func(ctx context.Context) error {
+			return u.insertLinksBulk(ctx, rows, links, results, pending)
+		})
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		if saved != nil {
+			if err := saved(end); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// prepareLinksBulk prepares the links of rows[start:end] concurrently and
+// returns the indexes of the ones to insert. The results of the rows
+// rejected are set.
+func (u *UseCase) prepareLinksBulk(ctx context.Context, rows []LinksBulkRow, links []entity.Link, results []entity.LinksBulkResult, start, end int) ([]int, error) {
+	group, groupCtx := errgroup.WithContext(ctx)
+	group.SetLimit(linksBulkPrepareConcurrency)
+
+	for i := start; i < end; i++ {
+		results[i] = entity.LinksBulkResult{Index: i}
+
+		if rows[i].Err != nil {
+			setLinksBulkError(&results[i], rows[i].Err)
+
+			continue
+		}
+
+		group.Go(func() error {
+			link, err := u.prepareLink(groupCtx, rows[i].Link)
+			if err != nil {
+				var appError erring.AppError
+				if !errors.As(err, &appError) {
+					return err
+				}
+
+				setLinksBulkError(&results[i], err)
+
+				return nil
+			}
+
+			links[i] = link
+
+			return nil
+		})
+	}
+
+	if err := group.Wait(); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	pending := make([]int, 0, end-start)
+
+	for i := start; i < end; i++ {
+		if results[i].ErrorCode == "" {
+			pending = append(pending, i)
+		}
+	}
+
+	return pending, nil
+}
+
+// insertLinksBulk inserts the pending links, retrying the ones whose random
+// code collided, and sets their results. It starts over when the
+// transaction is run again.
+func (u *UseCase) insertLinksBulk(ctx context.Context, rows []LinksBulkRow, links []entity.Link, results []entity.LinksBulkResult, pending []int) error {
+	for _, i := range pending {
+		results[i] = entity.LinksBulkResult{Index: i}
+	}
+
+	for attempt := 1; len(pending) > 0; attempt++ {
+		batch := make([]entity.Link, len(pending))
+
+		for j, i := range pending {
+			id, err := uuid.NewV7()
+			if err != nil {
+				return err //nolint:wrapcheck
+			}
+
+			links[i].ID = id.String()
+
+			code, err := u.newLinkCode(rows[i].Link.Alias)
+			if err != nil {
+				return err
+			}
+
+			links[i].Code = code
+			batch[j] = links[i]
+		}
+
+		created, err := u.LinksRepository.CreateMany(ctx, batch)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		retry := make([]int, 0)
+
+		for j, i := range pending {
+			switch {
+			case created[j]:
+				link := links[i]
+				results[i].Link = &link
+			case rows[i].Link.Alias != "" || attempt == createLinkMaxAttempts:
+				setLinksBulkError(&results[i], erring.ErrLinkCodeAlreadyExists)
+			default:
+				retry = append(retry, i)
+			}
+		}
+
+		pending = retry
+	}
+
+	return nil
+}
+
+func setLinksBulkError(result *entity.LinksBulkResult, err error) {
+	appError := erring.ErrRequestInvalid
+	errors.As(err, &appError)
+
+	result.ErrorCode = appError.Code
+	result.ErrorMessage = appError.Message
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/library/apikey"
 )
 
 type CreateUserInput struct {
@@ -15,6 +16,10 @@ type CreateUserInput struct {
 
 type CreateUserOutput struct {
 	UserID string
+
+	// APIKey authenticates the user. Only its hash is stored, so it can't be
+	// shown again.
+	APIKey string
 }
 
 func (u *UseCase) CreateUser(ctx context.Context, input CreateUserInput) (CreateUserOutput, error) {
@@ -24,12 +29,20 @@ func (u *UseCase) CreateUser(ctx context.Context, input CreateUserInput) (Create
 
 	input.User.ID = uuid.String()
 
-	err := u.UsersRepository.Create(ctx, input.User)
+	key, hash, err := apikey.New()
+	if err != nil {
+		return CreateUserOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	input.User.APIKeyHash = hash
+
+	err = u.UsersRepository.Create(ctx, input.User)
 	if err != nil {
 		return CreateUserOutput{}, fmt.Errorf("%s -> %w", operation, err)
 	}
 
 	return CreateUserOutput{
 		UserID: input.User.ID,
+		APIKey: key,
 	}, nil
 }
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/google/uuid"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/library/webhook"
+)
+
+type CreateWebhookInput struct {
+	Webhook entity.Webhook
+}
+
+type CreateWebhookOutput struct {
+	// Webhook holds the generated secret, which is only returned here.
+	Webhook entity.Webhook
+}
+
+func (u *UseCase) CreateWebhook(ctx context.Context, input CreateWebhookInput) (CreateWebhookOutput, error) {
+	const operation = "UseCase.CreateWebhook"
+
+	hook := input.Webhook
+
+	if _, err := u.UsersRepository.GetUserByID(ctx, hook.UserID); err != nil {
+		return CreateWebhookOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	var err error
+
+	hook.URL, err = u.checkWebhookURL(ctx, hook.URL)
+	if err != nil {
+		return CreateWebhookOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	hook.Secret, err = webhook.NewSecret()
+	if err != nil {
+		return CreateWebhookOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return CreateWebhookOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	hook.ID = id.String()
+
+	if err := u.WebhooksRepository.Create(ctx, hook); err != nil {
+		return CreateWebhookOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	hook, err = u.WebhooksRepository.GetWebhookByID(ctx, hook.ID)
+	if err != nil {
+		return CreateWebhookOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return CreateWebhookOutput{
+		Webhook: hook,
+	}, nil
+}
+
+// checkWebhookURL applies the destination URL checks of links to webhook
+// URLs, unless private networks are allowed for local development.
+func (u *UseCase) checkWebhookURL(ctx context.Context, rawURL string) (string, error) {
+	if !u.WebhookAllowPrivateNetworks {
+		return u.URLChecker.Check(ctx, rawURL) //nolint:wrapcheck
+	}
+
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Host == "" {
+		return "", erring.ErrURLInvalid
+	}
+
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return "", erring.ErrURLSchemeNotAllowed
+	}
+
+	return parsed.String(), nil
+}
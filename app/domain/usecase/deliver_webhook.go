@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/library/ctxkey"
+)
+
+type DeliverWebhookInput struct {
+	DeliveryID string `json:"delivery_id"`
+}
+
+// DeliverWebhook sends a pending delivery to its webhook. Failed attempts are
+// retried by the job queue; once the last one fails, the delivery is marked
+// failed and counts towards disabling the webhook.
+func (u *UseCase) DeliverWebhook(ctx context.Context, input DeliverWebhookInput) error {
+	const operation = "UseCase.DeliverWebhook"
+
+	delivery, err := u.WebhooksRepository.GetDeliveryByID(ctx, input.DeliveryID)
+	if err != nil {
+		// Deliveries go away with their webhook.
+		if errors.Is(err, erring.ErrWebhookDeliveryNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("%s (%s) -> %w", operation, input.DeliveryID, err)
+	}
+
+	if delivery.Status != entity.WebhookDeliveryStatusPending {
+		return nil
+	}
+
+	hook, err := u.WebhooksRepository.GetWebhookByID(ctx, delivery.WebhookID)
+	if err != nil {
+		if errors.Is(err, erring.ErrWebhookNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("%s (%s) -> %w", operation, input.DeliveryID, err)
+	}
+
+	if !hook.Enabled {
+		delivery.Status, delivery.Error = entity.WebhookDeliveryStatusFailed, "webhook disabled"
+
+		if err := u.WebhooksRepository.RecordAttempt(ctx, delivery); err != nil {
+			return fmt.Errorf("%s (%s) -> %w", operation, input.DeliveryID, err)
+		}
+
+		return nil
+	}
+
+	var sendErr error
+
+	delivery.ResponseStatus, delivery.ResponseBody, sendErr = u.WebhookSender.Deliver(ctx, hook, delivery)
+	if sendErr == nil {
+		delivery.Status = entity.WebhookDeliveryStatusSucceeded
+
+		if err := u.WebhooksRepository.RecordAttempt(ctx, delivery); err != nil {
+			return fmt.Errorf("%s (%s) -> %w", operation, input.DeliveryID, err)
+		}
+
+		if hook.ConsecutiveFailures > 0 {
+			if err := u.WebhooksRepository.ResetFailures(ctx, hook.ID); err != nil {
+				return fmt.Errorf("%s (%s) -> %w", operation, input.DeliveryID, err)
+			}
+		}
+
+		return nil
+	}
+
+	lastAttempt, _ := ctxkey.GetJobLastAttempt(ctx)
+
+	delivery.Error = sendErr.Error()
+	if lastAttempt {
+		delivery.Status = entity.WebhookDeliveryStatusFailed
+	}
+
+	if err := u.WebhooksRepository.RecordAttempt(ctx, delivery); err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, input.DeliveryID, errors.Join(sendErr, err))
+	}
+
+	if lastAttempt {
+		disabled, err := u.WebhooksRepository.RecordFailure(ctx, hook.ID, u.WebhookDisableAfterFailures)
+		if err != nil {
+			return fmt.Errorf("%s (%s) -> %w", operation, input.DeliveryID, errors.Join(sendErr, err))
+		}
+
+		if disabled {
+			slog.WarnContext(ctx, fmt.Sprintf("%s (%s) -> webhook %s disabled after %d failed deliveries", operation, input.DeliveryID, hook.ID, u.WebhookDisableAfterFailures))
+		}
+	}
+
+	return fmt.Errorf("%s (%s) -> %w", operation, input.DeliveryID, sendErr)
+}
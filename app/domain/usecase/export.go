@@ -0,0 +1,287 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/domain/types"
+	"github.com/go-api-template/app/library/export"
+)
+
+const (
+	exportJobCacheKeyPrefix = "export-job:"
+
+	exportKindLinks  = "links"
+	exportKindClicks = "clicks"
+)
+
+// ExportRequest selects what to export. Background exports carry it in their
+// job payload.
+type ExportRequest struct {
+	Kind   string               `json:"kind"`
+	Format export.Format        `json:"format"`
+	Links  *entity.LinksFilter  `json:"links,omitempty"`
+	Clicks *entity.ClicksFilter `json:"clicks,omitempty"`
+}
+
+// ExportOutput is either a stream of the exported rows or, for large and
+// Parquet exports, the background job writing them to a file.
+type ExportOutput struct {
+	Stream      io.ReadCloser
+	ContentType string
+	FileName    string
+
+	Job *entity.ExportJob
+}
+
+// exportSource describes the rows of an export.
+type exportSource struct {
+	columns []export.Column
+	count   func(ctx context.Context) (int64, error)
+	write   func(ctx context.Context, w export.Writer) (int64, error)
+}
+
+// userID returns the user whose data is exported.
+func (r ExportRequest) userID() string {
+	switch {
+	case r.Links != nil:
+		return r.Links.UserID
+	case r.Clicks != nil:
+		return r.Clicks.UserID
+	default:
+		return ""
+	}
+}
+
+func (u *UseCase) exportSource(request ExportRequest) (exportSource, error) {
+	switch {
+	case request.Kind == exportKindLinks && request.Links != nil:
+		return u.linksExportSource(*request.Links), nil
+	case request.Kind == exportKindClicks && request.Clicks != nil:
+		return u.clicksExportSource(*request.Clicks), nil
+	default:
+		return exportSource{}, fmt.Errorf("unknown export %q", request.Kind)
+	}
+}
+
+func (u *UseCase) export(ctx context.Context, request ExportRequest) (ExportOutput, error) {
+	format := request.Format
+	if !format.IsValid() {
+		return ExportOutput{}, errors.Join(export.ErrFormatInvalid, erring.ErrRequestInvalid)
+	}
+
+	source, err := u.exportSource(request)
+	if err != nil {
+		return ExportOutput{}, err
+	}
+
+	async := !format.Streamable()
+	if !async {
+		count, err := source.count(ctx)
+		if err != nil {
+			return ExportOutput{}, err
+		}
+
+		async = count > u.ExportAsyncThreshold
+	}
+
+	if async {
+		return u.startExportJob(ctx, request)
+	}
+
+	// Rows are written while the client reads them. The handler context ends
+	// when it returns, and a client going away closes the reader, which stops
+	// the export.
+	reader, writer := io.Pipe()
+
+	go func(ctx context.Context) {
+		_, err := writeExport(ctx, writer, format, source)
+		writer.CloseWithError(err)
+	}(context.WithoutCancel(ctx))
+
+	return ExportOutput{
+		Stream:      reader,
+		ContentType: format.ContentType(),
+		FileName:    request.Kind + format.Extension(),
+	}, nil
+}
+
+func (u *UseCase) startExportJob(ctx context.Context, request ExportRequest) (ExportOutput, error) {
+	id, err := uuid.NewV7()
+	if err != nil {
+		return ExportOutput{}, err //nolint:wrapcheck
+	}
+
+	job := entity.ExportJob{
+		ID:        id.String(),
+		Kind:      request.Kind,
+		Format:    string(request.Format),
+		Status:    entity.ExportJobStatusRunning,
+		UserID:    request.userID(),
+		CreatedAt: time.Now(),
+	}
+
+	// Without Redis the job could never be polled, so don't start it.
+	if err := u.Cache.Set(ctx, exportJobCacheKeyPrefix+job.ID, job, u.ExportJobTTL); err != nil {
+		return ExportOutput{}, err //nolint:wrapcheck
+	}
+
+	input := RunExportJobInput{
+		JobID:   job.ID,
+		Request: request,
+	}
+
+	if err := u.Jobs.Enqueue(ctx, types.ExportData, input); err != nil {
+		// Pollers would otherwise see it running until it expires.
+		_ = u.finishExportJob(ctx, job, entity.ExportJobStatusFailed, "", 0)
+
+		return ExportOutput{}, err //nolint:wrapcheck
+	}
+
+	return ExportOutput{
+		Job: &job,
+	}, nil
+}
+
+type RunExportJobInput struct {
+	JobID   string        `json:"job_id"`
+	Request ExportRequest `json:"request"`
+}
+
+// RunExportJob writes a background export to the export store. It runs on
+// the workers, as the handler of types.ExportData jobs.
+func (u *UseCase) RunExportJob(ctx context.Context, input RunExportJobInput) error {
+	const operation = "UseCase.RunExportJob"
+
+	job, err := u.getExportJob(ctx, input.JobID)
+	if errors.Is(err, erring.ErrExportJobNotFound) {
+		// Nobody can download it anymore.
+		slog.WarnContext(ctx, fmt.Sprintf("%s (%s) -> job expired before running", operation, input.JobID))
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, input.JobID, err)
+	}
+
+	source, err := u.exportSource(input.Request)
+	if err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, input.JobID, err)
+	}
+
+	fileName := job.ID + input.Request.Format.Extension()
+
+	rows, err := u.writeExportFile(ctx, fileName, input.Request.Format, source)
+	if err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, input.JobID, err)
+	}
+
+	if err := u.finishExportJob(ctx, job, entity.ExportJobStatusSucceeded, fileName, rows); err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, input.JobID, err)
+	}
+
+	return nil
+}
+
+// FailExportJob marks a background export failed. It runs on the workers,
+// once a types.ExportData job is dead-lettered: pollers only learn about the
+// failure once there are no retries left.
+func (u *UseCase) FailExportJob(ctx context.Context, input RunExportJobInput) error {
+	const operation = "UseCase.FailExportJob"
+
+	job, err := u.getExportJob(ctx, input.JobID)
+	if errors.Is(err, erring.ErrExportJobNotFound) {
+		return nil
+	}
+
+	if err == nil {
+		err = u.finishExportJob(ctx, job, entity.ExportJobStatusFailed, "", 0)
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, input.JobID, err)
+	}
+
+	return nil
+}
+
+func (u *UseCase) finishExportJob(ctx context.Context, job entity.ExportJob, status entity.ExportJobStatus, fileName string, rows int64) error {
+	finishedAt := time.Now()
+	job.FinishedAt = &finishedAt
+	job.Status = status
+
+	if status == entity.ExportJobStatusFailed {
+		job.Error = "export failed, try again later"
+	} else {
+		job.FileName = fileName
+		job.Rows = rows
+	}
+
+	return u.Cache.Set(ctx, exportJobCacheKeyPrefix+job.ID, job, u.ExportJobTTL) //nolint:wrapcheck
+}
+
+// DeleteExpiredExports deletes the files of background exports finished
+// longer than ExportJobTTL ago, as their job expired and nothing can download
+// them anymore. It runs periodically on the workers.
+func (u *UseCase) DeleteExpiredExports(ctx context.Context) error {
+	const operation = "UseCase.DeleteExpiredExports"
+
+	deleted, err := u.ExportStore.DeleteOlderThan(time.Now().Add(-u.ExportJobTTL))
+	if deleted > 0 {
+		slog.InfoContext(ctx, "expired exports deleted", slog.Int("files", deleted))
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
+
+func (u *UseCase) writeExportFile(ctx context.Context, fileName string, format export.Format, source exportSource) (int64, error) {
+	file, err := u.ExportStore.Create(fileName)
+	if err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+
+	rows, err := writeExport(ctx, file, format, source)
+	if err != nil {
+		_ = file.Close()
+		_ = u.ExportStore.Delete(fileName)
+
+		return 0, err
+	}
+
+	if err := file.Close(); err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+
+	return rows, nil
+}
+
+func writeExport(ctx context.Context, out io.Writer, format export.Format, source exportSource) (int64, error) {
+	writer, err := export.NewWriter(format, out, source.columns)
+	if err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+
+	rows, err := source.write(ctx, writer)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return 0, err //nolint:wrapcheck
+	}
+
+	return rows, nil
+}
@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/library/export"
+)
+
+var clicksExportColumns = []export.Column{
+	{Name: "id", Type: export.String},
+	{Name: "link_id", Type: export.String},
+	{Name: "code", Type: export.String},
+	{Name: "clicked_at", Type: export.Timestamp},
+	{Name: "referrer", Type: export.String},
+	{Name: "user_agent", Type: export.String},
+	{Name: "country", Type: export.String},
+	{Name: "region", Type: export.String},
+	{Name: "city", Type: export.String},
+	{Name: "asn", Type: export.Int64},
+	{Name: "browser", Type: export.String},
+	{Name: "os", Type: export.String},
+	{Name: "device", Type: export.String},
+	{Name: "bot", Type: export.String},
+}
+
+type ExportClicksInput struct {
+	Filter entity.ClicksFilter
+	Format export.Format
+}
+
+// ExportClicks exports the clicks of a time range, of all links of a user or
+// of a single link.
+func (u *UseCase) ExportClicks(ctx context.Context, input ExportClicksInput) (ExportOutput, error) {
+	const operation = "UseCase.ExportClicks"
+
+	filter := input.Filter
+	if !filter.From.Before(filter.To) || filter.To.Sub(filter.From) > u.ExportMaxRange {
+		return ExportOutput{}, fmt.Errorf("%s -> %w", operation, erring.ErrExportRangeInvalid)
+	}
+
+	output, err := u.export(ctx, ExportRequest{
+		Kind:   exportKindClicks,
+		Format: input.Format,
+		Clicks: &filter,
+	})
+	if err != nil {
+		return ExportOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return output, nil
+}
+
+func (u *UseCase) clicksExportSource(filter entity.ClicksFilter) exportSource {
+	return exportSource{
+		columns: clicksExportColumns,
+		count: func(ctx context.Context) (int64, error) {
+			return u.ClicksRepository.CountClicks(ctx, filter) //nolint:wrapcheck
+		},
+		write: func(ctx context.Context, w export.Writer) (int64, error) {
+			var rows int64
+
+			err := u.ClicksRepository.ExportClicks(ctx, filter, func(click entity.Click) error {
+				rows++
+
+				return w.Write([]any{
+					click.ID,
+					click.LinkID,
+					click.Code,
+					click.ClickedAt,
+					click.Referrer,
+					click.UserAgent,
+					click.Country,
+					click.Region,
+					click.City,
+					int64(click.ASN),
+					click.Browser,
+					click.OS,
+					click.Device,
+					strconv.FormatBool(click.Bot),
+				})
+			})
+
+			return rows, err //nolint:wrapcheck
+		},
+	}
+}
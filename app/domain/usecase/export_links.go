@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/library/export"
+)
+
+var linksExportColumns = []export.Column{
+	{Name: "id", Type: export.String},
+	{Name: "code", Type: export.String},
+	{Name: "target_url", Type: export.String},
+	{Name: "user_id", Type: export.String},
+	{Name: "tags", Type: export.String},
+	{Name: "expires_at", Type: export.Timestamp, Optional: true},
+	{Name: "created_at", Type: export.Timestamp},
+	{Name: "updated_at", Type: export.Timestamp},
+}
+
+type ExportLinksInput struct {
+	Filter entity.LinksFilter
+	Format export.Format
+}
+
+// ExportLinks exports the links of a user.
+func (u *UseCase) ExportLinks(ctx context.Context, input ExportLinksInput) (ExportOutput, error) {
+	const operation = "UseCase.ExportLinks"
+
+	output, err := u.export(ctx, ExportRequest{
+		Kind:   exportKindLinks,
+		Format: input.Format,
+		Links:  &input.Filter,
+	})
+	if err != nil {
+		return ExportOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return output, nil
+}
+
+func (u *UseCase) linksExportSource(filter entity.LinksFilter) exportSource {
+	return exportSource{
+		columns: linksExportColumns,
+		count: func(ctx context.Context) (int64, error) {
+			return u.LinksRepository.CountLinks(ctx, filter) //nolint:wrapcheck
+		},
+		write: func(ctx context.Context, w export.Writer) (int64, error) {
+			var rows int64
+
+			err := u.LinksRepository.ExportLinks(ctx, filter, func(link entity.Link) error {
+				rows++
+
+				var expiresAt any
+				if link.ExpiresAt != nil {
+					expiresAt = *link.ExpiresAt
+				}
+
+				return w.Write([]any{
+					link.ID,
+					link.Code,
+					link.TargetURL,
+					link.UserID,
+					// Same separator as bulk CSV uploads.
+					strings.Join(link.Tags, "|"),
+					expiresAt,
+					link.CreatedAt,
+					link.UpdatedAt,
+				})
+			})
+
+			return rows, err //nolint:wrapcheck
+		},
+	}
+}
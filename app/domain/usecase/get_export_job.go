@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/library/export"
+)
+
+type GetExportJobInput struct {
+	ID string
+
+	// CallerID is the user making the request. Jobs of other users are not
+	// found.
+	CallerID string
+}
+
+type GetExportJobOutput struct {
+	Job entity.ExportJob
+}
+
+func (u *UseCase) GetExportJob(ctx context.Context, input GetExportJobInput) (GetExportJobOutput, error) {
+	const operation = "UseCase.GetExportJob"
+
+	job, err := u.getOwnExportJob(ctx, input.ID, input.CallerID)
+	if err != nil {
+		return GetExportJobOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return GetExportJobOutput{
+		Job: job,
+	}, nil
+}
+
+type DownloadExportInput struct {
+	ID       string
+	CallerID string
+}
+
+type DownloadExportOutput struct {
+	File        io.ReadCloser
+	ContentType string
+	FileName    string
+}
+
+// DownloadExport opens the file written by a finished export job.
+func (u *UseCase) DownloadExport(ctx context.Context, input DownloadExportInput) (DownloadExportOutput, error) {
+	const operation = "UseCase.DownloadExport"
+
+	job, err := u.getOwnExportJob(ctx, input.ID, input.CallerID)
+	if err != nil {
+		return DownloadExportOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	if job.Status != entity.ExportJobStatusSucceeded {
+		return DownloadExportOutput{}, fmt.Errorf("%s (%s) -> %w", operation, job.Status, erring.ErrExportNotReady)
+	}
+
+	file, err := u.ExportStore.Open(job.FileName)
+	if err != nil {
+		return DownloadExportOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	format := export.Format(job.Format)
+
+	return DownloadExportOutput{
+		File:        file,
+		ContentType: format.ContentType(),
+		FileName:    job.Kind + format.Extension(),
+	}, nil
+}
+
+func (u *UseCase) getExportJob(ctx context.Context, id string) (entity.ExportJob, error) {
+	var job entity.ExportJob
+
+	err := u.Cache.Get(ctx, exportJobCacheKeyPrefix+id, &job)
+	if errors.Is(err, erring.ErrCacheKeyDoesNotExist) {
+		return entity.ExportJob{}, erring.ErrExportJobNotFound
+	}
+
+	return job, err //nolint:wrapcheck
+}
+
+// getOwnExportJob is getExportJob for the user who started it.
+func (u *UseCase) getOwnExportJob(ctx context.Context, id, userID string) (entity.ExportJob, error) {
+	job, err := u.getExportJob(ctx, id)
+	if err != nil {
+		return entity.ExportJob{}, err
+	}
+
+	if job.UserID == "" || job.UserID != userID {
+		return entity.ExportJob{}, erring.ErrExportJobNotFound
+	}
+
+	return job, nil
+}
@@ -0,0 +1,98 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/go-api-template/app/domain/entity"
+)
+
+const (
+	linkBreakdownCacheKeyPrefix = "link-breakdown:"
+
+	// Breakdowns are grouped over all clicks of a link, so they are only
+	// computed once in a while.
+	linkBreakdownCacheFreshFor = 5 * time.Minute
+	linkBreakdownLimit         = 10
+)
+
+type GetLinkInput struct {
+	Code string
+
+	// CallerID is the user making the request, empty when anonymous.
+	CallerID string
+}
+
+type GetLinkOutput struct {
+	Link  entity.Link
+	Stats entity.LinkStats
+}
+
+func (u *UseCase) GetLink(ctx context.Context, input GetLinkInput) (GetLinkOutput, error) {
+	const operation = "UseCase.GetLink"
+
+	link, err := u.LinksRepository.GetLinkByCode(ctx, input.Code)
+	if err != nil {
+		return GetLinkOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	stats, err := u.linkStats(ctx, link.ID)
+	if err != nil {
+		return GetLinkOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return GetLinkOutput{
+		Link:  withholdTarget(link, input.CallerID),
+		Stats: stats,
+	}, nil
+}
+
+// withholdTarget clears the target of links that guard it, unless callerID
+// owns them: the password of protected links, or the signature of links
+// requiring one, is what keeps it from others.
+func withholdTarget(link entity.Link, callerID string) entity.Link {
+	if (link.IsPasswordProtected() || link.RequireSignature) && !isLinkOwner(link, callerID) {
+		link.TargetURL = ""
+	}
+
+	return link
+}
+
+// isLinkOwner reports whether callerID owns link. Links without owner have
+// none.
+func isLinkOwner(link entity.Link, callerID string) bool {
+	return link.UserID != "" && link.UserID == callerID
+}
+
+// linkStats returns the stats of a link flushed into Postgres plus the live
+// counts in Redis. Without Redis, they are as of the last flush. The
+// breakdown is of the recorded clicks.
+func (u *UseCase) linkStats(ctx context.Context, linkID string) (entity.LinkStats, error) {
+	const operation = "UseCase.linkStats"
+
+	stats, err := u.LinkStatsRepository.GetLinkStats(ctx, linkID)
+	if err != nil {
+		return entity.LinkStats{}, err //nolint:wrapcheck
+	}
+
+	err = u.Cache.GetOrLoad(ctx, linkBreakdownCacheKeyPrefix+linkID, &stats.Breakdown, linkBreakdownCacheFreshFor, func(ctx context.Context) (any, error) {
+		return u.ClicksRepository.BreakDownClicks(ctx, linkID, linkBreakdownLimit)
+	})
+	if err != nil {
+		return entity.LinkStats{}, err //nolint:wrapcheck
+	}
+
+	live, err := u.ClickCounters.GetClickCounts(ctx, linkID)
+	if err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("%s (%s) -> serving flushed stats: %v", operation, linkID, err))
+
+		return stats, nil
+	}
+
+	stats.Clicks += live.Clicks
+	stats.UniqueVisitors = max(stats.UniqueVisitors, live.UniqueVisitors)
+
+	return stats, nil
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/library/qr"
+)
+
+const (
+	linkQRCodeCacheKeyPrefix = "link-qr:"
+
+	// QR codes only encode the short URL, which never changes for a code.
+	linkQRCodeCacheTTL = 24 * time.Hour
+)
+
+type GetLinkQRCodeInput struct {
+	Code    string
+	Options qr.Options
+}
+
+type GetLinkQRCodeOutput struct {
+	Image       []byte
+	ContentType string
+	ETag        string
+}
+
+type cachedQRCode struct {
+	Image []byte `json:"image"`
+	ETag  string `json:"etag"`
+}
+
+// GetLinkQRCode renders a QR code of the short URL of a link. Rendered images
+// are cached by code and options, but the link is always looked up (through
+// the link cache) first, so deleted and expired links get no QR code. Nor do
+// links requiring a signature, as their plain short URL never redirects.
+func (u *UseCase) GetLinkQRCode(ctx context.Context, input GetLinkQRCodeInput) (GetLinkQRCodeOutput, error) {
+	const operation = "UseCase.GetLinkQRCode"
+
+	link, err := u.resolveLink(ctx, input.Code)
+	if err != nil {
+		return GetLinkQRCodeOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	if link.IsExpired(time.Now()) {
+		return GetLinkQRCodeOutput{}, fmt.Errorf("%s -> %w", operation, erring.ErrLinkExpired)
+	}
+
+	if link.RequireSignature {
+		return GetLinkQRCodeOutput{}, fmt.Errorf("%s -> %w", operation, erring.ErrLinkNotFound)
+	}
+
+	cacheKey := linkQRCodeCacheKeyPrefix + link.Code + ":" + input.Options.Key()
+
+	var cached cachedQRCode
+	if err := u.Cache.Get(ctx, cacheKey, &cached); err == nil {
+		return GetLinkQRCodeOutput{
+			Image:       cached.Image,
+			ContentType: input.Options.Format.ContentType(),
+			ETag:        cached.ETag,
+		}, nil
+	}
+
+	image, err := qr.Render(strings.TrimRight(u.LinkBaseURL, "/")+"/"+link.Code, input.Options)
+	if err != nil {
+		if errors.Is(err, qr.ErrOptionsInvalid) {
+			err = errors.Join(err, erring.ErrRequestInvalid)
+		}
+
+		return GetLinkQRCodeOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	sum := sha256.Sum256(image)
+	cached = cachedQRCode{
+		Image: image,
+		ETag:  `"` + base64.RawURLEncoding.EncodeToString(sum[:16]) + `"`,
+	}
+
+	_ = u.Cache.Set(ctx, cacheKey, cached, linkQRCodeCacheTTL)
+
+	return GetLinkQRCodeOutput{
+		Image:       cached.Image,
+		ContentType: input.Options.Format.ContentType(),
+		ETag:        cached.ETag,
+	}, nil
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+)
+
+type GetLinksBulkJobInput struct {
+	ID string
+
+	// CallerID is the user making the request. Jobs of other users are not
+	// found.
+	CallerID string
+}
+
+type GetLinksBulkJobOutput struct {
+	Job entity.LinksBulkJob
+}
+
+func (u *UseCase) GetLinksBulkJob(ctx context.Context, input GetLinksBulkJobInput) (GetLinksBulkJobOutput, error) {
+	const operation = "UseCase.GetLinksBulkJob"
+
+	job, err := u.getOwnLinksBulkJob(ctx, input.ID, input.CallerID)
+	if err != nil {
+		return GetLinksBulkJobOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return GetLinksBulkJobOutput{
+		Job: job,
+	}, nil
+}
+
+func (u *UseCase) getLinksBulkJob(ctx context.Context, id string) (entity.LinksBulkJob, error) {
+	var job entity.LinksBulkJob
+
+	err := u.Cache.Get(ctx, linksBulkJobCacheKeyPrefix+id, &job)
+	if errors.Is(err, erring.ErrCacheKeyDoesNotExist) {
+		return entity.LinksBulkJob{}, erring.ErrLinksBulkJobNotFound
+	}
+
+	return job, err //nolint:wrapcheck
+}
+
+// getOwnLinksBulkJob is getLinksBulkJob for the user who started it.
+func (u *UseCase) getOwnLinksBulkJob(ctx context.Context, id, userID string) (entity.LinksBulkJob, error) {
+	job, err := u.getLinksBulkJob(ctx, id)
+	if err != nil {
+		return entity.LinksBulkJob{}, err
+	}
+
+	if job.UserID == "" || job.UserID != userID {
+		return entity.LinksBulkJob{}, erring.ErrLinksBulkJobNotFound
+	}
+
+	return job, nil
+}
@@ -3,10 +3,19 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/go-api-template/app/domain/entity"
 )
 
+const (
+	userCacheKeyPrefix = "user:"
+
+	// A cached user is served without touching Postgres while fresh, and kept
+	// around much longer so it can still be served when Postgres is down.
+	userCacheFreshFor = 1 * time.Minute
+)
+
 type GetUserInput struct {
 	ID string
 }
@@ -18,7 +27,11 @@ type GetUserOutput struct {
 func (u *UseCase) GetUser(ctx context.Context, input GetUserInput) (GetUserOutput, error) {
 	const operation = "UseCase.GetUser"
 
-	user, err := u.UsersRepository.GetUserByID(ctx, input.ID)
+	var user entity.User
+
+	err := u.Cache.GetOrLoad(ctx, userCacheKeyPrefix+input.ID, &user, userCacheFreshFor, func(ctx context.Context) (any, error) {
+		return u.UsersRepository.GetUserByID(ctx, input.ID)
+	})
 	if err != nil {
 		return GetUserOutput{}, fmt.Errorf("%s -> %w", operation, err)
 	}
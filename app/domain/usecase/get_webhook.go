@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+)
+
+type GetWebhookInput struct {
+	ID string
+
+	// CallerID is the user making the request. Webhooks of other users are
+	// not found.
+	CallerID string
+}
+
+type GetWebhookOutput struct {
+	Webhook entity.Webhook
+}
+
+func (u *UseCase) GetWebhook(ctx context.Context, input GetWebhookInput) (GetWebhookOutput, error) {
+	const operation = "UseCase.GetWebhook"
+
+	hook, err := u.getOwnWebhook(ctx, input.ID, input.CallerID)
+	if err != nil {
+		return GetWebhookOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return GetWebhookOutput{
+		Webhook: hook,
+	}, nil
+}
+
+type ListWebhooksInput struct {
+	UserID string
+}
+
+type ListWebhooksOutput struct {
+	Webhooks []entity.Webhook
+}
+
+func (u *UseCase) ListWebhooks(ctx context.Context, input ListWebhooksInput) (ListWebhooksOutput, error) {
+	const operation = "UseCase.ListWebhooks"
+
+	hooks, err := u.WebhooksRepository.ListWebhooks(ctx, input.UserID)
+	if err != nil {
+		return ListWebhooksOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return ListWebhooksOutput{
+		Webhooks: hooks,
+	}, nil
+}
+
+// getOwnWebhook returns the webhook with id if userID owns it.
+func (u *UseCase) getOwnWebhook(ctx context.Context, id, userID string) (entity.Webhook, error) {
+	hook, err := u.WebhooksRepository.GetWebhookByID(ctx, id)
+	if err != nil {
+		return entity.Webhook{}, err //nolint:wrapcheck
+	}
+
+	if hook.UserID != userID {
+		return entity.Webhook{}, erring.ErrWebhookNotFound
+	}
+
+	return hook, nil
+}
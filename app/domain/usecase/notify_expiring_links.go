@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Expiring links claimed per query.
+const expiringLinksBatchSize = 100
+
+// NotifyExpiringLinks queues a message to the owners of the links expiring
+// within LinkExpiryNoticeBefore. Each link is only notified once. It runs
+// periodically on the workers.
+func (u *UseCase) NotifyExpiringLinks(ctx context.Context) error {
+	const operation = "UseCase.NotifyExpiringLinks"
+
+	if u.Messenger == nil {
+		return nil
+	}
+
+	for {
+		var claimed int
+
+		// Links are claimed in the transaction queuing their messages, so a
+		// failure releases them for the next run.
+		err := u.TxManager.WithinTx(ctx, func(ctx context.Context) error {
+			links, err := u.LinksRepository.ClaimExpiringLinks(ctx, time.Now().Add(u.LinkExpiryNoticeBefore), expiringLinksBatchSize)
+			if err != nil {
+				return err //nolint:wrapcheck
+			}
+
+			claimed = len(links)
+
+			for _, link := range links {
+				messageID, err := uuid.NewV7()
+				if err != nil {
+					return err //nolint:wrapcheck
+				}
+
+				if err := u.enqueueLinkMessage(ctx, messageID.String(), link, linkExpiringTemplate); err != nil {
+					return fmt.Errorf("link %s: %w", link.Code, err)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("%s -> %w", operation, err)
+		}
+
+		if claimed < expiringLinksBatchSize {
+			return nil
+		}
+	}
+}
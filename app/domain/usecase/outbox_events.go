@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/types"
+	"github.com/go-api-template/app/library/ctxkey"
+)
+
+// The handlers below run on the workers, as the outbox relay dispatches
+// events. An event may be handled more than once, so they key what they
+// produce (messages, webhook events) by the outbox event ID.
+
+// HandleLinkCreated sends the new link to its owner and publishes it to
+// webhooks.
+func (u *UseCase) HandleLinkCreated(ctx context.Context, link entity.Link) error {
+	const operation = "UseCase.HandleLinkCreated"
+
+	eventID, err := outboxEventID(ctx)
+	if err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, link.Code, err)
+	}
+
+	if err := u.enqueueLinkMessage(ctx, eventID, link, linkCreatedTemplate); err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, link.Code, err)
+	}
+
+	if err := u.publishLinkEvent(ctx, eventID, types.LinkCreated, link, nil); err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, link.Code, err)
+	}
+
+	return nil
+}
+
+// HandleLinkUpdated drops the link from the cache, in case the request that
+// updated it failed to, and publishes it to webhooks.
+func (u *UseCase) HandleLinkUpdated(ctx context.Context, link entity.Link) error {
+	const operation = "UseCase.HandleLinkUpdated"
+
+	if _, err := u.Cache.Del(ctx, linkCacheKeyPrefix+link.Code); err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, link.Code, err)
+	}
+
+	eventID, err := outboxEventID(ctx)
+	if err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, link.Code, err)
+	}
+
+	if err := u.publishLinkEvent(ctx, eventID, types.LinkUpdated, link, nil); err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, link.Code, err)
+	}
+
+	return nil
+}
+
+// HandleUserCreated drops any cached lookup of the user made before it was
+// committed.
+func (u *UseCase) HandleUserCreated(ctx context.Context, user entity.User) error {
+	const operation = "UseCase.HandleUserCreated"
+
+	if _, err := u.Cache.Del(ctx, userCacheKeyPrefix+user.ID); err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, user.ID, err)
+	}
+
+	return nil
+}
+
+func outboxEventID(ctx context.Context) (string, error) {
+	if id, ok := ctxkey.GetOutboxEventID(ctx); ok {
+		return id, nil
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	return id.String(), nil
+}
@@ -0,0 +1,201 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/types"
+)
+
+// Expired links claimed per query.
+const expiredLinksBatchSize = 100
+
+// webhookEvent is the JSON body of a webhook delivery.
+type webhookEvent struct {
+	ID        string             `json:"id"`
+	Type      types.WebhookEvent `json:"type"`
+	CreatedAt time.Time          `json:"created_at"`
+	Data      webhookEventData   `json:"data"`
+}
+
+type webhookEventData struct {
+	Link  webhookLink   `json:"link"`
+	Click *webhookClick `json:"click,omitempty"`
+}
+
+type webhookLink struct {
+	Code             string     `json:"code"`
+	ShortURL         string     `json:"short_url"`
+	TargetURL        string     `json:"target_url"`
+	UserID           string     `json:"user_id"`
+	ExpiresAt        *time.Time `json:"expires_at,omitempty"`
+	Tags             []string   `json:"tags,omitempty"`
+	RequireSignature bool       `json:"require_signature"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+type webhookClick struct {
+	ClickedAt time.Time `json:"clicked_at"`
+	Referrer  string    `json:"referrer,omitempty"`
+	UserAgent string    `json:"user_agent,omitempty"`
+	Bot       bool      `json:"bot"`
+}
+
+// publishLinkEvent queues a delivery of event to every enabled webhook of the
+// link owner subscribed to it. Receivers dedupe events by eventID. click is
+// only set for types.LinkClicked.
+func (u *UseCase) publishLinkEvent(ctx context.Context, eventID string, event types.WebhookEvent, link entity.Link, click *entity.Click) error {
+	if u.WebhookSender == nil || link.UserID == "" {
+		return nil
+	}
+
+	hooks, err := u.WebhooksRepository.ListSubscribedWebhooks(ctx, link.UserID, event)
+	if err != nil || len(hooks) == 0 {
+		return err //nolint:wrapcheck
+	}
+
+	payload, err := json.Marshal(u.newWebhookEvent(eventID, event, link, click))
+	if err != nil {
+		return fmt.Errorf("encode event: %w", err)
+	}
+
+	var errs error
+
+	for _, hook := range hooks {
+		id, err := uuid.NewV7()
+		if err != nil {
+			errs = errors.Join(errs, fmt.Errorf("webhook %s: %w", hook.ID, err))
+
+			continue
+		}
+
+		delivery := entity.WebhookDelivery{
+			ID:        id.String(),
+			WebhookID: hook.ID,
+			EventID:   eventID,
+			EventType: event,
+			Payload:   payload,
+		}
+
+		if err := u.enqueueWebhookDelivery(ctx, delivery); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("webhook %s: %w", hook.ID, err))
+		}
+	}
+
+	return errs
+}
+
+// publishClickEvent publishes a types.LinkClicked event for a share
+// WebhookClickSampleRate of the clicks.
+func (u *UseCase) publishClickEvent(ctx context.Context, link entity.Link, click entity.Click) error {
+	if u.WebhookClickSampleRate <= 0 || rand.Float64() >= u.WebhookClickSampleRate { //nolint:gosec
+		return nil
+	}
+
+	return u.publishLinkEvent(ctx, click.ID, types.LinkClicked, link, &click)
+}
+
+// enqueueWebhookDelivery stores a delivery along with the job sending it.
+func (u *UseCase) enqueueWebhookDelivery(ctx context.Context, delivery entity.WebhookDelivery) error {
+	return u.TxManager.WithinTx(ctx, func(ctx context.Context) error { //nolint:wrapcheck
+		if err := u.WebhooksRepository.CreateDelivery(ctx, delivery); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		return u.Jobs.Enqueue(ctx, types.DeliverWebhook, DeliverWebhookInput{DeliveryID: delivery.ID}) //nolint:wrapcheck
+	})
+}
+
+func (u *UseCase) newWebhookEvent(id string, event types.WebhookEvent, link entity.Link, click *entity.Click) webhookEvent {
+	now := time.Now().UTC()
+
+	// New links are not read back from Postgres, which sets their timestamps.
+	if link.CreatedAt.IsZero() {
+		link.CreatedAt, link.UpdatedAt = now, now
+	}
+
+	data := webhookEventData{
+		Link: webhookLink{
+			Code:             link.Code,
+			ShortURL:         strings.TrimRight(u.LinkBaseURL, "/") + "/" + link.Code,
+			TargetURL:        link.TargetURL,
+			UserID:           link.UserID,
+			ExpiresAt:        link.ExpiresAt,
+			Tags:             link.Tags,
+			RequireSignature: link.RequireSignature,
+			CreatedAt:        link.CreatedAt,
+			UpdatedAt:        link.UpdatedAt,
+		},
+	}
+
+	if click != nil {
+		data.Click = &webhookClick{
+			ClickedAt: click.ClickedAt,
+			Referrer:  click.Referrer,
+			UserAgent: click.UserAgent,
+			Bot:       click.Bot,
+		}
+	}
+
+	return webhookEvent{
+		ID:        id,
+		Type:      event,
+		CreatedAt: now,
+		Data:      data,
+	}
+}
+
+// PublishExpiredLinks publishes a types.LinkExpired event for the links that
+// expired since its last run. Each link is only published once. It runs
+// periodically on the workers.
+func (u *UseCase) PublishExpiredLinks(ctx context.Context) error {
+	const operation = "UseCase.PublishExpiredLinks"
+
+	if u.WebhookSender == nil {
+		return nil
+	}
+
+	for {
+		var claimed int
+
+		// Links are claimed in the transaction queuing their deliveries, so a
+		// failure releases them for the next run.
+		err := u.TxManager.WithinTx(ctx, func(ctx context.Context) error {
+			links, err := u.LinksRepository.ClaimExpiredLinks(ctx, expiredLinksBatchSize)
+			if err != nil {
+				return err //nolint:wrapcheck
+			}
+
+			claimed = len(links)
+
+			for _, link := range links {
+				eventID, err := uuid.NewV7()
+				if err != nil {
+					return err //nolint:wrapcheck
+				}
+
+				if err := u.publishLinkEvent(ctx, eventID.String(), types.LinkExpired, link, nil); err != nil {
+					return fmt.Errorf("link %s: %w", link.Code, err)
+				}
+			}
+
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("%s -> %w", operation, err)
+		}
+
+		if claimed < expiredLinksBatchSize {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,246 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+)
+
+var errFake = errors.New("fake failure")
+
+// fakeClickCounters keeps click counts in memory, like the Redis ones.
+type fakeClickCounters struct {
+	mu        sync.Mutex
+	clicks    map[string]int64
+	dirty     map[string]bool
+	settleErr error
+}
+
+func (c *fakeClickCounters) CountClick(_ context.Context, linkID, _ string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.clicks[linkID]++
+	c.dirty[linkID] = true
+
+	return nil
+}
+
+func (c *fakeClickCounters) GetClickCounts(_ context.Context, linkID string) (entity.ClickCounts, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return entity.ClickCounts{LinkID: linkID, Clicks: c.clicks[linkID], UniqueVisitors: 1}, nil
+}
+
+func (c *fakeClickCounters) PopDirtyLinks(_ context.Context, limit int) ([]string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var linkIDs []string
+
+	for linkID := range c.dirty {
+		if len(linkIDs) == limit {
+			break
+		}
+
+		linkIDs = append(linkIDs, linkID)
+		delete(c.dirty, linkID)
+	}
+
+	return linkIDs, nil
+}
+
+func (c *fakeClickCounters) MarkDirtyLinks(_ context.Context, linkIDs ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, linkID := range linkIDs {
+		c.dirty[linkID] = true
+	}
+
+	return nil
+}
+
+func (c *fakeClickCounters) SettleClicks(_ context.Context, linkID string, clicks int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.settleErr != nil {
+		return c.settleErr
+	}
+
+	c.clicks[linkID] -= clicks
+
+	return nil
+}
+
+// fakeLinkStats adds clicks like the Postgres repository: the first flush
+// of a link seeds them with its recorded clicks instead.
+type fakeLinkStats struct {
+	mu       sync.Mutex
+	stats    map[string]entity.LinkStats
+	recorded map[string]int64
+	addErr   error
+
+	// Run within AddClicks, to count clicks while a flush is running.
+	during func()
+}
+
+func (r *fakeLinkStats) GetLinkStats(_ context.Context, linkID string) (entity.LinkStats, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.stats[linkID], nil
+}
+
+func (r *fakeLinkStats) AddClicks(_ context.Context, counts entity.ClickCounts) (entity.LinkStats, error) {
+	if r.during != nil {
+		r.during()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.addErr != nil {
+		return entity.LinkStats{}, r.addErr
+	}
+
+	recorded, ok := r.recorded[counts.LinkID]
+	if !ok {
+		return entity.LinkStats{}, erring.ErrLinkNotFound
+	}
+
+	stats, ok := r.stats[counts.LinkID]
+	if ok {
+		stats.Clicks += counts.Clicks
+	} else {
+		stats = entity.LinkStats{LinkID: counts.LinkID, Clicks: recorded}
+	}
+
+	stats.UniqueVisitors = max(stats.UniqueVisitors, counts.UniqueVisitors)
+	stats.RecordedClicks = recorded
+	r.stats[counts.LinkID] = stats
+
+	return stats, nil
+}
+
+func TestUseCase_ReconcileClickCounters(t *testing.T) {
+	t.Parallel()
+
+	const linkID = "link"
+
+	tests := []struct {
+		name       string
+		clicks     int64
+		stats      map[string]entity.LinkStats
+		recorded   map[string]int64
+		addErr     error
+		settleErr  error
+		during     func(counters *fakeClickCounters)
+		wantErr    error
+		wantStats  entity.LinkStats
+		wantClicks int64
+		wantDirty  bool
+	}{
+		{
+			name:       "first flush seeds the stats from the recorded clicks",
+			clicks:     3,
+			recorded:   map[string]int64{linkID: 10},
+			wantStats:  entity.LinkStats{LinkID: linkID, Clicks: 10, UniqueVisitors: 1, RecordedClicks: 10},
+			wantClicks: 0,
+		},
+		{
+			name:       "later flushes add the counted clicks once",
+			clicks:     3,
+			stats:      map[string]entity.LinkStats{linkID: {LinkID: linkID, Clicks: 10}},
+			recorded:   map[string]int64{linkID: 13},
+			wantStats:  entity.LinkStats{LinkID: linkID, Clicks: 13, UniqueVisitors: 1, RecordedClicks: 13},
+			wantClicks: 0,
+		},
+		{
+			name:     "clicks counted during a flush are kept for the next one",
+			clicks:   3,
+			stats:    map[string]entity.LinkStats{linkID: {LinkID: linkID, Clicks: 10}},
+			recorded: map[string]int64{linkID: 13},
+			during: func(counters *fakeClickCounters) {
+				_ = counters.CountClick(context.Background(), linkID, "visitor")
+				_ = counters.CountClick(context.Background(), linkID, "visitor")
+			},
+			wantStats:  entity.LinkStats{LinkID: linkID, Clicks: 13, UniqueVisitors: 1, RecordedClicks: 13},
+			wantClicks: 2,
+			wantDirty:  true,
+		},
+		{
+			name:       "counts of deleted links are dropped",
+			clicks:     3,
+			recorded:   map[string]int64{},
+			wantClicks: 3,
+		},
+		{
+			name:       "failed flushes are retried on the next run",
+			clicks:     3,
+			recorded:   map[string]int64{linkID: 10},
+			addErr:     errFake,
+			wantErr:    errClickCountsNotFlushed,
+			wantClicks: 3,
+			wantDirty:  true,
+		},
+		{
+			name:       "failed settles are retried on the next run",
+			clicks:     3,
+			stats:      map[string]entity.LinkStats{linkID: {LinkID: linkID, Clicks: 10}},
+			recorded:   map[string]int64{linkID: 13},
+			settleErr:  errFake,
+			wantErr:    errClickCountsNotFlushed,
+			wantStats:  entity.LinkStats{LinkID: linkID, Clicks: 13, UniqueVisitors: 1, RecordedClicks: 13},
+			wantClicks: 3,
+			wantDirty:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			counters := &fakeClickCounters{
+				clicks:    map[string]int64{linkID: tt.clicks},
+				dirty:     map[string]bool{linkID: true},
+				settleErr: tt.settleErr,
+			}
+
+			stats := &fakeLinkStats{
+				stats:    map[string]entity.LinkStats{},
+				recorded: tt.recorded,
+				addErr:   tt.addErr,
+			}
+			for id, s := range tt.stats {
+				stats.stats[id] = s
+			}
+
+			if tt.during != nil {
+				stats.during = func() { tt.during(counters) }
+			}
+
+			u := &UseCase{
+				ClickCounters:          counters,
+				ClickCountersBatchSize: 10,
+				LinkStatsRepository:    stats,
+			}
+
+			err := u.ReconcileClickCounters(context.Background())
+			require.ErrorIs(t, err, tt.wantErr)
+
+			assert.Equal(t, tt.wantStats, stats.stats[linkID])
+			assert.Equal(t, tt.wantClicks, counters.clicks[linkID])
+			assert.Equal(t, tt.wantDirty, counters.dirty[linkID])
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/library/consistency"
+)
+
+type RecordClickInput struct {
+	Link      entity.Link
+	Referrer  string
+	UserAgent string
+	ClientIP  string
+}
+
+// RecordClick counts and stores a click on a link in the background, so
+// redirects never wait for Redis or Postgres, streams it to the viewers of
+// the link and publishes a sample of them to webhooks. Bot clicks are stored
+// and streamed, but not counted. Clicks that can't be counted or stored are
+// only logged.
+func (u *UseCase) RecordClick(ctx context.Context, input RecordClickInput) {
+	const operation = "UseCase.RecordClick"
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("%s (%s) -> click not recorded: %v", operation, input.Link.Code, err))
+
+		return
+	}
+
+	click := entity.Click{
+		ID:        id.String(),
+		LinkID:    input.Link.ID,
+		Code:      input.Link.Code,
+		ClickedAt: time.Now(),
+		Referrer:  input.Referrer,
+		UserAgent: input.UserAgent,
+	}
+
+	go func(ctx context.Context) {
+		location := u.GeoLocator.Locate(input.ClientIP)
+		click.Country, click.Region, click.City, click.ASN = location.Country, location.Region, location.City, location.ASN
+
+		agent := u.UserAgentParser.Parse(input.UserAgent)
+		click.Browser, click.OS, click.Device, click.Bot = agent.Browser, agent.OS, string(agent.Device), agent.Bot
+
+		if !click.Bot {
+			if err := u.ClickCounters.CountClick(ctx, click.LinkID, visitorID(input.ClientIP, input.UserAgent)); err != nil {
+				slog.WarnContext(ctx, fmt.Sprintf("%s (%s) -> click not counted: %v", operation, click.Code, err))
+			}
+		}
+
+		if err := u.ClickEvents.Publish(ctx, click.LinkID, newClickEvent(click)); err != nil {
+			slog.WarnContext(ctx, fmt.Sprintf("%s (%s) -> click not streamed: %v", operation, click.Code, err))
+		}
+
+		if err := u.ClicksRepository.Create(ctx, click); err != nil {
+			slog.WarnContext(ctx, fmt.Sprintf("%s (%s) -> click not recorded: %v", operation, click.Code, err))
+
+			return
+		}
+
+		if err := u.publishClickEvent(ctx, input.Link, click); err != nil {
+			slog.WarnContext(ctx, fmt.Sprintf("%s (%s) -> click event not published: %v", operation, click.Code, err))
+		}
+	}(consistency.Detach(context.WithoutCancel(ctx)))
+}
+
+// visitorID tells visitors apart for unique visitor counts, without keeping
+// their address.
+func visitorID(clientIP, userAgent string) string {
+	sum := sha256.Sum256([]byte(clientIP + "|" + userAgent))
+
+	return hex.EncodeToString(sum[:16])
+}
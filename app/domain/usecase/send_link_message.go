@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/domain/types"
+)
+
+// Content templates of the link messages. Both take the user name as {{1}}
+// and the short URL as {{2}}; the expiring one also takes the expiry as {{3}}.
+const (
+	linkCreatedTemplate  = types.QuickResponseTemplate
+	linkExpiringTemplate = types.ListTemplate
+)
+
+type SendLinkMessageInput struct {
+	MessageID string               `json:"message_id"`
+	UserID    string               `json:"user_id"`
+	Code      string               `json:"code"`
+	Template  types.TwilioTemplate `json:"template"`
+}
+
+// SendLinkMessage sends a short link to its owner over their message
+// channel. It runs on the workers, as the handler of types.SendMessage jobs;
+// users without a phone are skipped.
+func (u *UseCase) SendLinkMessage(ctx context.Context, input SendLinkMessageInput) error {
+	const operation = "UseCase.SendLinkMessage"
+
+	// Retried jobs don't send the message again once the provider took it.
+	existing, err := u.MessagesRepository.GetMessageByID(ctx, input.MessageID)
+	if err == nil && existing.ProviderID != "" {
+		return nil
+	}
+
+	if err != nil && !errors.Is(err, erring.ErrMessageNotFound) {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	message, ok, err := u.newLinkMessage(ctx, input)
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	if !ok {
+		return nil
+	}
+
+	if err := u.MessagesRepository.Create(ctx, message); err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	providerID, err := u.Messenger.Send(ctx, message)
+	if errors.Is(err, erring.ErrMessageRejected) {
+		slog.WarnContext(ctx, fmt.Sprintf("%s (%s) -> %v", operation, message.ID, err))
+
+		if err := u.MessagesRepository.SetSendResult(ctx, message.ID, "", entity.MessageStatusFailed); err != nil {
+			return fmt.Errorf("%s -> %w", operation, err)
+		}
+
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	if err := u.MessagesRepository.SetSendResult(ctx, message.ID, providerID, entity.MessageStatusQueued); err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
+
+// newLinkMessage builds the message of input, or reports there is nothing to
+// send: the user has no phone, or the link is gone or no longer theirs.
+func (u *UseCase) newLinkMessage(ctx context.Context, input SendLinkMessageInput) (entity.Message, bool, error) {
+	user, err := u.UsersRepository.GetUserByID(ctx, input.UserID)
+	if errors.Is(err, erring.ErrUserNotFound) {
+		return entity.Message{}, false, nil
+	}
+
+	if err != nil {
+		return entity.Message{}, false, err //nolint:wrapcheck
+	}
+
+	if user.Phone == "" || user.MessageChannel == "" {
+		return entity.Message{}, false, nil
+	}
+
+	link, err := u.LinksRepository.GetLinkByCode(ctx, input.Code)
+	if errors.Is(err, erring.ErrLinkNotFound) {
+		return entity.Message{}, false, nil
+	}
+
+	if err != nil {
+		return entity.Message{}, false, err //nolint:wrapcheck
+	}
+
+	if link.UserID != user.ID || link.IsExpired(time.Now()) {
+		return entity.Message{}, false, nil
+	}
+
+	variables := map[string]string{
+		"1": user.Name,
+		"2": strings.TrimRight(u.LinkBaseURL, "/") + "/" + link.Code,
+	}
+
+	if link.ExpiresAt != nil {
+		variables["3"] = link.ExpiresAt.UTC().Format("2006-01-02 15:04 UTC")
+	}
+
+	return entity.Message{
+		ID:        input.MessageID,
+		UserID:    user.ID,
+		LinkID:    link.ID,
+		Channel:   user.MessageChannel,
+		To:        user.Phone,
+		Template:  input.Template,
+		Variables: variables,
+		Status:    entity.MessageStatusPending,
+	}, true, nil
+}
+
+// enqueueLinkMessage queues a message about link to its owner, when
+// messaging is enabled and the link has one. Messages are only sent once per
+// messageID.
+func (u *UseCase) enqueueLinkMessage(ctx context.Context, messageID string, link entity.Link, template types.TwilioTemplate) error {
+	if u.Messenger == nil || link.UserID == "" {
+		return nil
+	}
+
+	return u.Jobs.Enqueue(ctx, types.SendMessage, SendLinkMessageInput{ //nolint:wrapcheck
+		MessageID: messageID,
+		UserID:    link.UserID,
+		Code:      link.Code,
+		Template:  template,
+	})
+}
@@ -0,0 +1,54 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+)
+
+type SignLinkInput struct {
+	Code string
+
+	// ExpiresAt is embedded in the signed code when set, independently of the
+	// expiry of the link itself.
+	ExpiresAt *time.Time
+
+	// CallerID is the user making the request, empty when anonymous.
+	CallerID string
+}
+
+type SignLinkOutput struct {
+	Link       entity.Link
+	SignedCode string
+	KeyID      string
+}
+
+// SignLink mints a signed code for an existing link, e.g. for a link sent in a
+// transactional email. Links requiring a signature are only signed for their
+// owner, or anyone could get around it.
+func (u *UseCase) SignLink(ctx context.Context, input SignLinkInput) (SignLinkOutput, error) {
+	const operation = "UseCase.SignLink"
+
+	link, err := u.LinksRepository.GetLinkByCode(ctx, input.Code)
+	if err != nil {
+		return SignLinkOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	if link.RequireSignature && !isLinkOwner(link, input.CallerID) {
+		return SignLinkOutput{}, fmt.Errorf("%s -> %w", operation, erring.ErrUserForbidden)
+	}
+
+	signedCode, keyID, err := u.LinkSigner.Sign(link.Code, input.ExpiresAt)
+	if err != nil {
+		return SignLinkOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return SignLinkOutput{
+		Link:       link,
+		SignedCode: signedCode,
+		KeyID:      keyID,
+	}, nil
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+)
+
+type StreamLinkEventsInput struct {
+	Code string
+
+	// Clicks after this one still buffered are sent first.
+	LastEventID string
+
+	// CallerID is the user making the request. Only the owner of a link can
+	// follow its clicks, so links without owner can't be streamed.
+	CallerID string
+}
+
+type StreamLinkEventsOutput struct {
+	Events <-chan entity.ClickEvent
+
+	// Close ends the stream; Events is closed after it. It must be called
+	// even when the stream isn't consumed, as nothing else ends it.
+	Close func()
+}
+
+// StreamLinkEvents streams the clicks on a link as they happen. The stream
+// outlives ctx, to be consumed after the request handler returns, until
+// Close is called.
+func (u *UseCase) StreamLinkEvents(ctx context.Context, input StreamLinkEventsInput) (StreamLinkEventsOutput, error) {
+	const operation = "UseCase.StreamLinkEvents"
+
+	link, err := u.LinksRepository.GetLinkByCode(ctx, input.Code)
+	if err != nil {
+		return StreamLinkEventsOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	if !isLinkOwner(link, input.CallerID) {
+		return StreamLinkEventsOutput{}, fmt.Errorf("%s -> %w", operation, erring.ErrUserForbidden)
+	}
+
+	streamCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+
+	events, err := u.ClickEvents.Subscribe(streamCtx, link.ID, input.LastEventID)
+	if err != nil {
+		cancel()
+
+		return StreamLinkEventsOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return StreamLinkEventsOutput{
+		Events: events,
+		Close:  cancel,
+	}, nil
+}
+
+func newClickEvent(click entity.Click) entity.ClickEvent {
+	return entity.ClickEvent{
+		Code:      click.Code,
+		ClickedAt: click.ClickedAt,
+		Referrer:  click.Referrer,
+		Country:   click.Country,
+		Region:    click.Region,
+		City:      click.City,
+		Browser:   click.Browser,
+		OS:        click.OS,
+		Device:    click.Device,
+		Bot:       click.Bot,
+	}
+}
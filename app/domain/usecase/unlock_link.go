@@ -0,0 +1,85 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/library/password"
+)
+
+const linkUnlockAttemptsKeyPrefix = "link-unlock-attempts:"
+
+type UnlockLinkInput struct {
+	// Code is either a plain or a signed short code.
+	Code     string
+	Password string
+	ClientIP string
+}
+
+type UnlockLinkOutput struct {
+	Link entity.Link
+}
+
+// UnlockLink checks the password of a protected link. Failed attempts are
+// limited per link and client IP.
+func (u *UseCase) UnlockLink(ctx context.Context, input UnlockLinkInput) (UnlockLinkOutput, error) {
+	const operation = "UseCase.UnlockLink"
+
+	// Checked first so forged codes don't consume attempts of the real link.
+	code, _, err := u.verifyLinkCode(input.Code)
+	if err != nil {
+		return UnlockLinkOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	attemptsKey := linkUnlockAttemptsKeyPrefix + code + ":" + input.ClientIP
+
+	// Without Redis we can't count attempts; Argon2id keeps guessing slow anyway.
+	attempts, err := u.Cache.Incr(ctx, attemptsKey, u.LinkUnlockAttemptsWindow)
+	if err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("%s -> unlock attempts not counted: %v", operation, err))
+	}
+
+	if attempts > int64(u.LinkUnlockMaxAttempts) {
+		return UnlockLinkOutput{}, fmt.Errorf("%s -> %w", operation, erring.ErrLinkUnlockRateLimited)
+	}
+
+	link, err := u.resolveLinkCode(ctx, input.Code)
+	if err != nil {
+		return UnlockLinkOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	if link.IsExpired(time.Now()) {
+		return UnlockLinkOutput{}, fmt.Errorf("%s -> %w", operation, erring.ErrLinkExpired)
+	}
+
+	if !link.IsPasswordProtected() {
+		return UnlockLinkOutput{
+			Link: link,
+		}, nil
+	}
+
+	// Cached links don't carry their password hash.
+	stored, err := u.LinksRepository.GetLinkByCode(ctx, link.Code)
+	if err != nil {
+		return UnlockLinkOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	ok, err := password.Verify(input.Password, stored.PasswordHash)
+	if err != nil {
+		return UnlockLinkOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	if !ok {
+		return UnlockLinkOutput{}, fmt.Errorf("%s -> %w", operation, erring.ErrLinkPasswordInvalid)
+	}
+
+	_, _ = u.Cache.Del(ctx, attemptsKey)
+
+	return UnlockLinkOutput{
+		Link: stored,
+	}, nil
+}
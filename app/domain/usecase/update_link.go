@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/library/password"
+)
+
+type UpdateLinkInput struct {
+	Link entity.Link
+
+	// Password replaces the link password when set; an empty one removes it.
+	Password *string
+
+	// CallerID is the user making the request. Only the owner of a link can
+	// update it, so links without owner can't be updated.
+	CallerID string
+}
+
+type UpdateLinkOutput struct {
+	Link entity.Link
+}
+
+func (u *UseCase) UpdateLink(ctx context.Context, input UpdateLinkInput) (UpdateLinkOutput, error) {
+	const operation = "UseCase.UpdateLink"
+
+	current, err := u.LinksRepository.GetLinkByCode(ctx, input.Link.Code)
+	if err != nil {
+		return UpdateLinkOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	if !isLinkOwner(current, input.CallerID) {
+		return UpdateLinkOutput{}, fmt.Errorf("%s -> %w", operation, erring.ErrUserForbidden)
+	}
+
+	targetURL, err := u.URLChecker.Check(ctx, input.Link.TargetURL)
+	if err != nil {
+		return UpdateLinkOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	input.Link.TargetURL = targetURL
+
+	if input.Password != nil && *input.Password != "" {
+		hash, err := password.Hash(*input.Password)
+		if err != nil {
+			return UpdateLinkOutput{}, fmt.Errorf("%s -> %w", operation, err)
+		}
+
+		input.Link.SetPasswordHash(hash)
+	}
+
+	err = u.LinksRepository.Update(ctx, input.Link, input.Password != nil)
+	if err != nil {
+		return UpdateLinkOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	_, _ = u.Cache.Del(ctx, linkCacheKeyPrefix+input.Link.Code)
+
+	link, err := u.LinksRepository.GetLinkByCode(ctx, input.Link.Code)
+	if err != nil {
+		return UpdateLinkOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return UpdateLinkOutput{
+		Link: link,
+	}, nil
+}
@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-api-template/app/domain/entity"
+)
+
+type UpdateMessageStatusInput struct {
+	ProviderID string
+	Status     entity.MessageStatus
+	ErrorCode  string
+}
+
+// UpdateMessageStatus records a delivery status reported by the provider.
+func (u *UseCase) UpdateMessageStatus(ctx context.Context, input UpdateMessageStatusInput) error {
+	const operation = "UseCase.UpdateMessageStatus"
+
+	err := u.MessagesRepository.UpdateStatus(ctx, input.ProviderID, input.Status, input.ErrorCode)
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
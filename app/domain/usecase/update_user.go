@@ -19,5 +19,7 @@ func (u *UseCase) UpdateUser(ctx context.Context, input UpdateUserInput) error {
 		return fmt.Errorf("%s -> %w", operation, err)
 	}
 
+	_, _ = u.Cache.Del(ctx, userCacheKeyPrefix+input.User.ID)
+
 	return nil
 }
@@ -0,0 +1,72 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-api-template/app/domain/entity"
+)
+
+type UpdateWebhookInput struct {
+	// Webhook holds the new URL, events and enabled flag. Enabling a
+	// disabled webhook also clears its failures.
+	Webhook entity.Webhook
+
+	// CallerID is the user making the request. Webhooks of other users are
+	// not found.
+	CallerID string
+}
+
+type UpdateWebhookOutput struct {
+	Webhook entity.Webhook
+}
+
+func (u *UseCase) UpdateWebhook(ctx context.Context, input UpdateWebhookInput) (UpdateWebhookOutput, error) {
+	const operation = "UseCase.UpdateWebhook"
+
+	if _, err := u.getOwnWebhook(ctx, input.Webhook.ID, input.CallerID); err != nil {
+		return UpdateWebhookOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	hook := input.Webhook
+
+	var err error
+
+	hook.URL, err = u.checkWebhookURL(ctx, hook.URL)
+	if err != nil {
+		return UpdateWebhookOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	if err := u.WebhooksRepository.Update(ctx, hook); err != nil {
+		return UpdateWebhookOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	hook, err = u.WebhooksRepository.GetWebhookByID(ctx, hook.ID)
+	if err != nil {
+		return UpdateWebhookOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return UpdateWebhookOutput{
+		Webhook: hook,
+	}, nil
+}
+
+type DeleteWebhookInput struct {
+	ID       string
+	CallerID string
+}
+
+// DeleteWebhook removes a webhook along with its deliveries.
+func (u *UseCase) DeleteWebhook(ctx context.Context, input DeleteWebhookInput) error {
+	const operation = "UseCase.DeleteWebhook"
+
+	if _, err := u.getOwnWebhook(ctx, input.ID, input.CallerID); err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	if err := u.WebhooksRepository.Delete(ctx, input.ID); err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
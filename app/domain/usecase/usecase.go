@@ -2,19 +2,186 @@ package usecase
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/types"
+	"github.com/go-api-template/app/library/geoip"
+	"github.com/go-api-template/app/library/useragent"
 )
 
 type UseCase struct {
 	AppName string
 
+	// Links
+	LinkBaseURL              string
+	LinkCodeLength           int
+	LinkUnlockMaxAttempts    int
+	LinkUnlockAttemptsWindow time.Duration
+	LinkBulkMaxRows          int
+	LinkBulkAsyncThreshold   int
+	URLChecker               urlChecker
+	LinkSigner               linkSigner
+
+	// Exports
+	ExportAsyncThreshold int64
+	ExportMaxRange       time.Duration
+	ExportJobTTL         time.Duration
+	ExportStore          exportStore
+
+	// Background jobs
+	Jobs jobQueue
+
+	// Messaging, disabled while Messenger is nil
+	Messenger              messenger
+	LinkExpiryNoticeBefore time.Duration
+
+	// Webhooks
+	WebhookSender               webhookSender
+	WebhookDisableAfterFailures int
+	WebhookClickSampleRate      float64
+	WebhookAllowPrivateNetworks bool
+
+	// Click counters
+	ClickCounters          clickCounters
+	ClickCountersBatchSize int
+
+	// Live clicks
+	ClickEvents clickEvents
+
+	// Click locations and clients
+	GeoLocator      geoLocator
+	UserAgentParser userAgentParser
+
 	// Repos
-	UsersRepository usersRepository
+	TxManager           txManager
+	UsersRepository     usersRepository
+	LinksRepository     linksRepository
+	ClicksRepository    clicksRepository
+	MessagesRepository  messagesRepository
+	WebhooksRepository  webhooksRepository
+	LinkStatsRepository linkStatsRepository
+
+	// Cache
+	Cache cache
+}
+
+// txManager runs fn in a transaction joined by the repositories called with
+// the ctx it receives, at READ COMMITTED unless an isolation level is given.
+type txManager interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error, isolation ...types.TxIsolation) error
 }
 
 type usersRepository interface {
 	Create(ctx context.Context, user entity.User) error
 	GetUserByID(ctx context.Context, id string) (entity.User, error)
 	Update(ctx context.Context, user entity.User) error
+	GetUserIDByAPIKeyHash(ctx context.Context, hash string) (string, error)
+	SetAPIKeyHash(ctx context.Context, id, hash string) error
+}
+
+type linksRepository interface {
+	Create(ctx context.Context, link entity.Link) error
+	CreateMany(ctx context.Context, links []entity.Link) ([]bool, error)
+	GetLinkByCode(ctx context.Context, code string) (entity.Link, error)
+	Update(ctx context.Context, link entity.Link, updatePassword bool) error
+	CountLinks(ctx context.Context, filter entity.LinksFilter) (int64, error)
+	ExportLinks(ctx context.Context, filter entity.LinksFilter, fn func(entity.Link) error) error
+	ClaimExpiringLinks(ctx context.Context, until time.Time, limit int) ([]entity.Link, error)
+	ClaimExpiredLinks(ctx context.Context, limit int) ([]entity.Link, error)
+}
+
+type messagesRepository interface {
+	Create(ctx context.Context, message entity.Message) error
+	GetMessageByID(ctx context.Context, id string) (entity.Message, error)
+	SetSendResult(ctx context.Context, id, providerID string, status entity.MessageStatus) error
+	UpdateStatus(ctx context.Context, providerID string, status entity.MessageStatus, errorCode string) error
+}
+
+type webhooksRepository interface {
+	Create(ctx context.Context, webhook entity.Webhook) error
+	GetWebhookByID(ctx context.Context, id string) (entity.Webhook, error)
+	ListWebhooks(ctx context.Context, userID string) ([]entity.Webhook, error)
+	ListSubscribedWebhooks(ctx context.Context, userID string, event types.WebhookEvent) ([]entity.Webhook, error)
+	Update(ctx context.Context, webhook entity.Webhook) error
+	Delete(ctx context.Context, id string) error
+	RecordFailure(ctx context.Context, id string, disableAfter int) (bool, error)
+	ResetFailures(ctx context.Context, id string) error
+	CreateDelivery(ctx context.Context, delivery entity.WebhookDelivery) error
+	GetDeliveryByID(ctx context.Context, id string) (entity.WebhookDelivery, error)
+	ListDeliveries(ctx context.Context, filter entity.WebhookDeliveriesFilter) ([]entity.WebhookDelivery, error)
+	RecordAttempt(ctx context.Context, delivery entity.WebhookDelivery) error
+}
+
+type clicksRepository interface {
+	Create(ctx context.Context, click entity.Click) error
+	CountClicks(ctx context.Context, filter entity.ClicksFilter) (int64, error)
+	ExportClicks(ctx context.Context, filter entity.ClicksFilter, fn func(entity.Click) error) error
+	BreakDownClicks(ctx context.Context, linkID string, limit int) (entity.ClickBreakdown, error)
+}
+
+type linkStatsRepository interface {
+	GetLinkStats(ctx context.Context, linkID string) (entity.LinkStats, error)
+	AddClicks(ctx context.Context, counts entity.ClickCounts) (entity.LinkStats, error)
+}
+
+// clickCounters keeps live click counts, flushed into Postgres
+// periodically.
+type clickCounters interface {
+	CountClick(ctx context.Context, linkID, visitorID string) error
+	GetClickCounts(ctx context.Context, linkID string) (entity.ClickCounts, error)
+	PopDirtyLinks(ctx context.Context, limit int) ([]string, error)
+	MarkDirtyLinks(ctx context.Context, linkIDs ...string) error
+	SettleClicks(ctx context.Context, linkID string, clicks int64) error
+}
+
+// clickEvents streams the clicks of links live to their viewers.
+type clickEvents interface {
+	Publish(ctx context.Context, linkID string, event entity.ClickEvent) error
+	Subscribe(ctx context.Context, linkID, lastEventID string) (<-chan entity.ClickEvent, error)
+}
+
+type geoLocator interface {
+	Locate(ip string) geoip.Location
+}
+
+type userAgentParser interface {
+	Parse(userAgent string) useragent.Agent
+}
+
+type jobQueue interface {
+	Enqueue(ctx context.Context, jobType types.Job, payload any) error
+}
+
+type exportStore interface {
+	Create(name string) (io.WriteCloser, error)
+	Open(name string) (io.ReadCloser, error)
+	Delete(name string) error
+	DeleteOlderThan(before time.Time) (int, error)
+}
+
+type urlChecker interface {
+	Check(ctx context.Context, rawURL string) (string, error)
+}
+
+type messenger interface {
+	Send(ctx context.Context, message entity.Message) (providerID string, err error)
+}
+
+type webhookSender interface {
+	Deliver(ctx context.Context, webhook entity.Webhook, delivery entity.WebhookDelivery) (status int, body string, err error)
+}
+
+type linkSigner interface {
+	Sign(code string, expiresAt *time.Time) (signed string, keyID string, err error)
+	Verify(signed string) (code string, err error)
+}
+
+type cache interface {
+	Get(ctx context.Context, key string, objByRef any) error
+	Set(ctx context.Context, key string, obj any, ttl time.Duration) error
+	Del(ctx context.Context, key string) (bool, error)
+	Incr(ctx context.Context, key string, ttl time.Duration) (int64, error)
+	GetOrLoad(ctx context.Context, key string, objByRef any, ttl time.Duration, load func(context.Context) (any, error)) error
 }
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+)
+
+type ListWebhookDeliveriesInput struct {
+	Filter entity.WebhookDeliveriesFilter
+
+	// CallerID is the user making the request. Webhooks of other users are
+	// not found.
+	CallerID string
+}
+
+type ListWebhookDeliveriesOutput struct {
+	Deliveries []entity.WebhookDelivery
+}
+
+func (u *UseCase) ListWebhookDeliveries(ctx context.Context, input ListWebhookDeliveriesInput) (ListWebhookDeliveriesOutput, error) {
+	const operation = "UseCase.ListWebhookDeliveries"
+
+	if _, err := u.getOwnWebhook(ctx, input.Filter.WebhookID, input.CallerID); err != nil {
+		return ListWebhookDeliveriesOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	deliveries, err := u.WebhooksRepository.ListDeliveries(ctx, input.Filter)
+	if err != nil {
+		return ListWebhookDeliveriesOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return ListWebhookDeliveriesOutput{
+		Deliveries: deliveries,
+	}, nil
+}
+
+type ReplayWebhookDeliveryInput struct {
+	WebhookID  string
+	DeliveryID string
+	CallerID   string
+}
+
+type ReplayWebhookDeliveryOutput struct {
+	Delivery entity.WebhookDelivery
+}
+
+// ReplayWebhookDelivery queues a new delivery of the event of a previous one,
+// with the same event ID, so receivers can tell it apart from a new event.
+func (u *UseCase) ReplayWebhookDelivery(ctx context.Context, input ReplayWebhookDeliveryInput) (ReplayWebhookDeliveryOutput, error) {
+	const operation = "UseCase.ReplayWebhookDelivery"
+
+	if _, err := u.getOwnWebhook(ctx, input.WebhookID, input.CallerID); err != nil {
+		return ReplayWebhookDeliveryOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	original, err := u.WebhooksRepository.GetDeliveryByID(ctx, input.DeliveryID)
+	if err != nil {
+		return ReplayWebhookDeliveryOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	if original.WebhookID != input.WebhookID {
+		return ReplayWebhookDeliveryOutput{}, fmt.Errorf("%s -> %w", operation, erring.ErrWebhookDeliveryNotFound)
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return ReplayWebhookDeliveryOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	delivery := entity.WebhookDelivery{
+		ID:        id.String(),
+		WebhookID: original.WebhookID,
+		EventID:   original.EventID,
+		EventType: original.EventType,
+		Payload:   original.Payload,
+		Status:    entity.WebhookDeliveryStatusPending,
+	}
+
+	if err := u.enqueueWebhookDelivery(ctx, delivery); err != nil {
+		return ReplayWebhookDeliveryOutput{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return ReplayWebhookDeliveryOutput{
+		Delivery: delivery,
+	}, nil
+}
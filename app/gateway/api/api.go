@@ -3,6 +3,7 @@ package api
 import (
 	"net/http"
 
+	"github.com/cep21/circuit/v4"
 	"github.com/go-chi/chi/v5"
 
 	"github.com/go-api-template/app/config"
@@ -13,10 +14,11 @@ import (
 )
 
 type API struct {
-	Handler     http.Handler
-	cfg         config.Config
-	useCase     *usecase.UseCase
-	redisClient *redis.Client
+	Handler        http.Handler
+	cfg            config.Config
+	useCase        *usecase.UseCase
+	cache          *redis.LayeredCache
+	circuitManager *circuit.Manager
 }
 
 func BasicHandler() http.Handler {
@@ -26,11 +28,12 @@ func BasicHandler() http.Handler {
 	return router
 }
 
-func New(cfg config.Config, redisClient *redis.Client, useCase *usecase.UseCase) *API {
+func New(cfg config.Config, cache *redis.LayeredCache, useCase *usecase.UseCase, circuitManager *circuit.Manager) *API {
 	api := &API{
-		cfg:         cfg,
-		useCase:     useCase,
-		redisClient: redisClient,
+		cfg:            cfg,
+		useCase:        useCase,
+		cache:          cache,
+		circuitManager: circuitManager,
 	}
 
 	api.setupRouter()
@@ -46,13 +49,17 @@ func (api *API) setupRouter() {
 	}
 
 	router.Use(
-		middleware.CORS,
+		middleware.CORS(api.cfg.CORS),
 		middleware.CleanPath,
 		middleware.StripSlashes,
 		middleware.HeadersToContext,
 		middleware.Recoverer,
 	)
 
+	if len(api.cfg.Postgres.ReplicaDSNs) > 0 {
+		router.Use(middleware.ReadYourWrites(api.cfg.Postgres.ReadYourWritesWindow))
+	}
+
 	api.registerRoutes(router)
 
 	api.Handler = router
@@ -66,7 +73,43 @@ func (api *API) registerRoutes(router *chi.Mux) {
 			publicRouter,
 			api.cfg,
 			api.useCase,
-			api.redisClient,
+			api.cache,
+			api.circuitManager,
+		)
+	})
+
+	router.Route("/api/v1", func(linksRouter chi.Router) {
+		handler.RegisterLinkRoutes(
+			linksRouter,
+			api.cfg,
+			api.useCase,
+			api.cache,
+			api.circuitManager,
+		)
+	})
+
+	// Short links are served from the root, so this must come last.
+	handler.RegisterRedirectRoutes(
+		router,
+		api.cfg,
+		api.useCase,
+		api.cache,
+		api.circuitManager,
+	)
+
+	if api.cfg.Admin.APIToken == "" {
+		return
+	}
+
+	router.Route("/admin/v1", func(adminRouter chi.Router) {
+		adminRouter.Use(middleware.AdminAuth(api.cfg.Admin.APIToken))
+
+		handler.RegisterAdminRoutes(
+			adminRouter,
+			api.cfg,
+			api.useCase,
+			api.cache,
+			api.circuitManager,
 		)
 	})
 }
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/domain/usecase"
+)
+
+// callerID returns the ID of the user whose API key the request carries as a
+// bearer token, or "" for anonymous requests. Requests with an invalid key
+// fail rather than being served as anonymous.
+func (h *Handler) callerID(req *http.Request) (string, error) {
+	authorization := req.Header.Get("Authorization")
+	if authorization == "" {
+		return "", nil
+	}
+
+	key, found := strings.CutPrefix(authorization, "Bearer ")
+	if !found {
+		return "", erring.ErrUserUnauthenticated
+	}
+
+	output, err := h.useCase.AuthenticateUser(req.Context(), usecase.AuthenticateUserInput{APIKey: key})
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	return output.UserID, nil
+}
+
+// requireCallerID is callerID for routes that anonymous requests can't use.
+func (h *Handler) requireCallerID(req *http.Request) (string, error) {
+	userID, err := h.callerID(req)
+	if err == nil && userID == "" {
+		return "", erring.ErrUserUnauthenticated
+	}
+
+	return userID, err
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/handler/schema"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+)
+
+func (h *Handler) CreateLinkSetup(router chi.Router) {
+	const (
+		command = "create-link"
+		pattern = "/links"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.createLink)
+
+	router.Post(pattern, handler)
+}
+
+func (h *Handler) createLink(req *http.Request) *response.Response {
+	var request schema.CreateLinkRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		return response.AppExpectedError(errors.Join(err, erring.ErrRequestInvalid))
+	}
+	defer req.Body.Close()
+
+	if err := request.Validate(); err != nil {
+		return response.BadRequest(err, "invalid link")
+	}
+
+	// Anonymous links have no owner.
+	callerID, err := h.callerID(req)
+	if err != nil {
+		return appError(err)
+	}
+
+	input := usecase.CreateLinkInput{
+		Link: entity.Link{
+			UserID:           callerID,
+			TargetURL:        request.TargetURL,
+			Tags:             request.Tags,
+			ExpiresAt:        request.ExpiresAt,
+			RequireSignature: request.RequireSignature,
+		},
+		Alias:    request.Alias,
+		Password: request.Password,
+	}
+
+	output, err := h.useCase.CreateLink(req.Context(), input)
+	if err != nil {
+		return appError(err)
+	}
+
+	return response.Created(schema.NewLinkResponse(output.Link, h.cfg.Links.BaseURL))
+}
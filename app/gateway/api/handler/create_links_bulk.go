@@ -0,0 +1,137 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/handler/schema"
+	"github.com/go-api-template/app/gateway/api/middleware"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+)
+
+const (
+	// maxLinksBulkUploadSize bounds bulk request bodies, JSON or CSV.
+	maxLinksBulkUploadSize = 32 << 20
+
+	// linksBulkFormFile is the multipart field holding the CSV upload.
+	linksBulkFormFile = "file"
+)
+
+func (h *Handler) CreateLinksBulkSetup(router chi.Router) {
+	const (
+		command = "create-links-bulk"
+		pattern = "/links/bulk"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.createLinksBulk)
+
+	router.With(middleware.RequestSize(maxLinksBulkUploadSize)).Post(pattern, handler)
+}
+
+// createLinksBulk accepts either a JSON array of links, a CSV body or a CSV
+// file uploaded as multipart/form-data.
+func (h *Handler) createLinksBulk(req *http.Request) *response.Response {
+	requests, rowErrs, err := h.readLinksBulkRequest(req)
+	if err != nil {
+		return response.AppExpectedError(errors.Join(err, erring.ErrRequestInvalid))
+	}
+
+	if len(requests) == 0 {
+		return response.BadRequest(erring.ErrRequestInvalid, "no links to create")
+	}
+
+	// Anonymous links have no owner.
+	callerID, err := h.callerID(req)
+	if err != nil {
+		return appError(err)
+	}
+
+	input := usecase.CreateLinksBulkInput{
+		Rows:     make([]usecase.LinksBulkRow, len(requests)),
+		CallerID: callerID,
+	}
+
+	for i, request := range requests {
+		row := usecase.LinksBulkRow{
+			Link: usecase.CreateLinkInput{
+				Link: entity.Link{
+					UserID:           callerID,
+					TargetURL:        request.TargetURL,
+					Tags:             request.Tags,
+					ExpiresAt:        request.ExpiresAt,
+					RequireSignature: request.RequireSignature,
+				},
+				Alias:    request.Alias,
+				Password: request.Password,
+			},
+		}
+
+		if rowErrs != nil && rowErrs[i] != nil {
+			row.Err = rowErrs[i]
+		} else if err := request.Validate(); err != nil {
+			// Keeps the field level code the single create path responds with.
+			payload := response.BadRequestError(err, "invalid link")
+			row.Err = erring.NewAppError(payload.Code, payload.Message)
+		}
+
+		input.Rows[i] = row
+	}
+
+	output, err := h.useCase.CreateLinksBulk(req.Context(), input)
+	if err != nil {
+		return appError(err)
+	}
+
+	if output.Job != nil {
+		return response.Accepted(schema.NewLinksBulkJobResponse(*output.Job, h.cfg.Links.BaseURL)).
+			WithHeaders(map[string]string{"Location": "/api/v1/links/bulk/" + output.Job.ID})
+	}
+
+	return response.OK(schema.NewLinksBulkResponse(output.Results, h.cfg.Links.BaseURL))
+}
+
+func (h *Handler) readLinksBulkRequest(req *http.Request) ([]schema.CreateLinkRequest, []error, error) {
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+
+	switch mediaType {
+	case "text/csv":
+		return schema.NewCreateLinkRequestsFromCSV(req.Body, h.cfg.Links.BulkMaxRows) //nolint:wrapcheck
+	case "multipart/form-data":
+		reader, err := req.MultipartReader()
+		if err != nil {
+			return nil, nil, err //nolint:wrapcheck
+		}
+
+		for {
+			part, err := reader.NextPart()
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					return nil, nil, errors.New("missing csv file field \"" + linksBulkFormFile + "\"")
+				}
+
+				return nil, nil, err //nolint:wrapcheck
+			}
+
+			if part.FormName() == linksBulkFormFile {
+				return schema.NewCreateLinkRequestsFromCSV(part, h.cfg.Links.BulkMaxRows) //nolint:wrapcheck
+			}
+		}
+	default:
+		var requests []schema.CreateLinkRequest
+		if err := json.NewDecoder(req.Body).Decode(&requests); err != nil {
+			return nil, nil, err //nolint:wrapcheck
+		}
+
+		return requests, nil, nil
+	}
+}
@@ -35,9 +35,15 @@ func (h *Handler) createUser(req *http.Request) *response.Response {
 	}
 	defer req.Body.Close()
 
+	if err := request.Validate(); err != nil {
+		return response.BadRequest(err, "invalid user")
+	}
+
 	input := usecase.CreateUserInput{
 		User: entity.User{
-			Name: request.Name,
+			Name:           request.Name,
+			Phone:          request.Phone,
+			MessageChannel: entity.MessageChannel(request.MessageChannel),
 		},
 	}
 
@@ -46,5 +52,5 @@ func (h *Handler) createUser(req *http.Request) *response.Response {
 		return response.AppError(err)
 	}
 
-	return response.OK(schema.CreateUserResponse{ID: output.UserID})
+	return response.OK(schema.CreateUserResponse{ID: output.UserID, APIKey: output.APIKey})
 }
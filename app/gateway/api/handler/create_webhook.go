@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/handler/schema"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+)
+
+func (h *Handler) CreateWebhookSetup(router chi.Router) {
+	const (
+		command = "create-webhook"
+		pattern = "/webhooks"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.createWebhook)
+
+	router.Post(pattern, handler)
+}
+
+func (h *Handler) createWebhook(req *http.Request) *response.Response {
+	var request schema.CreateWebhookRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		return response.AppExpectedError(errors.Join(err, erring.ErrRequestInvalid))
+	}
+	defer req.Body.Close()
+
+	if err := request.Validate(); err != nil {
+		return response.BadRequest(err, "invalid webhook")
+	}
+
+	userID, err := h.requireCallerID(req)
+	if err != nil {
+		return appError(err)
+	}
+
+	input := usecase.CreateWebhookInput{
+		Webhook: entity.Webhook{
+			UserID: userID,
+			URL:    request.URL,
+			Events: schema.WebhookEvents(request.Events),
+		},
+	}
+
+	output, err := h.useCase.CreateWebhook(req.Context(), input)
+	if err != nil {
+		return appError(err)
+	}
+
+	// The secret is only ever shown here.
+	resp := schema.NewWebhookResponse(output.Webhook)
+	resp.Secret = output.Webhook.Secret
+
+	return response.Created(resp)
+}
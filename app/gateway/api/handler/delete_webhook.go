@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+)
+
+func (h *Handler) DeleteWebhookSetup(router chi.Router) {
+	const (
+		command = "delete-webhook"
+		pattern = "/webhooks/{id}"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.deleteWebhook)
+
+	router.Delete(pattern, handler)
+}
+
+func (h *Handler) deleteWebhook(req *http.Request) *response.Response {
+	userID, err := h.requireCallerID(req)
+	if err != nil {
+		return appError(err)
+	}
+
+	input := usecase.DeleteWebhookInput{
+		ID:       chi.URLParam(req, "id"),
+		CallerID: userID,
+	}
+
+	if err := h.useCase.DeleteWebhook(req.Context(), input); err != nil {
+		return appError(err)
+	}
+
+	return response.NoContent()
+}
@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+)
+
+func (h *Handler) DownloadExportSetup(router chi.Router) {
+	const (
+		command = "download-export"
+		pattern = "/exports/{id}/download"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.downloadExport)
+
+	router.Get(pattern, handler)
+}
+
+func (h *Handler) downloadExport(req *http.Request) *response.Response {
+	userID, err := h.requireCallerID(req)
+	if err != nil {
+		return appError(err)
+	}
+
+	input := usecase.DownloadExportInput{
+		ID:       chi.URLParam(req, "id"),
+		CallerID: userID,
+	}
+
+	output, err := h.useCase.DownloadExport(req.Context(), input)
+	if err != nil {
+		return appError(err)
+	}
+
+	// The stream body closes the file once sent.
+	return response.Stream(output.ContentType, output.File).
+		WithHeaders(map[string]string{"Content-Disposition": attachment(output.FileName)})
+}
@@ -0,0 +1,50 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/handler/schema"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+	"github.com/go-api-template/app/library/export"
+)
+
+func (h *Handler) ExportClicksSetup(router chi.Router) {
+	const (
+		command = "export-clicks"
+		pattern = "/clicks/export"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.exportClicks)
+
+	router.Get(pattern, handler)
+}
+
+func (h *Handler) exportClicks(req *http.Request) *response.Response {
+	request := schema.NewExportClicksRequest(req.URL.Query())
+
+	if err := request.Validate(); err != nil {
+		return response.BadRequest(err, "invalid export options")
+	}
+
+	userID, err := h.requireCallerID(req)
+	if err != nil {
+		return appError(err)
+	}
+
+	input := usecase.ExportClicksInput{
+		Filter: request.Filter(userID),
+		Format: export.Format(request.Format),
+	}
+
+	output, err := h.useCase.ExportClicks(req.Context(), input)
+	if err != nil {
+		return appError(err)
+	}
+
+	return exportResponse(output)
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/handler/schema"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+	"github.com/go-api-template/app/library/export"
+)
+
+func (h *Handler) ExportLinksSetup(router chi.Router) {
+	const (
+		command = "export-links"
+		pattern = "/links/export"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.exportLinks)
+
+	router.Get(pattern, handler)
+}
+
+func (h *Handler) exportLinks(req *http.Request) *response.Response {
+	request := schema.NewExportLinksRequest(req.URL.Query())
+
+	if err := request.Validate(); err != nil {
+		return response.BadRequest(err, "invalid export options")
+	}
+
+	userID, err := h.requireCallerID(req)
+	if err != nil {
+		return appError(err)
+	}
+
+	input := usecase.ExportLinksInput{
+		Filter: request.Filter(userID),
+		Format: export.Format(request.Format),
+	}
+
+	output, err := h.useCase.ExportLinks(req.Context(), input)
+	if err != nil {
+		return appError(err)
+	}
+
+	return exportResponse(output)
+}
+
+// exportResponse streams small exports and answers large ones with the job
+// writing them in the background.
+func exportResponse(output usecase.ExportOutput) *response.Response {
+	if output.Job != nil {
+		return response.Accepted(schema.NewExportJobResponse(*output.Job)).
+			WithHeaders(map[string]string{"Location": "/api/v1/exports/" + output.Job.ID})
+	}
+
+	return response.Stream(output.ContentType, output.Stream).
+		WithHeaders(map[string]string{"Content-Disposition": attachment(output.FileName)})
+}
+
+func attachment(fileName string) string {
+	return `attachment; filename="` + fileName + `"`
+}
@@ -0,0 +1,34 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/gateway/api/handler/schema"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+	"github.com/go-api-template/app/library/circuitbreaker"
+)
+
+func (h *Handler) GetCircuitSetup(router chi.Router) {
+	const (
+		command = "get-circuit"
+		pattern = "/circuits/{name}"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.getCircuit)
+
+	router.Get(pattern, handler)
+}
+
+func (h *Handler) getCircuit(req *http.Request) *response.Response {
+	circ := h.circuitManager.GetCircuit(chi.URLParam(req, "name"))
+	if circ == nil {
+		return response.AppExpectedError(erring.ErrCircuitNotFound)
+	}
+
+	return response.OK(schema.NewCircuitResponse(circuitbreaker.Snapshot(circ)))
+}
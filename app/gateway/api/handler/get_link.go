@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/handler/schema"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+)
+
+func (h *Handler) GetLinkSetup(router chi.Router) {
+	const (
+		command = "get-link"
+		pattern = "/links/{code}"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.getLink)
+
+	router.Get(pattern, handler)
+}
+
+func (h *Handler) getLink(req *http.Request) *response.Response {
+	callerID, err := h.callerID(req)
+	if err != nil {
+		return appError(err)
+	}
+
+	input := usecase.GetLinkInput{
+		Code:     chi.URLParam(req, "code"),
+		CallerID: callerID,
+	}
+
+	output, err := h.useCase.GetLink(req.Context(), input)
+	if err != nil {
+		return appError(err)
+	}
+
+	return response.OK(schema.NewLinkDetailsResponse(output.Link, output.Stats, h.cfg.Links.BaseURL))
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/handler/schema"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+)
+
+func (h *Handler) GetLinkQRCodeSetup(router chi.Router) {
+	const (
+		command = "get-link-qr-code"
+		pattern = "/links/{code}/qr"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.getLinkQRCode)
+
+	router.Get(pattern, handler)
+}
+
+func (h *Handler) getLinkQRCode(req *http.Request) *response.Response {
+	request := schema.NewLinkQRCodeRequest(req.URL.Query())
+
+	if err := request.Validate(); err != nil {
+		return response.BadRequest(err, "invalid qr code options")
+	}
+
+	input := usecase.GetLinkQRCodeInput{
+		Code:    chi.URLParam(req, "code"),
+		Options: request.Options(),
+	}
+
+	output, err := h.useCase.GetLinkQRCode(req.Context(), input)
+	if err != nil {
+		return appError(err)
+	}
+
+	// Revalidated on every use, so caches stop serving the QR codes of links
+	// once they are deleted or expire.
+	headers := map[string]string{
+		"ETag":          output.ETag,
+		"Cache-Control": "private, no-cache",
+	}
+
+	if req.Header.Get("If-None-Match") == output.ETag {
+		return response.NotModified().WithHeaders(headers)
+	}
+
+	return response.Binary(output.ContentType, output.Image).WithHeaders(headers)
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/handler/schema"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+)
+
+func (h *Handler) GetLinksBulkJobSetup(router chi.Router) {
+	const (
+		command = "get-links-bulk-job"
+		pattern = "/links/bulk/{id}"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.getLinksBulkJob)
+
+	router.Get(pattern, handler)
+}
+
+func (h *Handler) getLinksBulkJob(req *http.Request) *response.Response {
+	callerID, err := h.requireCallerID(req)
+	if err != nil {
+		return appError(err)
+	}
+
+	input := usecase.GetLinksBulkJobInput{
+		ID:       chi.URLParam(req, "id"),
+		CallerID: callerID,
+	}
+
+	output, err := h.useCase.GetLinksBulkJob(req.Context(), input)
+	if err != nil {
+		return appError(err)
+	}
+
+	return response.OK(schema.NewLinksBulkJobResponse(output.Job, h.cfg.Links.BaseURL))
+}
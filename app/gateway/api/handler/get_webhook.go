@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/handler/schema"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+)
+
+func (h *Handler) GetWebhookSetup(router chi.Router) {
+	const (
+		command = "get-webhook"
+		pattern = "/webhooks/{id}"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.getWebhook)
+
+	router.Get(pattern, handler)
+}
+
+func (h *Handler) getWebhook(req *http.Request) *response.Response {
+	userID, err := h.requireCallerID(req)
+	if err != nil {
+		return appError(err)
+	}
+
+	input := usecase.GetWebhookInput{
+		ID:       chi.URLParam(req, "id"),
+		CallerID: userID,
+	}
+
+	output, err := h.useCase.GetWebhook(req.Context(), input)
+	if err != nil {
+		return appError(err)
+	}
+
+	return response.OK(schema.NewWebhookResponse(output.Webhook))
+}
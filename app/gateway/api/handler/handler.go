@@ -2,15 +2,17 @@ package handler
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/cep21/circuit/v4"
-	"github.com/cep21/circuit/v4/closers/hystrix"
 	"github.com/go-chi/chi/v5"
 
 	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/domain/erring"
 	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/rest/response"
 )
 
 type Handler struct {
@@ -20,33 +22,7 @@ type Handler struct {
 	cache          cache
 }
 
-func New(cfg config.Config, useCase useCase, cache cache) Handler {
-	hystrixFactory := hystrix.Factory{
-		ConfigureOpener: hystrix.ConfigureOpener{
-			ErrorThresholdPercentage: int64(cfg.CircuitBreaker.ErrorPercentThreshold),
-			RequestVolumeThreshold:   int64(cfg.CircuitBreaker.RequestVolumeThreshold),
-		},
-		ConfigureCloser: hystrix.ConfigureCloser{
-			SleepWindow: cfg.CircuitBreaker.SleepWindow,
-		},
-	}
-
-	defaultFactory := func(_ string) circuit.Config {
-		return circuit.Config{
-			Execution: circuit.ExecutionConfig{
-				MaxConcurrentRequests: int64(cfg.CircuitBreaker.MaxConcurrentRequests),
-				Timeout:               cfg.CircuitBreaker.Timeout,
-			},
-		}
-	}
-
-	circuitManager := &circuit.Manager{
-		DefaultCircuitProperties: []circuit.CommandPropertiesConstructor{
-			defaultFactory,
-			hystrixFactory.Configure,
-		},
-	}
-
+func New(cfg config.Config, useCase useCase, cache cache, circuitManager *circuit.Manager) Handler {
 	return Handler{
 		circuitManager: circuitManager,
 		cfg:            cfg,
@@ -66,10 +42,70 @@ func RegisterPublicRoutes(
 	cfg config.Config,
 	useCase useCase,
 	cache cache,
+	circuitManager *circuit.Manager,
 ) {
-	handler := New(cfg, useCase, cache)
+	handler := New(cfg, useCase, cache, circuitManager)
 
 	handler.GetUserSetup(router)
+	handler.TwilioStatusSetup(router)
+}
+
+func RegisterLinkRoutes(
+	router chi.Router,
+	cfg config.Config,
+	useCase useCase,
+	cache cache,
+	circuitManager *circuit.Manager,
+) {
+	handler := New(cfg, useCase, cache, circuitManager)
+
+	handler.CreateLinkSetup(router)
+	handler.GetLinkSetup(router)
+	handler.StreamLinkEventsSetup(router)
+	handler.UpdateLinkSetup(router)
+	handler.SignLinkSetup(router)
+	handler.GetLinkQRCodeSetup(router)
+	handler.CreateLinksBulkSetup(router)
+	handler.GetLinksBulkJobSetup(router)
+	handler.ExportLinksSetup(router)
+	handler.ExportClicksSetup(router)
+	handler.GetExportJobSetup(router)
+	handler.DownloadExportSetup(router)
+	handler.CreateWebhookSetup(router)
+	handler.ListWebhooksSetup(router)
+	handler.GetWebhookSetup(router)
+	handler.UpdateWebhookSetup(router)
+	handler.DeleteWebhookSetup(router)
+	handler.ListWebhookDeliveriesSetup(router)
+	handler.ReplayWebhookDeliverySetup(router)
+}
+
+func RegisterRedirectRoutes(
+	router chi.Router,
+	cfg config.Config,
+	useCase useCase,
+	cache cache,
+	circuitManager *circuit.Manager,
+) {
+	handler := New(cfg, useCase, cache, circuitManager)
+
+	handler.RedirectSetup(router)
+	handler.UnlockLinkSetup(router)
+}
+
+func RegisterAdminRoutes(
+	router chi.Router,
+	cfg config.Config,
+	useCase useCase,
+	cache cache,
+	circuitManager *circuit.Manager,
+) {
+	handler := New(cfg, useCase, cache, circuitManager)
+
+	handler.ListCircuitsSetup(router)
+	handler.GetCircuitSetup(router)
+	handler.UpdateCircuitModeSetup(router)
+	handler.IssueUserAPIKeySetup(router)
 }
 
 type cache interface {
@@ -82,4 +118,44 @@ type useCase interface {
 	CreateUser(ctx context.Context, input usecase.CreateUserInput) (usecase.CreateUserOutput, error)
 	GetUser(ctx context.Context, input usecase.GetUserInput) (usecase.GetUserOutput, error)
 	UpdateUser(ctx context.Context, input usecase.UpdateUserInput) error
+	UpdateMessageStatus(ctx context.Context, input usecase.UpdateMessageStatusInput) error
+	AuthenticateUser(ctx context.Context, input usecase.AuthenticateUserInput) (usecase.AuthenticateUserOutput, error)
+	IssueUserAPIKey(ctx context.Context, input usecase.IssueUserAPIKeyInput) (usecase.IssueUserAPIKeyOutput, error)
+
+	CreateLink(ctx context.Context, input usecase.CreateLinkInput) (usecase.CreateLinkOutput, error)
+	GetLink(ctx context.Context, input usecase.GetLinkInput) (usecase.GetLinkOutput, error)
+	ResolveLink(ctx context.Context, input usecase.ResolveLinkInput) (usecase.ResolveLinkOutput, error)
+	UpdateLink(ctx context.Context, input usecase.UpdateLinkInput) (usecase.UpdateLinkOutput, error)
+	UnlockLink(ctx context.Context, input usecase.UnlockLinkInput) (usecase.UnlockLinkOutput, error)
+	SignLink(ctx context.Context, input usecase.SignLinkInput) (usecase.SignLinkOutput, error)
+	GetLinkQRCode(ctx context.Context, input usecase.GetLinkQRCodeInput) (usecase.GetLinkQRCodeOutput, error)
+	CreateLinksBulk(ctx context.Context, input usecase.CreateLinksBulkInput) (usecase.CreateLinksBulkOutput, error)
+	GetLinksBulkJob(ctx context.Context, input usecase.GetLinksBulkJobInput) (usecase.GetLinksBulkJobOutput, error)
+	RecordClick(ctx context.Context, input usecase.RecordClickInput)
+	StreamLinkEvents(ctx context.Context, input usecase.StreamLinkEventsInput) (usecase.StreamLinkEventsOutput, error)
+
+	ExportLinks(ctx context.Context, input usecase.ExportLinksInput) (usecase.ExportOutput, error)
+	ExportClicks(ctx context.Context, input usecase.ExportClicksInput) (usecase.ExportOutput, error)
+	GetExportJob(ctx context.Context, input usecase.GetExportJobInput) (usecase.GetExportJobOutput, error)
+	DownloadExport(ctx context.Context, input usecase.DownloadExportInput) (usecase.DownloadExportOutput, error)
+
+	CreateWebhook(ctx context.Context, input usecase.CreateWebhookInput) (usecase.CreateWebhookOutput, error)
+	ListWebhooks(ctx context.Context, input usecase.ListWebhooksInput) (usecase.ListWebhooksOutput, error)
+	GetWebhook(ctx context.Context, input usecase.GetWebhookInput) (usecase.GetWebhookOutput, error)
+	UpdateWebhook(ctx context.Context, input usecase.UpdateWebhookInput) (usecase.UpdateWebhookOutput, error)
+	DeleteWebhook(ctx context.Context, input usecase.DeleteWebhookInput) error
+	ListWebhookDeliveries(ctx context.Context, input usecase.ListWebhookDeliveriesInput) (usecase.ListWebhookDeliveriesOutput, error)
+	ReplayWebhookDelivery(ctx context.Context, input usecase.ReplayWebhookDeliveryInput) (usecase.ReplayWebhookDeliveryOutput, error)
+}
+
+// appError builds the error response for a use case error. Domain errors (not
+// found, rejected input...) are flagged as expected so they don't open the
+// handler circuit.
+func appError(err error) *response.Response {
+	var appErr erring.AppError
+	if errors.As(err, &appErr) {
+		return response.AppExpectedError(err)
+	}
+
+	return response.AppError(err)
 }
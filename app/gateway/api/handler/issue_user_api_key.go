@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/handler/schema"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+)
+
+func (h *Handler) IssueUserAPIKeySetup(router chi.Router) {
+	const (
+		command = "issue-user-api-key"
+		pattern = "/users/{id}/api-key"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.issueUserAPIKey)
+
+	router.Post(pattern, handler)
+}
+
+func (h *Handler) issueUserAPIKey(req *http.Request) *response.Response {
+	input := usecase.IssueUserAPIKeyInput{
+		UserID: chi.URLParam(req, "id"),
+	}
+
+	output, err := h.useCase.IssueUserAPIKey(req.Context(), input)
+	if err != nil {
+		return appError(err)
+	}
+
+	return response.OK(schema.UserAPIKeyResponse{APIKey: output.APIKey}).WithHeaders(map[string]string{
+		"Cache-Control": "no-store",
+	})
+}
@@ -0,0 +1,43 @@
+package handler
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/gateway/api/handler/schema"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+	"github.com/go-api-template/app/library/circuitbreaker"
+)
+
+func (h *Handler) ListCircuitsSetup(router chi.Router) {
+	const (
+		command = "list-circuits"
+		pattern = "/circuits"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.listCircuits)
+
+	router.Get(pattern, handler)
+}
+
+func (h *Handler) listCircuits(_ *http.Request) *response.Response {
+	circuits := h.circuitManager.AllCircuits()
+
+	resp := schema.ListCircuitsResponse{
+		Circuits: make([]schema.CircuitResponse, 0, len(circuits)),
+	}
+
+	for _, circ := range circuits {
+		resp.Circuits = append(resp.Circuits, schema.NewCircuitResponse(circuitbreaker.Snapshot(circ)))
+	}
+
+	sort.Slice(resp.Circuits, func(i, j int) bool {
+		return resp.Circuits[i].Name < resp.Circuits[j].Name
+	})
+
+	return response.OK(resp)
+}
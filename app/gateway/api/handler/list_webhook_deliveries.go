@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/handler/schema"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+)
+
+func (h *Handler) ListWebhookDeliveriesSetup(router chi.Router) {
+	const (
+		command = "list-webhook-deliveries"
+		pattern = "/webhooks/{id}/deliveries"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.listWebhookDeliveries)
+
+	router.Get(pattern, handler)
+}
+
+func (h *Handler) listWebhookDeliveries(req *http.Request) *response.Response {
+	request := schema.NewListWebhookDeliveriesRequest(req.URL.Query())
+
+	if err := request.Validate(); err != nil {
+		return response.BadRequest(err, "invalid deliveries query")
+	}
+
+	userID, err := h.requireCallerID(req)
+	if err != nil {
+		return appError(err)
+	}
+
+	input := usecase.ListWebhookDeliveriesInput{
+		Filter:   request.Filter(chi.URLParam(req, "id")),
+		CallerID: userID,
+	}
+
+	output, err := h.useCase.ListWebhookDeliveries(req.Context(), input)
+	if err != nil {
+		return appError(err)
+	}
+
+	return response.OK(schema.NewWebhookDeliveriesResponse(output.Deliveries, input.Filter.Limit))
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/handler/schema"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+)
+
+func (h *Handler) ListWebhooksSetup(router chi.Router) {
+	const (
+		command = "list-webhooks"
+		pattern = "/webhooks"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.listWebhooks)
+
+	router.Get(pattern, handler)
+}
+
+func (h *Handler) listWebhooks(req *http.Request) *response.Response {
+	userID, err := h.requireCallerID(req)
+	if err != nil {
+		return appError(err)
+	}
+
+	input := usecase.ListWebhooksInput{
+		UserID: userID,
+	}
+
+	output, err := h.useCase.ListWebhooks(req.Context(), input)
+	if err != nil {
+		return appError(err)
+	}
+
+	return response.OK(schema.NewWebhooksResponse(output.Webhooks))
+}
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/handler/schema"
+	"github.com/go-api-template/app/gateway/api/resource/interstitial"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+	"github.com/go-api-template/app/library/clientip"
+)
+
+func (h *Handler) RedirectSetup(router chi.Router) {
+	const (
+		command = "redirect"
+		pattern = "/{code}"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.redirect)
+
+	router.Get(pattern, handler)
+}
+
+func (h *Handler) redirect(req *http.Request) *response.Response {
+	// Signed codes are verified by the use case before any lookup.
+	input := usecase.ResolveLinkInput{
+		Code:      chi.URLParam(req, "code"),
+		UserAgent: req.UserAgent(),
+	}
+
+	output, err := h.useCase.ResolveLink(req.Context(), input)
+	if err != nil {
+		return appError(err)
+	}
+
+	if output.Link.IsPasswordProtected() && !h.hasUnlockCookie(req, input.Code, output.Link) {
+		return response.Redirect(http.StatusFound, "/"+input.Code+"/unlock").WithHeaders(map[string]string{
+			"Cache-Control": "private, no-cache",
+		})
+	}
+
+	// Link previews are not visits, so they are shown the link instead of
+	// being counted.
+	if output.Preview {
+		return response.HTML(http.StatusOK, interstitial.Preview, h.previewPage(input.Code, output.Link)).WithHeaders(map[string]string{
+			"Cache-Control": "private, no-cache",
+		})
+	}
+
+	h.recordClick(req, output.Link)
+
+	// Links can change target, so the redirect must not be cached by browsers.
+	return response.Redirect(http.StatusFound, output.Link.TargetURL).WithHeaders(map[string]string{
+		"Cache-Control": "private, no-cache",
+	})
+}
+
+// recordClick counts a visit that reaches the link target.
+func (h *Handler) recordClick(req *http.Request, link entity.Link) {
+	h.useCase.RecordClick(req.Context(), usecase.RecordClickInput{
+		Link:      link,
+		Referrer:  req.Referer(),
+		UserAgent: req.UserAgent(),
+		ClientIP:  clientip.FromRequest(req, h.cfg.Server.TrustedProxies),
+	})
+}
+
+func (h *Handler) previewPage(code string, link entity.Link) interstitial.PreviewPage {
+	title := link.TargetURL
+	if target, err := url.Parse(link.TargetURL); err == nil && target.Host != "" {
+		title = target.Host
+	}
+
+	return interstitial.PreviewPage{
+		Title:     title,
+		TargetURL: link.TargetURL,
+		ShortURL:  schema.ShortURL(h.cfg.Links.BaseURL, code),
+		SiteName:  h.cfg.App.Name,
+	}
+}
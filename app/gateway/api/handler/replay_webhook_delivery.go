@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/handler/schema"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+)
+
+func (h *Handler) ReplayWebhookDeliverySetup(router chi.Router) {
+	const (
+		command = "replay-webhook-delivery"
+		pattern = "/webhooks/{id}/deliveries/{delivery_id}/replay"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.replayWebhookDelivery)
+
+	router.Post(pattern, handler)
+}
+
+func (h *Handler) replayWebhookDelivery(req *http.Request) *response.Response {
+	userID, err := h.requireCallerID(req)
+	if err != nil {
+		return appError(err)
+	}
+
+	input := usecase.ReplayWebhookDeliveryInput{
+		WebhookID:  chi.URLParam(req, "id"),
+		DeliveryID: chi.URLParam(req, "delivery_id"),
+		CallerID:   userID,
+	}
+
+	output, err := h.useCase.ReplayWebhookDelivery(req.Context(), input)
+	if err != nil {
+		return appError(err)
+	}
+
+	return response.Accepted(schema.NewWebhookDeliveryResponse(output.Delivery))
+}
@@ -0,0 +1,45 @@
+package schema
+
+import (
+	"time"
+
+	"github.com/go-api-template/app/domain/entity"
+)
+
+type ClickEventResponse struct {
+	// Código do link clicado
+	Code string `json:"code" extensions:"x-order=0" example:"aZ3kP9q"`
+	// Data do clique
+	ClickedAt time.Time `json:"clicked_at" extensions:"x-order=1"`
+	// Página de origem do clique
+	Referrer string `json:"referrer,omitempty" extensions:"x-order=2" example:"https://news.ycombinator.com/"`
+	// Código ISO 3166-1 do país de origem
+	Country string `json:"country,omitempty" extensions:"x-order=3" example:"BR"`
+	// Código ISO 3166-2 da região de origem
+	Region string `json:"region,omitempty" extensions:"x-order=4" example:"SP"`
+	// Cidade de origem, em inglês
+	City string `json:"city,omitempty" extensions:"x-order=5" example:"São Paulo"`
+	// Navegador de origem
+	Browser string `json:"browser,omitempty" extensions:"x-order=6" example:"Chrome"`
+	// Sistema operacional de origem
+	OS string `json:"os,omitempty" extensions:"x-order=7" example:"Android"`
+	// Tipo de dispositivo de origem: desktop, mobile, tablet ou bot
+	Device string `json:"device,omitempty" extensions:"x-order=8" example:"mobile"`
+	// Indica se o clique veio de um bot ou de uma prévia de link
+	Bot bool `json:"bot" extensions:"x-order=9"`
+}
+
+func NewClickEventResponse(event entity.ClickEvent) ClickEventResponse {
+	return ClickEventResponse{
+		Code:      event.Code,
+		ClickedAt: event.ClickedAt,
+		Referrer:  event.Referrer,
+		Country:   event.Country,
+		Region:    event.Region,
+		City:      event.City,
+		Browser:   event.Browser,
+		OS:        event.OS,
+		Device:    event.Device,
+		Bot:       event.Bot,
+	}
+}
@@ -1,17 +1,54 @@
 package schema
 
+import (
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/go-api-template/app/domain/entity"
+)
+
+// E.164: a plus sign and up to 15 digits.
+var phoneRegex = regexp.MustCompile(`^\+[1-9][0-9]{6,14}$`)
+
 // INPUTS.
 type (
 	CreateUserRequest struct {
 		// Nome do usuário
 		Name string `json:"name" extensions:"x-order=0"`
+		// Telefone no formato E.164, para receber os links
+		Phone string `json:"phone,omitempty" extensions:"x-order=1" example:"+5511999999999"`
+		// Canal das mensagens: whatsapp ou sms
+		MessageChannel string `json:"message_channel,omitempty" extensions:"x-order=2" example:"whatsapp"`
 	}
 )
 
+func (r CreateUserRequest) Validate() error {
+	return validation.ValidateStruct(&r, //nolint:wrapcheck
+		validation.Field(&r.Phone, validation.Match(phoneRegex)),
+		validation.Field(&r.MessageChannel, messageChannelRules(r.Phone)...),
+	)
+}
+
 // RESPONSES.
 type (
 	CreateUserResponse struct {
 		// ID do usuário criado
 		ID string `json:"id" extensions:"x-order=0"`
+		// Chave de API do usuário, exibida apenas uma vez
+		APIKey string `json:"api_key" extensions:"x-order=1"`
+	}
+
+	UserAPIKeyResponse struct {
+		// Chave de API do usuário, exibida apenas uma vez
+		APIKey string `json:"api_key" extensions:"x-order=0"`
 	}
 )
+
+// messageChannelRules requires a channel along with a phone.
+func messageChannelRules(phone string) []validation.Rule {
+	return []validation.Rule{
+		validation.When(phone != "", validation.Required),
+		validation.In(string(entity.MessageChannelWhatsApp), string(entity.MessageChannelSMS)),
+	}
+}
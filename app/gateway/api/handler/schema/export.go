@@ -0,0 +1,133 @@
+package schema
+
+import (
+	"net/url"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/library/export"
+)
+
+var exportFormats = []any{string(export.FormatCSV), string(export.FormatNDJSON), string(export.FormatParquet)}
+
+// INPUTS.
+type (
+	// Os links exportados são os do usuário autenticado.
+	ExportLinksRequest struct {
+		// Formato do arquivo: csv, ndjson ou parquet
+		Format string `json:"format" extensions:"x-order=0" example:"csv"`
+	}
+
+	// Os cliques exportados são os dos links do usuário autenticado.
+	ExportClicksRequest struct {
+		// Código de um único link
+		Code string `json:"code" extensions:"x-order=0"`
+		// Início do período, em RFC 3339
+		From string `json:"from" extensions:"x-order=1" example:"2024-01-01T00:00:00Z"`
+		// Fim do período, em RFC 3339
+		To string `json:"to" extensions:"x-order=2" example:"2024-02-01T00:00:00Z"`
+		// Formato do arquivo: csv, ndjson ou parquet
+		Format string `json:"format" extensions:"x-order=3" example:"csv"`
+	}
+)
+
+func NewExportLinksRequest(query url.Values) ExportLinksRequest {
+	return ExportLinksRequest{
+		Format: exportFormat(query),
+	}
+}
+
+func (r ExportLinksRequest) Validate() error {
+	return validation.ValidateStruct(&r, //nolint:wrapcheck
+		validation.Field(&r.Format, validation.In(exportFormats...)),
+	)
+}
+
+// Filter selects the links of userID.
+func (r ExportLinksRequest) Filter(userID string) entity.LinksFilter {
+	return entity.LinksFilter{
+		UserID: userID,
+	}
+}
+
+func NewExportClicksRequest(query url.Values) ExportClicksRequest {
+	return ExportClicksRequest{
+		Code:   query.Get("code"),
+		From:   query.Get("from"),
+		To:     query.Get("to"),
+		Format: exportFormat(query),
+	}
+}
+
+func (r ExportClicksRequest) Validate() error {
+	return validation.ValidateStruct(&r, //nolint:wrapcheck
+		validation.Field(&r.From, validation.Required, validation.Date(time.RFC3339)),
+		validation.Field(&r.To, validation.Required, validation.Date(time.RFC3339)),
+		validation.Field(&r.Format, validation.In(exportFormats...)),
+	)
+}
+
+// Filter selects the clicks on the links of userID. It must only be called
+// on a valid request.
+func (r ExportClicksRequest) Filter(userID string) entity.ClicksFilter {
+	from, _ := time.Parse(time.RFC3339, r.From)
+	to, _ := time.Parse(time.RFC3339, r.To)
+
+	return entity.ClicksFilter{
+		UserID: userID,
+		Code:   r.Code,
+		From:   from,
+		To:     to,
+	}
+}
+
+func exportFormat(query url.Values) string {
+	if format := query.Get("format"); format != "" {
+		return format
+	}
+
+	return string(export.FormatCSV)
+}
+
+// RESPONSES.
+type (
+	ExportJobResponse struct {
+		// ID do job
+		ID string `json:"id" extensions:"x-order=0"`
+		// Dados exportados: links ou clicks
+		Kind string `json:"kind" extensions:"x-order=1" example:"clicks"`
+		// Formato do arquivo
+		Format string `json:"format" extensions:"x-order=2" example:"parquet"`
+		// Estado do job: running, succeeded ou failed
+		Status string `json:"status" extensions:"x-order=3" example:"running"`
+		// Quantidade de linhas exportadas
+		Rows int64 `json:"rows" extensions:"x-order=4" example:"250000"`
+		// Motivo da falha do job
+		Error string `json:"error,omitempty" extensions:"x-order=5"`
+		// Endereço para baixar o arquivo, quando o job termina com sucesso
+		DownloadURL string     `json:"download_url,omitempty" extensions:"x-order=6"`
+		CreatedAt   time.Time  `json:"created_at" extensions:"x-order=7"`
+		FinishedAt  *time.Time `json:"finished_at,omitempty" extensions:"x-order=8"`
+	}
+)
+
+func NewExportJobResponse(job entity.ExportJob) ExportJobResponse {
+	resp := ExportJobResponse{
+		ID:         job.ID,
+		Kind:       job.Kind,
+		Format:     job.Format,
+		Status:     string(job.Status),
+		Rows:       job.Rows,
+		Error:      job.Error,
+		CreatedAt:  job.CreatedAt,
+		FinishedAt: job.FinishedAt,
+	}
+
+	if job.Status == entity.ExportJobStatusSucceeded {
+		resp.DownloadURL = "/api/v1/exports/" + job.ID + "/download"
+	}
+
+	return resp
+}
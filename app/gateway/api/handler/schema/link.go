@@ -0,0 +1,201 @@
+package schema
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/go-api-template/app/domain/entity"
+)
+
+var aliasRegex = regexp.MustCompile(`^[A-Za-z0-9_-]{3,32}$`)
+
+// Root paths of other routes, which are matched before short links, so links
+// with these aliases would be unreachable.
+var reservedAliases = []any{"healthcheck", "api", "admin"}
+
+var tagRegex = regexp.MustCompile(`^[a-z0-9_-]{1,32}$`)
+
+const (
+	maxPasswordLength = 128
+	maxTags           = 10
+)
+
+// INPUTS.
+type (
+	CreateLinkRequest struct {
+		// URL de destino
+		TargetURL string `json:"target_url" extensions:"x-order=0" example:"https://example.com/campaign"`
+		// Código personalizado do link (opcional)
+		Alias string `json:"alias,omitempty" extensions:"x-order=1" example:"launch-2024"`
+		// Data de expiração do link (opcional)
+		ExpiresAt *time.Time `json:"expires_at,omitempty" extensions:"x-order=2"`
+		// Senha exigida antes do redirecionamento (opcional)
+		Password string `json:"password,omitempty" extensions:"x-order=3"`
+		// Só redireciona por códigos assinados (opcional)
+		RequireSignature bool `json:"require_signature,omitempty" extensions:"x-order=4"`
+		// Tags para agrupar links, e.g. por campanha (opcional)
+		Tags []string `json:"tags,omitempty" extensions:"x-order=5" example:"launch,email"`
+	}
+
+	UpdateLinkRequest struct {
+		// URL de destino
+		TargetURL string `json:"target_url" extensions:"x-order=0" example:"https://example.com/campaign"`
+		// Data de expiração do link (opcional)
+		ExpiresAt *time.Time `json:"expires_at,omitempty" extensions:"x-order=1"`
+		// Nova senha do link; vazia remove a proteção, ausente mantém a atual
+		Password *string `json:"password,omitempty" extensions:"x-order=2"`
+	}
+)
+
+func (r CreateLinkRequest) Validate() error {
+	return validation.ValidateStruct(&r, //nolint:wrapcheck
+		validation.Field(&r.TargetURL, validation.Required, validation.Length(1, 2048)),
+		validation.Field(&r.Alias,
+			validation.Match(aliasRegex),
+			validation.NotIn(reservedAliases...).Error("must not be a reserved path"),
+		),
+		validation.Field(&r.Password, validation.Length(0, maxPasswordLength)),
+		validation.Field(&r.Tags, validation.Length(0, maxTags), validation.Each(validation.Match(tagRegex))),
+	)
+}
+
+func (r UpdateLinkRequest) Validate() error {
+	return validation.ValidateStruct(&r, //nolint:wrapcheck
+		validation.Field(&r.TargetURL, validation.Required, validation.Length(1, 2048)),
+		validation.Field(&r.Password, validation.Length(0, maxPasswordLength)),
+	)
+}
+
+// RESPONSES.
+type (
+	LinkResponse struct {
+		// Código do link
+		Code string `json:"code" extensions:"x-order=0" example:"aZ3kP9q"`
+		// URL curta pública
+		ShortURL string `json:"short_url" extensions:"x-order=1" example:"https://sho.rt/aZ3kP9q"`
+		// URL de destino normalizada, omitida de links protegidos para quem não é o dono
+		TargetURL string `json:"target_url,omitempty" extensions:"x-order=2" example:"https://example.com/campaign"`
+		// ID do usuário dono do link
+		UserID string `json:"user_id,omitempty" extensions:"x-order=3"`
+		// Data de expiração do link
+		ExpiresAt *time.Time `json:"expires_at,omitempty" extensions:"x-order=4"`
+		// Tags do link
+		Tags []string `json:"tags,omitempty" extensions:"x-order=5"`
+		// Indica se o link exige senha
+		PasswordProtected bool `json:"password_protected" extensions:"x-order=6"`
+		// Indica se o link só redireciona por códigos assinados
+		RequireSignature bool      `json:"require_signature" extensions:"x-order=7"`
+		CreatedAt        time.Time `json:"created_at,omitempty" extensions:"x-order=8"`
+		UpdatedAt        time.Time `json:"updated_at,omitempty" extensions:"x-order=9"`
+	}
+
+	LinkDetailsResponse struct {
+		LinkResponse
+		// Contagem de cliques em tempo real
+		Stats LinkStatsResponse `json:"stats" extensions:"x-order=10"`
+	}
+
+	LinkStatsResponse struct {
+		// Total de cliques, sem os de bots
+		Clicks int64 `json:"clicks" extensions:"x-order=0" example:"1520"`
+		// Estimativa de visitantes únicos
+		UniqueVisitors int64 `json:"unique_visitors" extensions:"x-order=1" example:"830"`
+		// Data da última consolidação no Postgres
+		ReconciledAt *time.Time `json:"reconciled_at,omitempty" extensions:"x-order=2"`
+		// Países com mais cliques registrados
+		Countries []LocationClicksResponse `json:"countries" extensions:"x-order=3"`
+		// Cidades com mais cliques registrados
+		Cities []LocationClicksResponse `json:"cities" extensions:"x-order=4"`
+		// Navegadores com mais cliques registrados
+		Browsers []ValueClicksResponse `json:"browsers" extensions:"x-order=5"`
+		// Sistemas operacionais com mais cliques registrados
+		OperatingSystems []ValueClicksResponse `json:"operating_systems" extensions:"x-order=6"`
+		// Tipos de dispositivo com mais cliques registrados
+		Devices []ValueClicksResponse `json:"devices" extensions:"x-order=7"`
+		// Total de cliques registrados de bots e de prévias de links
+		BotClicks int64 `json:"bot_clicks" extensions:"x-order=8" example:"96"`
+	}
+
+	LocationClicksResponse struct {
+		// Código ISO 3166-1 do país
+		Country string `json:"country" extensions:"x-order=0" example:"BR"`
+		// Código ISO 3166-2 da região
+		Region string `json:"region,omitempty" extensions:"x-order=1" example:"SP"`
+		// Nome da cidade, em inglês
+		City string `json:"city,omitempty" extensions:"x-order=2" example:"São Paulo"`
+		// Total de cliques registrados
+		Clicks int64 `json:"clicks" extensions:"x-order=3" example:"412"`
+	}
+
+	ValueClicksResponse struct {
+		// Navegador, sistema operacional ou tipo de dispositivo
+		Value string `json:"value" extensions:"x-order=0" example:"Chrome"`
+		// Total de cliques registrados
+		Clicks int64 `json:"clicks" extensions:"x-order=1" example:"640"`
+	}
+)
+
+func NewLinkResponse(link entity.Link, baseURL string) LinkResponse {
+	return LinkResponse{
+		Code:              link.Code,
+		ShortURL:          ShortURL(baseURL, link.Code),
+		TargetURL:         link.TargetURL,
+		UserID:            link.UserID,
+		ExpiresAt:         link.ExpiresAt,
+		Tags:              link.Tags,
+		PasswordProtected: link.IsPasswordProtected(),
+		RequireSignature:  link.RequireSignature,
+		CreatedAt:         link.CreatedAt,
+		UpdatedAt:         link.UpdatedAt,
+	}
+}
+
+func NewLinkDetailsResponse(link entity.Link, stats entity.LinkStats, baseURL string) LinkDetailsResponse {
+	return LinkDetailsResponse{
+		LinkResponse: NewLinkResponse(link, baseURL),
+		Stats: LinkStatsResponse{
+			Clicks:           stats.Clicks,
+			UniqueVisitors:   stats.UniqueVisitors,
+			ReconciledAt:     stats.ReconciledAt,
+			Countries:        newLocationClicksResponses(stats.Breakdown.Countries),
+			Cities:           newLocationClicksResponses(stats.Breakdown.Cities),
+			Browsers:         newValueClicksResponses(stats.Breakdown.Browsers),
+			OperatingSystems: newValueClicksResponses(stats.Breakdown.OSes),
+			Devices:          newValueClicksResponses(stats.Breakdown.Devices),
+			BotClicks:        stats.Breakdown.BotClicks,
+		},
+	}
+}
+
+func newLocationClicksResponses(locations []entity.LocationClicks) []LocationClicksResponse {
+	responses := make([]LocationClicksResponse, len(locations))
+	for i, location := range locations {
+		responses[i] = LocationClicksResponse{
+			Country: location.Country,
+			Region:  location.Region,
+			City:    location.City,
+			Clicks:  location.Clicks,
+		}
+	}
+
+	return responses
+}
+
+func newValueClicksResponses(values []entity.ValueClicks) []ValueClicksResponse {
+	responses := make([]ValueClicksResponse, len(values))
+	for i, value := range values {
+		responses[i] = ValueClicksResponse{
+			Value:  value.Value,
+			Clicks: value.Clicks,
+		}
+	}
+
+	return responses
+}
+
+func ShortURL(baseURL, code string) string {
+	return strings.TrimRight(baseURL, "/") + "/" + code
+}
@@ -0,0 +1,87 @@
+package schema
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/go-api-template/app/library/qr"
+)
+
+var hexColorRegex = regexp.MustCompile(`^#?[0-9A-Fa-f]{6}$`)
+
+// INPUTS.
+type (
+	LinkQRCodeRequest struct {
+		// Formato da imagem: png ou svg
+		Format string `json:"format" extensions:"x-order=0" example:"png"`
+		// Largura e altura da imagem em pixels
+		Size int `json:"size" extensions:"x-order=1" example:"256"`
+		// Nível de correção de erros: L, M, Q ou H
+		ECC string `json:"ecc" extensions:"x-order=2" example:"M"`
+		// Cor dos módulos em hexadecimal
+		Foreground string `json:"fg" extensions:"x-order=3" example:"000000"`
+		// Cor de fundo em hexadecimal
+		Background string `json:"bg" extensions:"x-order=4" example:"ffffff"`
+	}
+)
+
+// NewLinkQRCodeRequest reads the request from query parameters, filling in
+// the defaults of the missing ones.
+func NewLinkQRCodeRequest(query url.Values) LinkQRCodeRequest {
+	request := LinkQRCodeRequest{
+		Format:     string(qr.FormatPNG),
+		Size:       256,
+		ECC:        "M",
+		Foreground: "000000",
+		Background: "ffffff",
+	}
+
+	if format := query.Get("format"); format != "" {
+		request.Format = format
+	}
+
+	if size := query.Get("size"); size != "" {
+		// Invalid numbers fail the validation below.
+		request.Size, _ = strconv.Atoi(size)
+		if request.Size == 0 {
+			request.Size = -1
+		}
+	}
+
+	if ecc := query.Get("ecc"); ecc != "" {
+		request.ECC = ecc
+	}
+
+	if fg := query.Get("fg"); fg != "" {
+		request.Foreground = fg
+	}
+
+	if bg := query.Get("bg"); bg != "" {
+		request.Background = bg
+	}
+
+	return request
+}
+
+func (r LinkQRCodeRequest) Validate() error {
+	return validation.ValidateStruct(&r, //nolint:wrapcheck
+		validation.Field(&r.Format, validation.In(string(qr.FormatPNG), string(qr.FormatSVG))),
+		validation.Field(&r.Size, validation.Min(64), validation.Max(2048)),
+		validation.Field(&r.ECC, validation.In("L", "M", "Q", "H")),
+		validation.Field(&r.Foreground, validation.Match(hexColorRegex)),
+		validation.Field(&r.Background, validation.Match(hexColorRegex)),
+	)
+}
+
+func (r LinkQRCodeRequest) Options() qr.Options {
+	return qr.Options{
+		Format:     qr.Format(r.Format),
+		Size:       r.Size,
+		Level:      r.ECC,
+		Foreground: r.Foreground,
+		Background: r.Background,
+	}
+}
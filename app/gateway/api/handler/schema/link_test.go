@@ -0,0 +1,40 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateLinkRequest_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		alias   string
+		wantErr bool
+	}{
+		{name: "no alias", alias: ""},
+		{name: "alias", alias: "launch-2024"},
+		{name: "too short alias", alias: "ab", wantErr: true},
+		{name: "alias with a slash", alias: "a/b/c", wantErr: true},
+		{name: "reserved alias", alias: "healthcheck", wantErr: true},
+		{name: "reserved api prefix", alias: "api", wantErr: true},
+		{name: "reserved admin prefix", alias: "admin", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			request := CreateLinkRequest{TargetURL: "https://example.com", Alias: tt.alias}
+
+			err := request.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
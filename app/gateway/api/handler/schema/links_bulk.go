@@ -0,0 +1,197 @@
+package schema
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+)
+
+// Columns of bulk CSV uploads. The header row is required; only target_url is.
+const (
+	csvColumnTargetURL = "target_url"
+	csvColumnAlias     = "alias"
+	csvColumnTags      = "tags"
+	csvColumnExpiresAt = "expires_at"
+
+	// Tags are separated by "|" inside the tags column.
+	csvTagSeparator = "|"
+)
+
+var (
+	errCSVHeaderInvalid = errors.New("csv header must name the columns target_url, alias, tags and expires_at")
+	errCSVExpiresAt     = erring.NewAppError("expires_at:invalid-format", "expires_at must be an RFC 3339 date")
+)
+
+// NewCreateLinkRequestsFromCSV reads bulk links from a CSV upload. Rows that
+// can't be parsed get an error at the same position in rowErrs.
+func NewCreateLinkRequestsFromCSV(reader io.Reader, maxRows int) (requests []CreateLinkRequest, rowErrs []error, err error) {
+	csvReader := csv.NewReader(reader)
+	csvReader.TrimLeadingSpace = true
+	csvReader.ReuseRecord = true
+
+	header, err := csvReader.Read()
+	if err != nil {
+		return nil, nil, fmt.Errorf("read csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+
+		switch name {
+		case csvColumnTargetURL, csvColumnAlias, csvColumnTags, csvColumnExpiresAt:
+			columns[name] = i
+		default:
+			return nil, nil, fmt.Errorf("%w: unknown column %q", errCSVHeaderInvalid, name)
+		}
+	}
+
+	if _, ok := columns[csvColumnTargetURL]; !ok {
+		return nil, nil, fmt.Errorf("%w: missing column %q", errCSVHeaderInvalid, csvColumnTargetURL)
+	}
+
+	for {
+		record, err := csvReader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, nil, fmt.Errorf("read csv line %d: %w", len(requests)+2, err)
+		}
+
+		// Stop early instead of buffering an arbitrarily large upload.
+		if len(requests) == maxRows {
+			return nil, nil, erring.ErrLinksBulkTooLarge
+		}
+
+		request, rowErr := newCreateLinkRequestFromCSV(record, columns)
+		requests = append(requests, request)
+		rowErrs = append(rowErrs, rowErr)
+	}
+
+	return requests, rowErrs, nil
+}
+
+func newCreateLinkRequestFromCSV(record []string, columns map[string]int) (CreateLinkRequest, error) {
+	value := func(column string) string {
+		if i, ok := columns[column]; ok {
+			return strings.TrimSpace(record[i])
+		}
+
+		return ""
+	}
+
+	request := CreateLinkRequest{
+		TargetURL: value(csvColumnTargetURL),
+		Alias:     value(csvColumnAlias),
+	}
+
+	if tags := value(csvColumnTags); tags != "" {
+		for _, tag := range strings.Split(tags, csvTagSeparator) {
+			request.Tags = append(request.Tags, strings.TrimSpace(tag))
+		}
+	}
+
+	if expiresAt := value(csvColumnExpiresAt); expiresAt != "" {
+		parsed, err := time.Parse(time.RFC3339, expiresAt)
+		if err != nil {
+			return request, errCSVExpiresAt
+		}
+
+		request.ExpiresAt = &parsed
+	}
+
+	return request, nil
+}
+
+// RESPONSES.
+type (
+	LinksBulkResponse struct {
+		// Quantidade de linhas recebidas
+		Total int `json:"total" extensions:"x-order=0" example:"3"`
+		// Quantidade de links criados
+		Created int `json:"created" extensions:"x-order=1" example:"2"`
+		// Quantidade de linhas rejeitadas
+		Failed int `json:"failed" extensions:"x-order=2" example:"1"`
+		// Resultado de cada linha, na ordem recebida
+		Results []LinksBulkRowResponse `json:"results" extensions:"x-order=3"`
+	}
+
+	LinksBulkRowResponse struct {
+		// Posição da linha, a partir de 0
+		Index int `json:"index" extensions:"x-order=0" example:"0"`
+		// Link criado
+		Link *LinkResponse `json:"link,omitempty" extensions:"x-order=1"`
+		// Erro que rejeitou a linha
+		Error *response.Error `json:"error,omitempty" extensions:"x-order=2"`
+	}
+
+	LinksBulkJobResponse struct {
+		// ID do job
+		ID string `json:"id" extensions:"x-order=0"`
+		// Estado do job: running, succeeded ou failed
+		Status string `json:"status" extensions:"x-order=1" example:"running"`
+		// Quantidade de linhas recebidas
+		Total int `json:"total" extensions:"x-order=2" example:"5000"`
+		// Quantidade de linhas já processadas
+		Processed int `json:"processed" extensions:"x-order=3" example:"1500"`
+		// Motivo da falha do job
+		Error string `json:"error,omitempty" extensions:"x-order=4"`
+		// Resultado, quando o job termina com sucesso
+		Result     *LinksBulkResponse `json:"result,omitempty" extensions:"x-order=5"`
+		CreatedAt  time.Time          `json:"created_at" extensions:"x-order=6"`
+		FinishedAt *time.Time         `json:"finished_at,omitempty" extensions:"x-order=7"`
+	}
+)
+
+func NewLinksBulkResponse(results []entity.LinksBulkResult, baseURL string) LinksBulkResponse {
+	resp := LinksBulkResponse{
+		Total:   len(results),
+		Results: make([]LinksBulkRowResponse, len(results)),
+	}
+
+	for i, result := range results {
+		row := LinksBulkRowResponse{Index: result.Index}
+
+		if result.Link != nil {
+			link := NewLinkResponse(*result.Link, baseURL)
+			row.Link = &link
+			resp.Created++
+		} else {
+			rowErr := response.ErrorFromCode(result.ErrorCode, result.ErrorMessage)
+			row.Error = &rowErr
+			resp.Failed++
+		}
+
+		resp.Results[i] = row
+	}
+
+	return resp
+}
+
+func NewLinksBulkJobResponse(job entity.LinksBulkJob, baseURL string) LinksBulkJobResponse {
+	resp := LinksBulkJobResponse{
+		ID:         job.ID,
+		Status:     string(job.Status),
+		Total:      job.Total,
+		Processed:  len(job.Results),
+		Error:      job.Error,
+		CreatedAt:  job.CreatedAt,
+		FinishedAt: job.FinishedAt,
+	}
+
+	if job.Status == entity.LinksBulkJobStatusSucceeded {
+		result := NewLinksBulkResponse(job.Results, baseURL)
+		resp.Result = &result
+	}
+
+	return resp
+}
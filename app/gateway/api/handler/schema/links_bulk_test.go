@@ -0,0 +1,68 @@
+package schema
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-api-template/app/domain/erring"
+)
+
+func TestNewCreateLinkRequestsFromCSV(t *testing.T) {
+	t.Parallel()
+
+	t.Run("parses rows", func(t *testing.T) {
+		t.Parallel()
+
+		csv := "\ufeffTarget_URL,alias,tags,expires_at\n" +
+			"https://example.com/a,launch,email|q4,2030-01-02T03:04:05Z\n" +
+			"https://example.com/b,,,\n" +
+			"https://example.com/c,,,tomorrow\n"
+
+		requests, rowErrs, err := NewCreateLinkRequestsFromCSV(strings.NewReader(csv), 10)
+		require.NoError(t, err)
+		require.Len(t, requests, 3)
+
+		assert.Equal(t, "https://example.com/a", requests[0].TargetURL)
+		assert.Equal(t, "launch", requests[0].Alias)
+		assert.Equal(t, []string{"email", "q4"}, requests[0].Tags)
+		require.NotNil(t, requests[0].ExpiresAt)
+		assert.Equal(t, 2030, requests[0].ExpiresAt.Year())
+		require.NoError(t, rowErrs[0])
+
+		assert.Empty(t, requests[1].Tags)
+		assert.Nil(t, requests[1].ExpiresAt)
+		require.NoError(t, rowErrs[1])
+
+		require.ErrorIs(t, rowErrs[2], errCSVExpiresAt)
+	})
+
+	t.Run("only target url is required", func(t *testing.T) {
+		t.Parallel()
+
+		requests, _, err := NewCreateLinkRequestsFromCSV(strings.NewReader("target_url\nhttps://example.com/\n"), 10)
+		require.NoError(t, err)
+		assert.Len(t, requests, 1)
+	})
+
+	t.Run("invalid header", func(t *testing.T) {
+		t.Parallel()
+
+		_, _, err := NewCreateLinkRequestsFromCSV(strings.NewReader("alias\nlaunch\n"), 10)
+		require.ErrorIs(t, err, errCSVHeaderInvalid)
+
+		_, _, err = NewCreateLinkRequestsFromCSV(strings.NewReader("target_url,owner\nhttps://example.com/,me\n"), 10)
+		require.ErrorIs(t, err, errCSVHeaderInvalid)
+	})
+
+	t.Run("too many rows", func(t *testing.T) {
+		t.Parallel()
+
+		csv := "target_url\n" + strings.Repeat("https://example.com/\n", 3)
+
+		_, _, err := NewCreateLinkRequestsFromCSV(strings.NewReader(csv), 2)
+		require.ErrorIs(t, err, erring.ErrLinksBulkTooLarge)
+	})
+}
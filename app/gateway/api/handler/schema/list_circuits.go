@@ -0,0 +1,69 @@
+package schema
+
+import (
+	"github.com/go-api-template/app/library/circuitbreaker"
+)
+
+// RESPONSES.
+type (
+	ListCircuitsResponse struct {
+		// Circuit breakers registrados na aplicação
+		Circuits []CircuitResponse `json:"circuits" extensions:"x-order=0"`
+	}
+
+	CircuitResponse struct {
+		// Nome do circuito
+		Name string `json:"name" extensions:"x-order=0"`
+		// Indica se o circuito está aberto (falhando rápido)
+		IsOpen bool `json:"is_open" extensions:"x-order=1"`
+		// Modo do circuito: auto, forced-open ou forced-closed
+		Mode string `json:"mode" extensions:"x-order=2" example:"auto"`
+		// Requisições em execução no momento
+		ConcurrentRequests int64 `json:"concurrent_requests" extensions:"x-order=3"`
+		// Estatísticas da janela deslizante
+		Stats CircuitStatsResponse `json:"stats" extensions:"x-order=4"`
+		// Configuração do circuito
+		Config CircuitConfigResponse `json:"config" extensions:"x-order=5"`
+	}
+
+	CircuitStatsResponse struct {
+		Attempts           int64   `json:"attempts"            extensions:"x-order=0"`
+		Errors             int64   `json:"errors"              extensions:"x-order=1"`
+		ErrorPercentage    float64 `json:"error_percentage"    extensions:"x-order=2"`
+		Timeouts           int64   `json:"timeouts"            extensions:"x-order=3"`
+		ShortCircuits      int64   `json:"short_circuits"      extensions:"x-order=4"`
+		ConcurrencyRejects int64   `json:"concurrency_rejects" extensions:"x-order=5"`
+	}
+
+	CircuitConfigResponse struct {
+		Timeout                  string `json:"timeout"                    extensions:"x-order=0" example:"50s"`
+		MaxConcurrentRequests    int64  `json:"max_concurrent_requests"    extensions:"x-order=1"`
+		ErrorThresholdPercentage int64  `json:"error_threshold_percentage" extensions:"x-order=2"`
+		RequestVolumeThreshold   int64  `json:"request_volume_threshold"   extensions:"x-order=3"`
+		SleepWindow              string `json:"sleep_window"               extensions:"x-order=4" example:"15s"`
+	}
+)
+
+func NewCircuitResponse(state circuitbreaker.State) CircuitResponse {
+	return CircuitResponse{
+		Name:               state.Name,
+		IsOpen:             state.IsOpen,
+		Mode:               string(state.Mode),
+		ConcurrentRequests: state.ConcurrentRequests,
+		Stats: CircuitStatsResponse{
+			Attempts:           state.Attempts,
+			Errors:             state.Errors,
+			ErrorPercentage:    state.ErrorPercentage,
+			Timeouts:           state.Timeouts,
+			ShortCircuits:      state.ShortCircuits,
+			ConcurrencyRejects: state.ConcurrencyRejects,
+		},
+		Config: CircuitConfigResponse{
+			Timeout:                  state.Timeout.String(),
+			MaxConcurrentRequests:    state.MaxConcurrentRequests,
+			ErrorThresholdPercentage: state.ErrorThresholdPercentage,
+			RequestVolumeThreshold:   state.RequestVolumeThreshold,
+			SleepWindow:              state.SleepWindow.String(),
+		},
+	}
+}
@@ -0,0 +1,34 @@
+package schema
+
+import "time"
+
+// INPUTS.
+type (
+	SignLinkRequest struct {
+		// Data de expiração embutida no código assinado (opcional)
+		ExpiresAt *time.Time `json:"expires_at,omitempty" extensions:"x-order=0"`
+	}
+)
+
+// RESPONSES.
+type (
+	SignLinkResponse struct {
+		// Código assinado
+		Code string `json:"code" extensions:"x-order=0" example:"aZ3kP9q.v1.mf2x1c.3q2-7wXk0bqEoVbH1p9Z4A"`
+		// URL curta pública com o código assinado
+		ShortURL string `json:"short_url" extensions:"x-order=1" example:"https://sho.rt/aZ3kP9q.v1.mf2x1c.3q2-7wXk0bqEoVbH1p9Z4A"`
+		// ID da chave usada na assinatura
+		KeyID string `json:"key_id" extensions:"x-order=2" example:"v1"`
+		// Data de expiração embutida no código
+		ExpiresAt *time.Time `json:"expires_at,omitempty" extensions:"x-order=3"`
+	}
+)
+
+func NewSignLinkResponse(signedCode, keyID string, expiresAt *time.Time, baseURL string) SignLinkResponse {
+	return SignLinkResponse{
+		Code:      signedCode,
+		ShortURL:  ShortURL(baseURL, signedCode),
+		KeyID:     keyID,
+		ExpiresAt: expiresAt,
+	}
+}
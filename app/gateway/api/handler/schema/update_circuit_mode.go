@@ -0,0 +1,11 @@
+package schema
+
+// INPUTS.
+type (
+	UpdateCircuitModeRequest struct {
+		// Novo modo do circuito: auto, forced-open ou forced-closed
+		Mode string `json:"mode" extensions:"x-order=0" example:"forced-open"`
+		// Motivo da alteração, registrado nos logs
+		Reason string `json:"reason,omitempty" extensions:"x-order=1" example:"database maintenance"`
+	}
+)
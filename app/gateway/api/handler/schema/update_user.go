@@ -1,9 +1,24 @@
 package schema
 
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
 // INPUTS.
 type (
 	UpdateUserRequest struct {
 		// Nome do usuário
 		Name string `json:"name" extensions:"x-order=0"`
+		// Telefone no formato E.164, para receber os links; vazio para não receber
+		Phone string `json:"phone,omitempty" extensions:"x-order=1" example:"+5511999999999"`
+		// Canal das mensagens: whatsapp ou sms
+		MessageChannel string `json:"message_channel,omitempty" extensions:"x-order=2" example:"whatsapp"`
 	}
 )
+
+func (r UpdateUserRequest) Validate() error {
+	return validation.ValidateStruct(&r, //nolint:wrapcheck
+		validation.Field(&r.Phone, validation.Match(phoneRegex)),
+		validation.Field(&r.MessageChannel, messageChannelRules(r.Phone)...),
+	)
+}
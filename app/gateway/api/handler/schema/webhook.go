@@ -0,0 +1,236 @@
+package schema
+
+import (
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/types"
+)
+
+const (
+	defaultWebhookDeliveriesLimit = 50
+	maxWebhookDeliveriesLimit     = 100
+)
+
+var webhookDeliveryStatuses = []any{
+	string(entity.WebhookDeliveryStatusPending),
+	string(entity.WebhookDeliveryStatusSucceeded),
+	string(entity.WebhookDeliveryStatusFailed),
+}
+
+// INPUTS.
+type (
+	// O webhook pertence ao usuário autenticado.
+	CreateWebhookRequest struct {
+		// URL que recebe os eventos
+		URL string `json:"url" extensions:"x-order=0" example:"https://example.com/webhooks/links"`
+		// Eventos assinados: link.created, link.updated, link.expired ou link.clicked
+		Events []string `json:"events" extensions:"x-order=1" example:"link.created,link.clicked"`
+	}
+
+	UpdateWebhookRequest struct {
+		// URL que recebe os eventos
+		URL string `json:"url" extensions:"x-order=0" example:"https://example.com/webhooks/links"`
+		// Eventos assinados: link.created, link.updated, link.expired ou link.clicked
+		Events []string `json:"events" extensions:"x-order=1" example:"link.created,link.clicked"`
+		// Habilita o webhook; reabilitar zera as falhas consecutivas
+		Enabled *bool `json:"enabled" extensions:"x-order=2"`
+	}
+
+	ListWebhookDeliveriesRequest struct {
+		// Situação das entregas: pending, succeeded ou failed (opcional)
+		Status string `json:"status" extensions:"x-order=0" example:"failed"`
+		// ID da última entrega da página anterior (opcional)
+		Before string `json:"before" extensions:"x-order=1"`
+		// Quantidade de entregas, até 100
+		Limit string `json:"limit" extensions:"x-order=2" example:"50"`
+	}
+)
+
+func (r CreateWebhookRequest) Validate() error {
+	return validation.ValidateStruct(&r, //nolint:wrapcheck
+		validation.Field(&r.URL, validation.Required, validation.Length(1, 2048)),
+		validation.Field(&r.Events, webhookEventsRules()...),
+	)
+}
+
+func (r UpdateWebhookRequest) Validate() error {
+	return validation.ValidateStruct(&r, //nolint:wrapcheck
+		validation.Field(&r.URL, validation.Required, validation.Length(1, 2048)),
+		validation.Field(&r.Events, webhookEventsRules()...),
+		validation.Field(&r.Enabled, validation.NotNil),
+	)
+}
+
+func webhookEventsRules() []validation.Rule {
+	events := make([]any, len(types.WebhookEvents))
+	for i, event := range types.WebhookEvents {
+		events[i] = string(event)
+	}
+
+	return []validation.Rule{validation.Required, validation.Each(validation.In(events...))}
+}
+
+// WebhookEvents converts the events of a valid request.
+func WebhookEvents(events []string) []types.WebhookEvent {
+	converted := make([]types.WebhookEvent, 0, len(events))
+	for _, event := range events {
+		if !slices.Contains(converted, types.WebhookEvent(event)) {
+			converted = append(converted, types.WebhookEvent(event))
+		}
+	}
+
+	return converted
+}
+
+func NewListWebhookDeliveriesRequest(query url.Values) ListWebhookDeliveriesRequest {
+	return ListWebhookDeliveriesRequest{
+		Status: query.Get("status"),
+		Before: query.Get("before"),
+		Limit:  query.Get("limit"),
+	}
+}
+
+func (r ListWebhookDeliveriesRequest) Validate() error {
+	return validation.ValidateStruct(&r, //nolint:wrapcheck
+		validation.Field(&r.Status, validation.In(webhookDeliveryStatuses...)),
+		validation.Field(&r.Limit, validation.By(func(any) error {
+			if r.Limit == "" {
+				return nil
+			}
+
+			limit, err := strconv.Atoi(r.Limit)
+			if err != nil {
+				return validation.ErrInInvalid
+			}
+
+			return validation.Validate(limit, validation.Min(1), validation.Max(maxWebhookDeliveriesLimit)) //nolint:wrapcheck
+		})),
+	)
+}
+
+// Filter must only be called on a valid request.
+func (r ListWebhookDeliveriesRequest) Filter(webhookID string) entity.WebhookDeliveriesFilter {
+	limit := defaultWebhookDeliveriesLimit
+	if r.Limit != "" {
+		limit, _ = strconv.Atoi(r.Limit)
+	}
+
+	return entity.WebhookDeliveriesFilter{
+		WebhookID: webhookID,
+		Status:    entity.WebhookDeliveryStatus(r.Status),
+		Before:    r.Before,
+		Limit:     limit,
+	}
+}
+
+// RESPONSES.
+type (
+	WebhookResponse struct {
+		ID     string `json:"id" extensions:"x-order=0"`
+		UserID string `json:"user_id" extensions:"x-order=1"`
+		// URL que recebe os eventos
+		URL string `json:"url" extensions:"x-order=2"`
+		// Segredo das assinaturas, retornado apenas na criação
+		Secret string `json:"secret,omitempty" extensions:"x-order=3"`
+		// Eventos assinados
+		Events []types.WebhookEvent `json:"events" extensions:"x-order=4"`
+		// Indica se o webhook recebe eventos
+		Enabled bool `json:"enabled" extensions:"x-order=5"`
+		// Entregas seguidas que falharam todas as tentativas
+		ConsecutiveFailures int `json:"consecutive_failures" extensions:"x-order=6"`
+		// Data em que o webhook foi desabilitado por falhas
+		DisabledAt *time.Time `json:"disabled_at,omitempty" extensions:"x-order=7"`
+		CreatedAt  time.Time  `json:"created_at" extensions:"x-order=8"`
+		UpdatedAt  time.Time  `json:"updated_at" extensions:"x-order=9"`
+	}
+
+	WebhookDeliveryResponse struct {
+		ID string `json:"id" extensions:"x-order=0"`
+		// ID do evento, repetido nos reenvios
+		EventID string `json:"event_id" extensions:"x-order=1"`
+		// Tipo do evento
+		EventType types.WebhookEvent `json:"event_type" extensions:"x-order=2" example:"link.created"`
+		// Situação da entrega: pending, succeeded ou failed
+		Status string `json:"status" extensions:"x-order=3" example:"succeeded"`
+		// Tentativas feitas
+		Attempts int `json:"attempts" extensions:"x-order=4"`
+		// Status HTTP da última tentativa
+		ResponseStatus int `json:"response_status,omitempty" extensions:"x-order=5"`
+		// Início do corpo da resposta da última tentativa
+		ResponseBody string `json:"response_body,omitempty" extensions:"x-order=6"`
+		// Erro da última tentativa
+		Error       string     `json:"error,omitempty" extensions:"x-order=7"`
+		CreatedAt   time.Time  `json:"created_at" extensions:"x-order=8"`
+		DeliveredAt *time.Time `json:"delivered_at,omitempty" extensions:"x-order=9"`
+	}
+
+	WebhookDeliveriesResponse struct {
+		Deliveries []WebhookDeliveryResponse `json:"deliveries" extensions:"x-order=0"`
+		// Valor de before para a próxima página; vazio na última
+		Next string `json:"next,omitempty" extensions:"x-order=1"`
+	}
+)
+
+// NewWebhookResponse leaves the secret out; it is only set by the create
+// handler.
+func NewWebhookResponse(webhook entity.Webhook) WebhookResponse {
+	return WebhookResponse{
+		ID:                  webhook.ID,
+		UserID:              webhook.UserID,
+		URL:                 webhook.URL,
+		Events:              webhook.Events,
+		Enabled:             webhook.Enabled,
+		ConsecutiveFailures: webhook.ConsecutiveFailures,
+		DisabledAt:          webhook.DisabledAt,
+		CreatedAt:           webhook.CreatedAt,
+		UpdatedAt:           webhook.UpdatedAt,
+	}
+}
+
+func NewWebhooksResponse(webhooks []entity.Webhook) []WebhookResponse {
+	responses := make([]WebhookResponse, len(webhooks))
+	for i, webhook := range webhooks {
+		responses[i] = NewWebhookResponse(webhook)
+	}
+
+	return responses
+}
+
+func NewWebhookDeliveryResponse(delivery entity.WebhookDelivery) WebhookDeliveryResponse {
+	return WebhookDeliveryResponse{
+		ID:             delivery.ID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Status:         string(delivery.Status),
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		Error:          delivery.Error,
+		CreatedAt:      delivery.CreatedAt,
+		DeliveredAt:    delivery.DeliveredAt,
+	}
+}
+
+// NewWebhookDeliveriesResponse sets Next when the page is full, since more
+// deliveries may follow.
+func NewWebhookDeliveriesResponse(deliveries []entity.WebhookDelivery, limit int) WebhookDeliveriesResponse {
+	resp := WebhookDeliveriesResponse{
+		Deliveries: make([]WebhookDeliveryResponse, len(deliveries)),
+	}
+
+	for i, delivery := range deliveries {
+		resp.Deliveries[i] = NewWebhookDeliveryResponse(delivery)
+	}
+
+	if len(deliveries) > 0 && len(deliveries) == limit {
+		resp.Next = deliveries[len(deliveries)-1].ID
+	}
+
+	return resp
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/handler/schema"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+)
+
+func (h *Handler) SignLinkSetup(router chi.Router) {
+	const (
+		command = "sign-link"
+		pattern = "/links/{code}/signatures"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.signLink)
+
+	router.Post(pattern, handler)
+}
+
+func (h *Handler) signLink(req *http.Request) *response.Response {
+	var request schema.SignLinkRequest
+
+	// The body is optional: without it the signed code never expires.
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil && !errors.Is(err, io.EOF) {
+		return response.AppExpectedError(errors.Join(err, erring.ErrRequestInvalid))
+	}
+	defer req.Body.Close()
+
+	callerID, err := h.callerID(req)
+	if err != nil {
+		return appError(err)
+	}
+
+	input := usecase.SignLinkInput{
+		Code:      chi.URLParam(req, "code"),
+		ExpiresAt: request.ExpiresAt,
+		CallerID:  callerID,
+	}
+
+	output, err := h.useCase.SignLink(req.Context(), input)
+	if err != nil {
+		return appError(err)
+	}
+
+	return response.Created(schema.NewSignLinkResponse(output.SignedCode, output.KeyID, request.ExpiresAt, h.cfg.Links.BaseURL))
+}
@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/handler/schema"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+)
+
+func (h *Handler) StreamLinkEventsSetup(router chi.Router) {
+	const (
+		command = "stream-link-events"
+		pattern = "/links/{code}/events"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.streamLinkEvents)
+
+	router.Get(pattern, handler)
+}
+
+func (h *Handler) streamLinkEvents(req *http.Request) *response.Response {
+	callerID, err := h.requireCallerID(req)
+	if err != nil {
+		return appError(err)
+	}
+
+	input := usecase.StreamLinkEventsInput{
+		Code:        chi.URLParam(req, "code"),
+		LastEventID: req.Header.Get("Last-Event-ID"),
+		CallerID:    callerID,
+	}
+
+	output, err := h.useCase.StreamLinkEvents(req.Context(), input)
+	if err != nil {
+		return appError(err)
+	}
+
+	// The circuit only covers subscribing: the stream body ends the
+	// subscription once the client is gone, or when it is discarded because
+	// the circuit replaced the response.
+	return response.EventStream(response.EventStreamBody[entity.ClickEvent]{
+		Events: output.Events,
+		ToEvent: func(click entity.ClickEvent) response.Event {
+			return response.Event{ID: click.ID, Name: "click", Data: schema.NewClickEventResponse(click)}
+		},
+		Heartbeat:    h.cfg.ClickEvents.Heartbeat,
+		WriteTimeout: h.cfg.Server.WriteTimeout,
+		Close:        output.Close,
+	})
+}
@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/middleware"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+	"github.com/go-api-template/app/gateway/twilio"
+)
+
+// maxTwilioCallbackSize bounds status callback bodies, which are a handful
+// of form fields.
+const maxTwilioCallbackSize = 64 << 10
+
+func (h *Handler) TwilioStatusSetup(router chi.Router) {
+	const (
+		command = "twilio-status"
+		pattern = "/twilio/status"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.twilioStatus)
+
+	router.With(middleware.RequestSize(maxTwilioCallbackSize)).Post(pattern, handler)
+}
+
+// twilioStatus receives the delivery status callbacks of the messages sent
+// through Twilio.
+func (h *Handler) twilioStatus(req *http.Request) *response.Response {
+	if err := req.ParseForm(); err != nil {
+		return response.AppExpectedError(errors.Join(err, erring.ErrRequestInvalid))
+	}
+
+	// The signature covers the URL Twilio called, which proxies in front of
+	// us may rewrite, so the configured one is used.
+	signature := req.Header.Get(twilio.SignatureHeader)
+	if !twilio.ValidateSignature(h.cfg.Twilio.AuthToken, h.cfg.Twilio.StatusCallbackURL, req.PostForm, signature) {
+		return response.AppExpectedError(erring.ErrMessageSignatureInvalid)
+	}
+
+	input := usecase.UpdateMessageStatusInput{
+		ProviderID: req.PostForm.Get("MessageSid"),
+		Status:     entity.MessageStatus(req.PostForm.Get("MessageStatus")),
+		ErrorCode:  req.PostForm.Get("ErrorCode"),
+	}
+
+	if err := h.useCase.UpdateMessageStatus(req.Context(), input); err != nil {
+		return appError(err)
+	}
+
+	return response.NoContent()
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-api-template/app/domain/entity"
+)
+
+const unlockCookiePrefix = "link_unlock_"
+
+// unlockCookie lets the client skip the password form of link until the
+// cookie expires. The cookie is bound to the code the client used, plain or
+// signed, and to the current password fingerprint, so changing the password
+// invalidates it.
+func (h *Handler) unlockCookie(code string, link entity.Link) *http.Cookie {
+	expiresAt := time.Now().Add(h.cfg.Links.UnlockCookieTTL)
+	expiry := strconv.FormatInt(expiresAt.Unix(), 10)
+
+	return &http.Cookie{
+		Name:     unlockCookiePrefix + code,
+		Value:    expiry + "." + h.unlockSignature(code, link, expiry),
+		Path:     "/" + code,
+		Expires:  expiresAt,
+		MaxAge:   int(h.cfg.Links.UnlockCookieTTL.Seconds()),
+		Secure:   strings.HasPrefix(h.cfg.Links.BaseURL, "https://"),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+func (h *Handler) hasUnlockCookie(req *http.Request, code string, link entity.Link) bool {
+	cookie, err := req.Cookie(unlockCookiePrefix + code)
+	if err != nil {
+		return false
+	}
+
+	expiry, signature, found := strings.Cut(cookie.Value, ".")
+	if !found {
+		return false
+	}
+
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil || time.Now().Unix() >= expiresAt {
+		return false
+	}
+
+	return hmac.Equal([]byte(signature), []byte(h.unlockSignature(code, link, expiry)))
+}
+
+func (h *Handler) unlockSignature(code string, link entity.Link, expiry string) string {
+	mac := hmac.New(sha256.New, []byte(h.cfg.Links.UnlockCookieSecret))
+	mac.Write([]byte(code + "|" + expiry + "|" + link.PasswordFingerprint))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,89 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/middleware"
+	"github.com/go-api-template/app/gateway/api/resource/interstitial"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+	"github.com/go-api-template/app/library/clientip"
+)
+
+// maxUnlockFormSize bounds the unlock form body, which only holds a password.
+const maxUnlockFormSize = 4 << 10
+
+func (h *Handler) UnlockLinkSetup(router chi.Router) {
+	const (
+		pageCommand = "unlock-link-page"
+		command     = "unlock-link"
+		pattern     = "/{code}/unlock"
+	)
+
+	pageCircuit := h.circuitManager.MustCreateCircuit(pageCommand)
+	router.Get(pattern, rest.HandleWithCircuit(pageCircuit, h.cfg.CircuitBreaker, h.cache, pattern, h.unlockLinkPage))
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	router.With(middleware.RequestSize(maxUnlockFormSize)).Post(pattern, rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.unlockLink))
+}
+
+func (h *Handler) unlockLinkPage(req *http.Request) *response.Response {
+	return response.HTML(http.StatusOK, interstitial.Unlock, unlockPage(req, "")).
+		WithHeaders(map[string]string{"Cache-Control": "no-store"})
+}
+
+func (h *Handler) unlockLink(req *http.Request) *response.Response {
+	headers := map[string]string{"Cache-Control": "no-store"}
+
+	if err := req.ParseForm(); err != nil {
+		return response.AppExpectedError(errors.Join(err, erring.ErrRequestInvalid)).
+			WithBody(response.HTMLBody{Template: interstitial.Unlock, Data: unlockPage(req, "Invalid form.")}).
+			WithHeaders(headers)
+	}
+
+	input := usecase.UnlockLinkInput{
+		Code:     chi.URLParam(req, "code"),
+		Password: req.PostForm.Get("password"),
+		ClientIP: clientip.FromRequest(req, h.cfg.Server.TrustedProxies),
+	}
+
+	output, err := h.useCase.UnlockLink(req.Context(), input)
+	if err != nil {
+		page := unlockPage(req, "Something went wrong. Try again in a moment.")
+
+		switch {
+		case errors.Is(err, erring.ErrLinkPasswordInvalid):
+			page.Error = "Incorrect password."
+		case errors.Is(err, erring.ErrLinkUnlockRateLimited):
+			page.Error = "Too many attempts. Try again later."
+			headers["Retry-After"] = fmt.Sprintf("%.0f", h.cfg.Links.UnlockAttemptsWindow.Seconds())
+		case errors.Is(err, erring.ErrLinkNotFound), errors.Is(err, erring.ErrLinkSignatureInvalid):
+			page.Error, page.Unavailable = "This link does not exist.", true
+		case errors.Is(err, erring.ErrLinkExpired):
+			page.Error, page.Unavailable = "This link has expired.", true
+		}
+
+		return appError(err).
+			WithBody(response.HTMLBody{Template: interstitial.Unlock, Data: page}).
+			WithHeaders(headers)
+	}
+
+	h.recordClick(req, output.Link)
+
+	return response.Redirect(http.StatusSeeOther, output.Link.TargetURL).
+		WithHeaders(headers).
+		WithCookie(h.unlockCookie(input.Code, output.Link))
+}
+
+func unlockPage(req *http.Request, errMessage string) interstitial.UnlockPage {
+	return interstitial.UnlockPage{
+		Action: "/" + chi.URLParam(req, "code") + "/unlock",
+		Error:  errMessage,
+	}
+}
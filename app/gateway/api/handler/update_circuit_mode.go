@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/gateway/api/handler/schema"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+	"github.com/go-api-template/app/library/circuitbreaker"
+	"github.com/go-api-template/app/library/logutil"
+)
+
+const updateCircuitModeCommand = "update-circuit-mode"
+
+func (h *Handler) UpdateCircuitModeSetup(router chi.Router) {
+	const (
+		command = updateCircuitModeCommand
+		pattern = "/circuits/{name}/mode"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.updateCircuitMode)
+
+	router.Put(pattern, handler)
+}
+
+func (h *Handler) updateCircuitMode(req *http.Request) *response.Response {
+	var request schema.UpdateCircuitModeRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		return response.AppExpectedError(errors.Join(err, erring.ErrRequestInvalid))
+	}
+	defer req.Body.Close()
+
+	mode := circuitbreaker.Mode(request.Mode)
+	if !mode.IsValid() {
+		return response.AppExpectedError(erring.ErrCircuitModeInvalid)
+	}
+
+	name := chi.URLParam(req, "name")
+
+	// Forcing this endpoint's own circuit open would lock operators out.
+	if name == updateCircuitModeCommand {
+		return response.AppExpectedError(erring.ErrCircuitProtected)
+	}
+
+	circ := h.circuitManager.GetCircuit(name)
+	if circ == nil {
+		return response.AppExpectedError(erring.ErrCircuitNotFound)
+	}
+
+	previousMode := circuitbreaker.ModeOf(circ)
+
+	circuitbreaker.SetMode(req.Context(), circ, mode)
+
+	trace.SpanFromContext(req.Context()).AddEvent("circuit.mode-changed", trace.WithAttributes(
+		attribute.String("circuit.name", name),
+		attribute.String("circuit.previous_mode", string(previousMode)),
+		attribute.String("circuit.mode", string(mode)),
+		attribute.String("circuit.reason", request.Reason),
+	))
+
+	slog.InfoContext(req.Context(), "circuit mode changed by operator", logutil.WithMetadata(
+		slog.String("circuit", name),
+		slog.String("previous_mode", string(previousMode)),
+		slog.String("mode", string(mode)),
+		slog.String("reason", request.Reason),
+		slog.String("remote_addr", req.RemoteAddr),
+	))
+
+	return response.OK(schema.NewCircuitResponse(circuitbreaker.Snapshot(circ)))
+}
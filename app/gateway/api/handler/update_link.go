@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/handler/schema"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+)
+
+func (h *Handler) UpdateLinkSetup(router chi.Router) {
+	const (
+		command = "update-link"
+		pattern = "/links/{code}"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.updateLink)
+
+	router.Put(pattern, handler)
+}
+
+func (h *Handler) updateLink(req *http.Request) *response.Response {
+	var request schema.UpdateLinkRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		return response.AppExpectedError(errors.Join(err, erring.ErrRequestInvalid))
+	}
+	defer req.Body.Close()
+
+	if err := request.Validate(); err != nil {
+		return response.BadRequest(err, "invalid link")
+	}
+
+	callerID, err := h.requireCallerID(req)
+	if err != nil {
+		return appError(err)
+	}
+
+	input := usecase.UpdateLinkInput{
+		Link: entity.Link{
+			Code:      chi.URLParam(req, "code"),
+			TargetURL: request.TargetURL,
+			ExpiresAt: request.ExpiresAt,
+		},
+		Password: request.Password,
+		CallerID: callerID,
+	}
+
+	output, err := h.useCase.UpdateLink(req.Context(), input)
+	if err != nil {
+		return appError(err)
+	}
+
+	return response.OK(schema.NewLinkResponse(output.Link, h.cfg.Links.BaseURL))
+}
@@ -17,7 +17,7 @@ import (
 
 func (h *Handler) UpdateUserSetup(router chi.Router) {
 	const (
-		command = "create-user"
+		command = "update-user"
 		pattern = "/user/{id}"
 	)
 
@@ -35,9 +35,16 @@ func (h *Handler) updateUser(req *http.Request) *response.Response {
 	}
 	defer req.Body.Close()
 
+	if err := request.Validate(); err != nil {
+		return response.BadRequest(err, "invalid user")
+	}
+
 	input := usecase.UpdateUserInput{
 		User: entity.User{
-			Name: request.Name,
+			ID:             chi.URLParam(req, "id"),
+			Name:           request.Name,
+			Phone:          request.Phone,
+			MessageChannel: entity.MessageChannel(request.MessageChannel),
 		},
 	}
 
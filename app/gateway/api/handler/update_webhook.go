@@ -0,0 +1,63 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/domain/usecase"
+	"github.com/go-api-template/app/gateway/api/handler/schema"
+	"github.com/go-api-template/app/gateway/api/rest"
+	"github.com/go-api-template/app/gateway/api/rest/response"
+)
+
+func (h *Handler) UpdateWebhookSetup(router chi.Router) {
+	const (
+		command = "update-webhook"
+		pattern = "/webhooks/{id}"
+	)
+
+	circuit := h.circuitManager.MustCreateCircuit(command)
+	handler := rest.HandleWithCircuit(circuit, h.cfg.CircuitBreaker, h.cache, pattern, h.updateWebhook)
+
+	router.Put(pattern, handler)
+}
+
+func (h *Handler) updateWebhook(req *http.Request) *response.Response {
+	var request schema.UpdateWebhookRequest
+
+	if err := json.NewDecoder(req.Body).Decode(&request); err != nil {
+		return response.AppExpectedError(errors.Join(err, erring.ErrRequestInvalid))
+	}
+	defer req.Body.Close()
+
+	if err := request.Validate(); err != nil {
+		return response.BadRequest(err, "invalid webhook")
+	}
+
+	userID, err := h.requireCallerID(req)
+	if err != nil {
+		return appError(err)
+	}
+
+	input := usecase.UpdateWebhookInput{
+		CallerID: userID,
+		Webhook: entity.Webhook{
+			ID:      chi.URLParam(req, "id"),
+			URL:     request.URL,
+			Events:  schema.WebhookEvents(request.Events),
+			Enabled: *request.Enabled,
+		},
+	}
+
+	output, err := h.useCase.UpdateWebhook(req.Context(), input)
+	if err != nil {
+		return appError(err)
+	}
+
+	return response.OK(schema.NewWebhookResponse(output.Webhook))
+}
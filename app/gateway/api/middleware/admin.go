@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/go-api-template/app/gateway/api/rest/response"
+)
+
+// AdminAuth only lets through requests carrying the admin bearer token.
+func AdminAuth(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			bearer, found := strings.CutPrefix(req.Header.Get(_authorizationHeaderName), "Bearer ")
+
+			if token == "" || !found || subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+				resp := response.Unauthorized()
+
+				rw.Header().Set("Content-Type", "application/json")
+				rw.WriteHeader(resp.Status)
+				json.NewEncoder(rw).Encode(resp.Payload) //nolint:errcheck
+
+				return
+			}
+
+			next.ServeHTTP(rw, req)
+		})
+	}
+}
@@ -2,39 +2,219 @@ package middleware
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
+
+	"github.com/go-api-template/app/config"
 )
 
-var allowedHeaders = strings.Join([]string{
-	"Accept",
-	"Authorization",
-	"Content-Type",
-	"Origin",
-	"Referer",
-	"User-Agent",
-	"device-generated-id",
-	"platform-id",
-	"sec-ch-ua",
-	"sec-ch-ua-mobile",
-	"sec-ch-ua-platform",
-}, ", ")
-
-func CORS(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
-		origin := req.Header.Get("Origin")
-		if origin != "" {
-			resp.Header().Set("Access-Control-Allow-Origin", "*")
-			resp.Header().Set("Access-Control-Allow-Methods", "POST, GET, OPTIONS, HEAD, PUT, PATCH, DELETE")
-			resp.Header().Set("Access-Control-Allow-Headers", allowedHeaders)
-			resp.Header().Set("Access-Control-Allow-Credentials", "true")
+const _anyOrigin = "*"
+
+type corsPolicy struct {
+	allowAnyOrigin   bool
+	exactOrigins     map[string]struct{}
+	wildcardOrigins  []wildcardOrigin
+	allowedMethods   map[string]struct{}
+	allowedHeaders   map[string]struct{}
+	methods          string
+	exposedHeaders   string
+	maxAge           string
+	allowCredentials bool
+}
+
+// wildcardOrigin matches origins such as https://*.example.com, where the
+// wildcard stands for one or more subdomain labels.
+type wildcardOrigin struct {
+	prefix string
+	suffix string
+}
+
+func (w wildcardOrigin) match(origin string) bool {
+	return len(origin) > len(w.prefix)+len(w.suffix) &&
+		strings.HasPrefix(origin, w.prefix) &&
+		strings.HasSuffix(origin, w.suffix)
+}
+
+// CORS builds a middleware applying the given cross-origin policy.
+// Only allowed origins are echoed back (config rejects "*" with credentials),
+// responses always vary by Origin, and preflight requests are answered only
+// when they are real preflights for an allowed origin, method and headers.
+func CORS(cfg config.CORS) func(http.Handler) http.Handler {
+	policy := newCORSPolicy(cfg)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			origin := req.Header.Get("Origin")
+
+			if req.Method == http.MethodOptions && origin != "" && req.Header.Get("Access-Control-Request-Method") != "" {
+				policy.handlePreflight(rw, req, origin)
+
+				return
+			}
+
+			rw.Header().Add("Vary", "Origin")
+
+			if origin != "" && policy.isOriginAllowed(origin) {
+				policy.setOriginHeaders(rw, origin)
+
+				if policy.exposedHeaders != "" {
+					rw.Header().Set("Access-Control-Expose-Headers", policy.exposedHeaders)
+				}
+			}
+
+			next.ServeHTTP(rw, req)
+		})
+	}
+}
+
+func newCORSPolicy(cfg config.CORS) *corsPolicy {
+	policy := &corsPolicy{
+		exactOrigins:     make(map[string]struct{}),
+		allowedMethods:   make(map[string]struct{}),
+		allowedHeaders:   make(map[string]struct{}),
+		allowCredentials: cfg.AllowCredentials,
+		exposedHeaders:   strings.Join(cfg.ExposedHeaders, ", "),
+	}
+
+	for _, origin := range cfg.AllowedOrigins {
+		origin = strings.ToLower(strings.TrimSpace(origin))
+
+		switch {
+		case origin == "":
+			continue
+		case origin == _anyOrigin:
+			policy.allowAnyOrigin = true
+		case strings.Contains(origin, "*"):
+			prefix, suffix, _ := strings.Cut(origin, "*")
+			policy.wildcardOrigins = append(policy.wildcardOrigins, wildcardOrigin{prefix: prefix, suffix: suffix})
+		default:
+			policy.exactOrigins[origin] = struct{}{}
+		}
+	}
+
+	methods := make([]string, 0, len(cfg.AllowedMethods))
+
+	for _, method := range cfg.AllowedMethods {
+		method = strings.ToUpper(strings.TrimSpace(method))
+		if method == "" {
+			continue
 		}
 
-		if req.Method == http.MethodOptions {
-			resp.WriteHeader(http.StatusNoContent)
+		policy.allowedMethods[method] = struct{}{}
+		methods = append(methods, method)
+	}
 
-			return
+	policy.methods = strings.Join(methods, ", ")
+
+	for _, header := range cfg.AllowedHeaders {
+		if header = strings.TrimSpace(header); header != "" {
+			policy.allowedHeaders[http.CanonicalHeaderKey(header)] = struct{}{}
 		}
+	}
+
+	if cfg.MaxAge > 0 {
+		policy.maxAge = strconv.Itoa(int(cfg.MaxAge.Seconds()))
+	}
+
+	return policy
+}
+
+func (p *corsPolicy) isOriginAllowed(origin string) bool {
+	if p.allowAnyOrigin {
+		return true
+	}
+
+	origin = strings.ToLower(origin)
+
+	if _, ok := p.exactOrigins[origin]; ok {
+		return true
+	}
+
+	for _, wildcard := range p.wildcardOrigins {
+		if wildcard.match(origin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (p *corsPolicy) setOriginHeaders(rw http.ResponseWriter, origin string) {
+	// Any origin never gets credentials, as that would let any site act with
+	// the user's cookies; config rejects the combination anyway.
+	if p.allowAnyOrigin {
+		rw.Header().Set("Access-Control-Allow-Origin", _anyOrigin)
+
+		return
+	}
+
+	rw.Header().Set("Access-Control-Allow-Origin", origin)
+
+	if p.allowCredentials {
+		rw.Header().Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+func (p *corsPolicy) handlePreflight(rw http.ResponseWriter, req *http.Request, origin string) {
+	rw.Header().Add("Vary", "Origin")
+	rw.Header().Add("Vary", "Access-Control-Request-Method")
+	rw.Header().Add("Vary", "Access-Control-Request-Headers")
+
+	if !p.isOriginAllowed(origin) {
+		rw.WriteHeader(http.StatusForbidden)
+
+		return
+	}
+
+	method := strings.ToUpper(req.Header.Get("Access-Control-Request-Method"))
+	if _, ok := p.allowedMethods[method]; !ok {
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+
+		return
+	}
+
+	requestedHeaders, ok := p.allowedRequestHeaders(req.Header.Get("Access-Control-Request-Headers"))
+	if !ok {
+		rw.WriteHeader(http.StatusForbidden)
+
+		return
+	}
+
+	p.setOriginHeaders(rw, origin)
+	rw.Header().Set("Access-Control-Allow-Methods", p.methods)
+
+	if len(requestedHeaders) > 0 {
+		rw.Header().Set("Access-Control-Allow-Headers", strings.Join(requestedHeaders, ", "))
+	}
+
+	if p.maxAge != "" {
+		rw.Header().Set("Access-Control-Max-Age", p.maxAge)
+	}
+
+	rw.WriteHeader(http.StatusNoContent)
+}
+
+// allowedRequestHeaders answers a preflight with only the headers that were
+// asked for, failing if any of them is outside the allowed list.
+func (p *corsPolicy) allowedRequestHeaders(requested string) ([]string, bool) {
+	if requested == "" {
+		return nil, true
+	}
+
+	headers := make([]string, 0)
+
+	for _, header := range strings.Split(requested, ",") {
+		header = strings.TrimSpace(header)
+		if header == "" {
+			continue
+		}
+
+		if _, ok := p.allowedHeaders[http.CanonicalHeaderKey(header)]; !ok {
+			return nil, false
+		}
+
+		headers = append(headers, header)
+	}
 
-		next.ServeHTTP(resp, req)
-	})
+	return headers, true
 }
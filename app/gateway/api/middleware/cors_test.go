@@ -0,0 +1,174 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/go-api-template/app/config"
+)
+
+func TestCORS(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.CORS{
+		AllowedOrigins:   []string{"https://app.example.org", "https://*.example.com"},
+		AllowedMethods:   []string{"GET", "POST"},
+		AllowedHeaders:   []string{"Authorization", "Content-Type"},
+		ExposedHeaders:   []string{"x-request-id"},
+		AllowCredentials: true,
+		MaxAge:           10 * time.Minute,
+	}
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+
+	handler := CORS(cfg)(next)
+
+	tests := []struct {
+		name           string
+		method         string
+		headers        map[string]string
+		wantStatus     int
+		wantHeaders    map[string]string
+		wantNoHeaderOf []string
+	}{
+		{
+			name:       "exact origin is reflected with credentials",
+			method:     http.MethodGet,
+			headers:    map[string]string{"Origin": "https://app.example.org"},
+			wantStatus: http.StatusOK,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":      "https://app.example.org",
+				"Access-Control-Allow-Credentials": "true",
+				"Access-Control-Expose-Headers":    "x-request-id",
+				"Vary":                             "Origin",
+			},
+		},
+		{
+			name:        "wildcard subdomain origin is reflected",
+			method:      http.MethodGet,
+			headers:     map[string]string{"Origin": "https://a.b.example.com"},
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Access-Control-Allow-Origin": "https://a.b.example.com"},
+		},
+		{
+			name:           "wildcard does not match the bare domain",
+			method:         http.MethodGet,
+			headers:        map[string]string{"Origin": "https://example.com"},
+			wantStatus:     http.StatusOK,
+			wantNoHeaderOf: []string{"Access-Control-Allow-Origin"},
+		},
+		{
+			name:           "unknown origin gets no cors headers",
+			method:         http.MethodGet,
+			headers:        map[string]string{"Origin": "https://evil.test"},
+			wantStatus:     http.StatusOK,
+			wantNoHeaderOf: []string{"Access-Control-Allow-Origin", "Access-Control-Allow-Credentials"},
+		},
+		{
+			name:           "options without origin reaches the handler",
+			method:         http.MethodOptions,
+			wantStatus:     http.StatusOK,
+			wantNoHeaderOf: []string{"Access-Control-Allow-Methods"},
+		},
+		{
+			name:   "valid preflight",
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                         "https://app.example.org",
+				"Access-Control-Request-Method":  "POST",
+				"Access-Control-Request-Headers": "content-type",
+			},
+			wantStatus: http.StatusNoContent,
+			wantHeaders: map[string]string{
+				"Access-Control-Allow-Origin":  "https://app.example.org",
+				"Access-Control-Allow-Methods": "GET, POST",
+				"Access-Control-Allow-Headers": "content-type",
+				"Access-Control-Max-Age":       "600",
+			},
+		},
+		{
+			name:   "preflight with disallowed method",
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://app.example.org",
+				"Access-Control-Request-Method": "DELETE",
+			},
+			wantStatus:     http.StatusMethodNotAllowed,
+			wantNoHeaderOf: []string{"Access-Control-Allow-Origin"},
+		},
+		{
+			name:   "preflight with disallowed header",
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                         "https://app.example.org",
+				"Access-Control-Request-Method":  "GET",
+				"Access-Control-Request-Headers": "x-custom",
+			},
+			wantStatus:     http.StatusForbidden,
+			wantNoHeaderOf: []string{"Access-Control-Allow-Origin"},
+		},
+		{
+			name:   "preflight from unknown origin",
+			method: http.MethodOptions,
+			headers: map[string]string{
+				"Origin":                        "https://evil.test",
+				"Access-Control-Request-Method": "GET",
+			},
+			wantStatus:     http.StatusForbidden,
+			wantNoHeaderOf: []string{"Access-Control-Allow-Origin"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(tt.method, "/api/v1/chatbot/user/1", nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+
+			for key, value := range tt.wantHeaders {
+				assert.Equal(t, value, rec.Header().Get(key), key)
+			}
+
+			for _, key := range tt.wantNoHeaderOf {
+				assert.Empty(t, rec.Header().Get(key), key)
+			}
+		})
+	}
+}
+
+func TestCORS_AnyOrigin(t *testing.T) {
+	t.Parallel()
+
+	next := http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+
+	withoutCredentials := CORS(config.CORS{AllowedOrigins: []string{"*"}})(next)
+	withCredentials := CORS(config.CORS{AllowedOrigins: []string{"*"}, AllowCredentials: true})(next)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Origin", "https://any.test")
+
+	rec := httptest.NewRecorder()
+	withoutCredentials.ServeHTTP(rec, req)
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+
+	rec = httptest.NewRecorder()
+	withCredentials.ServeHTTP(rec, req)
+	assert.Equal(t, "*", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+}
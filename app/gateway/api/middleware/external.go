@@ -9,4 +9,5 @@ var (
 	Logger       = middleware.Logger
 	CleanPath    = middleware.CleanPath
 	StripSlashes = middleware.StripSlashes
+	RequestSize  = middleware.RequestSize
 )
@@ -0,0 +1,102 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-api-template/app/library/consistency"
+)
+
+const _lastWriteCookieName = "last_write"
+
+// ReadYourWrites keeps the reads of a client on the Postgres primary for
+// window after its last write, so it doesn't miss its own changes on a
+// replica that is behind. Writes are remembered across requests with a
+// cookie holding their time; a time in the future, which no write of ours
+// sets, is clamped to now so a forged cookie can't pin a client to the
+// primary for longer than window.
+func ReadYourWrites(window time.Duration) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			var lastWrite time.Time
+
+			if cookie, err := req.Cookie(_lastWriteCookieName); err == nil {
+				if nanos, err := strconv.ParseInt(cookie.Value, 10, 64); err == nil {
+					lastWrite = time.Unix(0, nanos)
+				}
+
+				if now := time.Now(); lastWrite.After(now) {
+					lastWrite = now
+				}
+			}
+
+			req = req.WithContext(consistency.NewContext(req.Context(), lastWrite))
+
+			next.ServeHTTP(&lastWriteWriter{
+				ResponseWriter: rw,
+				req:            req,
+				window:         window,
+				seen:           lastWrite,
+			}, req)
+		})
+	}
+}
+
+// lastWriteWriter sets the last write cookie before the response headers
+// are sent, when the request wrote something.
+type lastWriteWriter struct {
+	http.ResponseWriter
+	req         *http.Request
+	window      time.Duration
+	seen        time.Time
+	wroteHeader bool
+}
+
+func (w *lastWriteWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.setCookie()
+	}
+
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *lastWriteWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	return w.ResponseWriter.Write(b) //nolint:wrapcheck
+}
+
+func (w *lastWriteWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (w *lastWriteWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+func (w *lastWriteWriter) setCookie() {
+	lastWrite := consistency.LastWrite(w.req.Context())
+	if !lastWrite.After(w.seen) {
+		return
+	}
+
+	http.SetCookie(w.ResponseWriter, &http.Cookie{
+		Name:     _lastWriteCookieName,
+		Value:    strconv.FormatInt(lastWrite.UnixNano(), 10),
+		Path:     "/",
+		MaxAge:   int(w.window.Seconds()) + 1,
+		HttpOnly: true,
+		Secure:   w.req.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/go-api-template/app/library/consistency"
+)
+
+func TestReadYourWrites(t *testing.T) {
+	t.Parallel()
+
+	const window = 5 * time.Second
+
+	tests := []struct {
+		name      string
+		lastWrite time.Time
+		want      bool
+	}{
+		{
+			name: "no cookie reads from replicas",
+			want: false,
+		},
+		{
+			name:      "recent write reads from the primary",
+			lastWrite: time.Now().Add(-time.Second),
+			want:      true,
+		},
+		{
+			name:      "old write reads from replicas",
+			lastWrite: time.Now().Add(-time.Minute),
+			want:      false,
+		},
+		{
+			name:      "future write is clamped to now",
+			lastWrite: time.Now().Add(time.Hour),
+			want:      true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var lastWrite time.Time
+
+			var requiresPrimary bool
+
+			next := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				lastWrite = consistency.LastWrite(req.Context())
+				requiresPrimary = consistency.RequiresPrimary(req.Context(), window)
+				rw.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if !tt.lastWrite.IsZero() {
+				req.AddCookie(&http.Cookie{
+					Name:  _lastWriteCookieName,
+					Value: strconv.FormatInt(tt.lastWrite.UnixNano(), 10),
+				})
+			}
+
+			ReadYourWrites(window)(next).ServeHTTP(httptest.NewRecorder(), req)
+
+			assert.Equal(t, tt.want, requiresPrimary)
+			assert.False(t, lastWrite.After(time.Now()))
+		})
+	}
+}
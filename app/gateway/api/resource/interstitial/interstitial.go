@@ -0,0 +1,34 @@
+package interstitial
+
+import (
+	"embed"
+	"html/template"
+)
+
+//go:embed *.gohtml
+var files embed.FS
+
+// Unlock is the password form of protected links, rendered with an
+// UnlockPage.
+var Unlock = template.Must(template.ParseFS(files, "unlock.gohtml"))
+
+// Preview is the page served to link-preview fetchers instead of a redirect,
+// rendered with a PreviewPage.
+var Preview = template.Must(template.ParseFS(files, "preview.gohtml"))
+
+type UnlockPage struct {
+	// Action is where the form is posted to.
+	Action string
+	// Error is shown above the form when not empty.
+	Error string
+	// Unavailable hides the form, for links that can't be unlocked.
+	Unavailable bool
+}
+
+type PreviewPage struct {
+	// Title is the host of the link target.
+	Title     string
+	TargetURL string
+	ShortURL  string
+	SiteName  string
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/go-api-template/app/config"
 	"github.com/go-api-template/app/domain/erring"
 	"github.com/go-api-template/app/gateway/api/rest/response"
+	"github.com/go-api-template/app/library/circuitbreaker"
 	"github.com/go-api-template/app/library/logutil"
 	"github.com/go-api-template/app/library/resource"
 	"github.com/go-api-template/app/telemetry"
@@ -34,14 +35,16 @@ func HandleWithCircuit(circ *circuit.Circuit, cuircuitCfg config.CircuitBreaker,
 			start time.Time
 		)
 
-		err := circ.Execute(req.Context(), func(ctx context.Context) error {
+		err := circuitbreaker.Run(req.Context(), circ, func(ctx context.Context) error {
 			start = time.Now()
 
 			resp = handler(req.WithContext(ctx))
 
 			// If the error is expected, we don't want to open the circuit breaker,
 			// so we return a circuit.SimpleBadRequest error.
-			if errors.Is(resp.InternalErr, erring.ErrExpected) {
+			// Unavailable dependencies are already tracked by their own circuits
+			// in the gateways, so they must not open the handler circuit too.
+			if errors.Is(resp.InternalErr, erring.ErrExpected) || errors.Is(resp.InternalErr, erring.ErrDependencyUnavailable) {
 				// Also, if the circuit is already open, we close it again (the lib is not doing it by itself)
 				circ.CloseCircuit(ctx)
 
@@ -49,7 +52,7 @@ func HandleWithCircuit(circ *circuit.Circuit, cuircuitCfg config.CircuitBreaker,
 			}
 
 			return resp.InternalErr
-		}, nil)
+		})
 		if err != nil {
 			code, desc = codes.Error, err.Error()
 
@@ -62,6 +65,7 @@ func HandleWithCircuit(circ *circuit.Circuit, cuircuitCfg config.CircuitBreaker,
 			if resp == nil || resp.InternalErr == nil || errors.Is(err, context.DeadlineExceeded) {
 				var spanLink telemetry.SpanLink
 
+				// The cache has its own circuit, so this fails fast during a Redis outage.
 				_ = cache.Get(req.Context(), circ.Name(), &spanLink)
 
 				// Creates a new span with a linked span from the last error that caused the circuit to open.
@@ -76,6 +80,8 @@ func HandleWithCircuit(circ *circuit.Circuit, cuircuitCfg config.CircuitBreaker,
 
 				defer span.End()
 
+				discard(resp)
+
 				resp = handleCircuitBreakerErrorResponse(err)
 			} else {
 				// Stores the reference from the last error on our cache (trace_id and span_id), so we can retrieve
@@ -113,7 +119,7 @@ func HandleWithCircuit(circ *circuit.Circuit, cuircuitCfg config.CircuitBreaker,
 			span.RecordError(err)
 		}
 
-		err = sendJSON(rw, resp.Status, resp.Payload, resp.Headers)
+		err = send(rw, resp)
 		if err != nil {
 			code, desc = codes.Error, err.Error()
 			span.RecordError(err)
@@ -126,11 +132,31 @@ func HandleWithCircuit(circ *circuit.Circuit, cuircuitCfg config.CircuitBreaker,
 	}
 }
 
-func sendJSON(rw http.ResponseWriter, statusCode int, payload any, header map[string]string) error {
-	for key, value := range header {
-		rw.Header().Set(key, value)
+// discard releases what the body of a response that won't be sent holds,
+// e.g. the subscription of an event stream.
+func discard(resp *response.Response) {
+	if resp == nil {
+		return
+	}
+
+	if body, ok := resp.Body.(interface{ Discard() }); ok {
+		body.Discard()
+	}
+}
+
+func send(rw http.ResponseWriter, resp *response.Response) error {
+	for key, values := range resp.Headers {
+		rw.Header()[key] = values
+	}
+
+	if resp.Body != nil {
+		return resp.Body.Send(rw, resp.Status) //nolint:wrapcheck
 	}
 
+	return sendJSON(rw, resp.Status, resp.Payload)
+}
+
+func sendJSON(rw http.ResponseWriter, statusCode int, payload any) error {
 	if payload == nil {
 		rw.WriteHeader(statusCode)
 
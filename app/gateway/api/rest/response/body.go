@@ -0,0 +1,263 @@
+package response
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Body is a response body other than JSON. Response.Headers are already set
+// when Send is called. Bodies holding resources until sent also implement
+// Discard, called instead of Send when their response is replaced.
+type Body interface {
+	Send(rw http.ResponseWriter, status int) error
+}
+
+// BytesBody sends raw bytes, e.g. an image.
+type BytesBody struct {
+	ContentType string
+	Data        []byte
+}
+
+func (b BytesBody) Send(rw http.ResponseWriter, status int) error {
+	rw.Header().Set("Content-Type", b.ContentType)
+	rw.Header().Set("Content-Length", strconv.Itoa(len(b.Data)))
+	rw.WriteHeader(status)
+
+	if _, err := rw.Write(b.Data); err != nil {
+		return fmt.Errorf("send bytes: %w", err)
+	}
+
+	return nil
+}
+
+// StreamBody copies Reader to the client, flushing after every write so
+// long-running streams reach the client as they are produced. Readers that
+// implement io.Closer are closed once sent.
+//
+// The stream is consumed after the handler returns, when the context it got
+// from the circuit is already canceled, so readers must not depend on it (see
+// context.WithoutCancel).
+type StreamBody struct {
+	ContentType string
+	Reader      io.Reader
+}
+
+func (b StreamBody) Send(rw http.ResponseWriter, status int) error {
+	if closer, ok := b.Reader.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	rw.Header().Set("Content-Type", b.ContentType)
+	rw.WriteHeader(status)
+
+	if _, err := io.Copy(flushWriter{rw}, b.Reader); err != nil {
+		return fmt.Errorf("send stream: %w", err)
+	}
+
+	return nil
+}
+
+func (b StreamBody) Discard() {
+	if closer, ok := b.Reader.(io.Closer); ok {
+		_ = closer.Close()
+	}
+}
+
+type flushWriter struct {
+	rw http.ResponseWriter
+}
+
+func (w flushWriter) Write(p []byte) (int, error) {
+	n, err := w.rw.Write(p)
+	if err == nil {
+		if errFlush := http.NewResponseController(w.rw).Flush(); errFlush != nil && !errors.Is(errFlush, http.ErrNotSupported) {
+			err = errFlush
+		}
+	}
+
+	return n, err //nolint:wrapcheck
+}
+
+// Event is a Server-Sent Event. Data is sent as JSON.
+type Event struct {
+	ID   string
+	Name string
+	Data any
+}
+
+// EventStreamBody sends Events as Server-Sent Events, converted by ToEvent,
+// until the channel is closed or the client is gone. Idle streams get a
+// comment every Heartbeat, which also tells when the client is gone.
+//
+// Each write has WriteTimeout to complete, instead of the server deadline
+// for the whole response, so streams can stay open for longer. Close, when
+// set, is called once done to stop the producer of Events.
+type EventStreamBody[T any] struct {
+	Events       <-chan T
+	ToEvent      func(T) Event
+	Heartbeat    time.Duration
+	WriteTimeout time.Duration
+	Close        func()
+}
+
+func (b EventStreamBody[T]) Send(rw http.ResponseWriter, status int) error {
+	if b.Close != nil {
+		defer b.Close()
+	}
+
+	rw.Header().Set("Content-Type", "text/event-stream")
+	rw.Header().Set("Cache-Control", "no-cache")
+	// Stops nginx from buffering the stream.
+	rw.Header().Set("X-Accel-Buffering", "no")
+
+	w := eventWriter{rw: rw, rc: http.NewResponseController(rw), timeout: b.WriteTimeout}
+
+	if err := w.write(status, []byte(": connected\n\n")); err != nil {
+		return fmt.Errorf("send event stream: %w", err)
+	}
+
+	var heartbeat <-chan time.Time
+
+	if b.Heartbeat > 0 {
+		ticker := time.NewTicker(b.Heartbeat)
+		defer ticker.Stop()
+
+		heartbeat = ticker.C
+	}
+
+	for {
+		var data []byte
+
+		select {
+		case <-heartbeat:
+			data = []byte(": heartbeat\n\n")
+		case value, ok := <-b.Events:
+			if !ok {
+				return nil
+			}
+
+			var err error
+
+			data, err = encodeEvent(b.ToEvent(value))
+			if err != nil {
+				return fmt.Errorf("send event stream: %w", err)
+			}
+		}
+
+		if err := w.write(status, data); err != nil {
+			return fmt.Errorf("send event stream: %w", err)
+		}
+	}
+}
+
+func (b EventStreamBody[T]) Discard() {
+	if b.Close != nil {
+		b.Close()
+	}
+}
+
+// encodeEvent encodes an event in the text/event-stream format. JSON holds no
+// newlines, so the data fits in a single line.
+func encodeEvent(event Event) ([]byte, error) {
+	data, err := json.Marshal(event.Data)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	var buf bytes.Buffer
+
+	if event.ID != "" {
+		buf.WriteString("id: " + event.ID + "\n")
+	}
+
+	if event.Name != "" {
+		buf.WriteString("event: " + event.Name + "\n")
+	}
+
+	buf.WriteString("data: ")
+	buf.Write(data)
+	buf.WriteString("\n\n")
+
+	return buf.Bytes(), nil
+}
+
+type eventWriter struct {
+	rw          http.ResponseWriter
+	rc          *http.ResponseController
+	timeout     time.Duration
+	wroteHeader bool
+}
+
+// write writes and flushes p, within timeout from now. The status is written
+// along with the first event, as the write deadline must be extended first.
+func (w *eventWriter) write(status int, p []byte) error {
+	var deadline time.Time
+	if w.timeout > 0 {
+		deadline = time.Now().Add(w.timeout)
+	}
+
+	if err := w.rc.SetWriteDeadline(deadline); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err //nolint:wrapcheck
+	}
+
+	if !w.wroteHeader {
+		w.rw.WriteHeader(status)
+		w.wroteHeader = true
+	}
+
+	if _, err := w.rw.Write(p); err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if err := w.rc.Flush(); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err //nolint:wrapcheck
+	}
+
+	return nil
+}
+
+// RedirectBody sends the client to Location.
+type RedirectBody struct {
+	Location string
+}
+
+func (b RedirectBody) Send(rw http.ResponseWriter, status int) error {
+	rw.Header().Set("Location", b.Location)
+	rw.WriteHeader(status)
+
+	return nil
+}
+
+// HTMLBody renders Template with Data. The page is rendered before anything is
+// written, so template errors don't leave half a page behind.
+type HTMLBody struct {
+	Template *template.Template
+	Data     any
+}
+
+func (b HTMLBody) Send(rw http.ResponseWriter, status int) error {
+	var buf bytes.Buffer
+
+	if err := b.Template.Execute(&buf, b.Data); err != nil {
+		rw.WriteHeader(http.StatusInternalServerError)
+
+		return fmt.Errorf("send html execute template: %w", err)
+	}
+
+	rw.Header().Set("Content-Type", "text/html; charset=utf-8")
+	rw.Header().Set("Content-Length", strconv.Itoa(buf.Len()))
+	rw.WriteHeader(status)
+
+	if _, err := buf.WriteTo(rw); err != nil {
+		return fmt.Errorf("send html: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package response
+
+import (
+	"html/template"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBodies(t *testing.T) {
+	t.Parallel()
+
+	page := template.Must(template.New("page").Parse(`<p>{{.}}</p>`))
+
+	events := make(chan int, 2)
+	events <- 1
+	events <- 2
+	close(events)
+
+	tests := []struct {
+		name        string
+		resp        *Response
+		wantStatus  int
+		wantHeaders map[string]string
+		wantBody    string
+	}{
+		{
+			name:        "binary",
+			resp:        Binary("image/png", []byte{0x89, 'P', 'N', 'G'}),
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Content-Type": "image/png", "Content-Length": "4"},
+			wantBody:    "\x89PNG",
+		},
+		{
+			name:        "stream",
+			resp:        Stream("text/csv", strings.NewReader("a,b\n1,2\n")),
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Content-Type": "text/csv"},
+			wantBody:    "a,b\n1,2\n",
+		},
+		{
+			name: "event stream",
+			resp: EventStream(EventStreamBody[int]{
+				Events: events,
+				ToEvent: func(n int) Event {
+					return Event{ID: strconv.Itoa(n), Name: "count", Data: map[string]int{"n": n}}
+				},
+			}),
+			wantStatus:  http.StatusOK,
+			wantHeaders: map[string]string{"Content-Type": "text/event-stream", "Cache-Control": "no-cache"},
+			wantBody:    ": connected\n\nid: 1\nevent: count\ndata: {\"n\":1}\n\nid: 2\nevent: count\ndata: {\"n\":2}\n\n",
+		},
+		{
+			name:        "redirect",
+			resp:        Redirect(http.StatusFound, "https://example.com/"),
+			wantStatus:  http.StatusFound,
+			wantHeaders: map[string]string{"Location": "https://example.com/"},
+		},
+		{
+			name:        "html escapes data",
+			resp:        HTML(http.StatusUnauthorized, page, "<b>"),
+			wantStatus:  http.StatusUnauthorized,
+			wantHeaders: map[string]string{"Content-Type": "text/html; charset=utf-8"},
+			wantBody:    "<p>&lt;b&gt;</p>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rec := httptest.NewRecorder()
+			require.NoError(t, tt.resp.Body.Send(rec, tt.resp.Status))
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantBody, rec.Body.String())
+
+			for key, value := range tt.wantHeaders {
+				assert.Equal(t, value, rec.Header().Get(key), key)
+			}
+		})
+	}
+}
+
+func TestBodies_Discard(t *testing.T) {
+	t.Parallel()
+
+	var closed bool
+
+	body := EventStreamBody[int]{Close: func() { closed = true }}
+	body.Discard()
+
+	assert.True(t, closed)
+}
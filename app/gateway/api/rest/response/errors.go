@@ -4,6 +4,7 @@ import (
 	"net/http"
 
 	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/library/resource"
 )
 
 type Error struct {
@@ -14,7 +15,52 @@ type Error struct {
 
 var errorToStatusCode = map[error]int{
 	// Shared
-	erring.ErrEventInvalid: http.StatusBadRequest,
+	erring.ErrEventInvalid:          http.StatusBadRequest,
+	erring.ErrRequestInvalid:        http.StatusBadRequest,
+	erring.ErrDependencyUnavailable: http.StatusServiceUnavailable,
+
+	// User
+	erring.ErrUserNotFound:        http.StatusNotFound,
+	erring.ErrUserUnauthenticated: http.StatusUnauthorized,
+	erring.ErrUserForbidden:       http.StatusForbidden,
+
+	// Link
+	erring.ErrLinkNotFound:             http.StatusNotFound,
+	erring.ErrLinkExpired:              http.StatusGone,
+	erring.ErrLinkCodeAlreadyExists:    http.StatusConflict,
+	erring.ErrLinkPasswordInvalid:      http.StatusUnauthorized,
+	erring.ErrLinkUnlockRateLimited:    http.StatusTooManyRequests,
+	erring.ErrLinkSignatureInvalid:     http.StatusNotFound,
+	erring.ErrLinkSigningNotConfigured: http.StatusNotImplemented,
+	erring.ErrLinksBulkTooLarge:        http.StatusBadRequest,
+	erring.ErrLinksBulkJobNotFound:     http.StatusNotFound,
+
+	// Export
+	erring.ErrExportJobNotFound:  http.StatusNotFound,
+	erring.ErrExportNotReady:     http.StatusConflict,
+	erring.ErrExportRangeInvalid: http.StatusBadRequest,
+	erring.ErrFileNotFound:       http.StatusNotFound,
+
+	// Message
+	erring.ErrMessageNotFound:         http.StatusNotFound,
+	erring.ErrMessageRejected:         http.StatusUnprocessableEntity,
+	erring.ErrMessageSignatureInvalid: http.StatusForbidden,
+
+	// Webhook
+	erring.ErrWebhookNotFound:         http.StatusNotFound,
+	erring.ErrWebhookDeliveryNotFound: http.StatusNotFound,
+
+	// URL
+	erring.ErrURLInvalid:          http.StatusUnprocessableEntity,
+	erring.ErrURLSchemeNotAllowed: http.StatusUnprocessableEntity,
+	erring.ErrURLHostNotAllowed:   http.StatusUnprocessableEntity,
+	erring.ErrURLBlocked:          http.StatusUnprocessableEntity,
+	erring.ErrURLRedirectLoop:     http.StatusUnprocessableEntity,
+
+	// Circuit
+	erring.ErrCircuitNotFound:    http.StatusNotFound,
+	erring.ErrCircuitModeInvalid: http.StatusBadRequest,
+	erring.ErrCircuitProtected:   http.StatusBadRequest,
 }
 
 func StatusCodeFromError(err error) int {
@@ -24,3 +70,24 @@ func StatusCodeFromError(err error) int {
 
 	return http.StatusNotImplemented
 }
+
+// ErrorFromCode builds the body of a domain error that was stored by its code,
+// e.g. in the result of a background job. Codes of no domain error come from
+// request validation and are reported as bad requests.
+func ErrorFromCode(code, message string) Error {
+	status := http.StatusBadRequest
+
+	for err, statusCode := range errorToStatusCode {
+		if appError, ok := err.(erring.AppError); ok && appError.Code == code { //nolint:errorlint
+			status = statusCode
+
+			break
+		}
+	}
+
+	return Error{
+		Type:    string(resource.ResourceFromStatusCode(status)),
+		Code:    code,
+		Message: message,
+	}
+}
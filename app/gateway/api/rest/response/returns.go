@@ -3,6 +3,8 @@ package response
 import (
 	"errors"
 	"fmt"
+	"html/template"
+	"io"
 	"net/http"
 	"strings"
 
@@ -15,10 +17,13 @@ import (
 type Response struct { //nolint:errname
 	Status      int
 	Payload     any
-	Headers     map[string]string
+	Headers     http.Header
 	InternalErr error
 	LogAttrs    map[string]any
 	OmitLogs    bool
+
+	// Body replaces the JSON Payload when set.
+	Body Body
 }
 
 func (r *Response) Error() string {
@@ -26,7 +31,33 @@ func (r *Response) Error() string {
 }
 
 func (r *Response) WithHeaders(header map[string]string) *Response {
-	r.Headers = header
+	if r.Headers == nil {
+		r.Headers = make(http.Header, len(header))
+	}
+
+	for key, value := range header {
+		r.Headers.Set(key, value)
+	}
+
+	return r
+}
+
+// WithCookie adds a Set-Cookie header for cookie, keeping the ones already
+// added.
+func (r *Response) WithCookie(cookie *http.Cookie) *Response {
+	if r.Headers == nil {
+		r.Headers = make(http.Header)
+	}
+
+	r.Headers.Add("Set-Cookie", cookie.String())
+
+	return r
+}
+
+// WithBody sends body instead of the JSON payload, e.g. to render an error as
+// an HTML page.
+func (r *Response) WithBody(body Body) *Response {
+	r.Body = body
 
 	return r
 }
@@ -66,6 +97,50 @@ func Accepted(payload any) *Response {
 	}
 }
 
+// Binary responds with data as it is, e.g. an image.
+func Binary(contentType string, data []byte) *Response {
+	return &Response{
+		Status: http.StatusOK,
+		Body:   BytesBody{ContentType: contentType, Data: data},
+	}
+}
+
+// Stream responds with everything read from reader.
+func Stream(contentType string, reader io.Reader) *Response {
+	return &Response{
+		Status: http.StatusOK,
+		Body:   StreamBody{ContentType: contentType, Reader: reader},
+	}
+}
+
+// EventStream streams events as Server-Sent Events; see EventStreamBody.
+func EventStream[T any](body EventStreamBody[T]) *Response {
+	return &Response{
+		Status: http.StatusOK,
+		Body:   body,
+	}
+}
+
+func Redirect(status int, location string) *Response {
+	return &Response{
+		Status: status,
+		Body:   RedirectBody{Location: location},
+	}
+}
+
+func HTML(status int, tmpl *template.Template, data any) *Response {
+	return &Response{
+		Status: status,
+		Body:   HTMLBody{Template: tmpl, Data: data},
+	}
+}
+
+func NotModified() *Response {
+	return &Response{
+		Status: http.StatusNotModified,
+	}
+}
+
 func NoContent() *Response {
 	return &Response{
 		Status: http.StatusNoContent,
@@ -77,7 +152,7 @@ func NoContent() *Response {
 func BadRequest(err error, message string) *Response {
 	return &Response{
 		Status:      http.StatusBadRequest,
-		Payload:     makeBadRequestError(err, message),
+		Payload:     BadRequestError(err, message),
 		InternalErr: err,
 	}
 }
@@ -131,7 +206,7 @@ func InternalServerError(err error) *Response {
 }
 
 func AppError(err error) *Response {
-	appError := new(erring.AppError)
+	var appError erring.AppError
 	if errors.As(err, &appError) {
 		status := StatusCodeFromError(appError)
 
@@ -155,8 +230,10 @@ func AppExpectedError(err error) *Response {
 	return AppError(err)
 }
 
-func makeBadRequestError(err error, message string) Error {
-	appError := new(erring.AppError)
+// BadRequestError is the payload BadRequest responds with, e.g. to report
+// invalid items of a batch along with the valid ones.
+func BadRequestError(err error, message string) Error {
+	var appError erring.AppError
 	if errors.As(err, &appError) {
 		return Error{
 			Type:    string(resource.SrnErrorBadRequest),
@@ -0,0 +1,47 @@
+package response
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponse_WithHeaders(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		resp *Response
+		want http.Header
+	}{
+		{
+			name: "headers",
+			resp: NoContent().WithHeaders(map[string]string{"cache-control": "no-store"}),
+			want: http.Header{"Cache-Control": {"no-store"}},
+		},
+		{
+			name: "headers are merged",
+			resp: NoContent().
+				WithHeaders(map[string]string{"Cache-Control": "no-store", "ETag": `"a"`}).
+				WithHeaders(map[string]string{"ETag": `"b"`}),
+			want: http.Header{"Cache-Control": {"no-store"}, "Etag": {`"b"`}},
+		},
+		{
+			name: "cookies",
+			resp: NoContent().
+				WithCookie(&http.Cookie{Name: "a", Value: "1"}).
+				WithHeaders(map[string]string{"Cache-Control": "no-store"}).
+				WithCookie(&http.Cookie{Name: "b", Value: "2"}),
+			want: http.Header{"Cache-Control": {"no-store"}, "Set-Cookie": {"a=1", "b=2"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.resp.Headers)
+		})
+	}
+}
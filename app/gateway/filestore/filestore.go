@@ -0,0 +1,170 @@
+package filestore
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/go-api-template/app/domain/erring"
+)
+
+// Store keeps files in a local directory. The directory may be a mounted
+// object storage bucket (e.g. with s3fs or gcsfuse), so files are written
+// under a temporary name and only appear once complete.
+type Store struct {
+	dir string
+}
+
+func New(dir string) (*Store, error) {
+	const operation = "FileStore.New"
+
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, fmt.Errorf("%s (%s) -> %w", operation, dir, err)
+	}
+
+	return &Store{dir: dir}, nil
+}
+
+// Create returns a writer for the file name. The file is only visible after
+// the writer is closed.
+func (s *Store) Create(name string) (io.WriteCloser, error) {
+	const operation = "FileStore.Create"
+
+	path, err := s.path(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	file, err := os.CreateTemp(s.dir, "."+name+".*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("%s (%s) -> %w", operation, name, err)
+	}
+
+	return &pendingFile{File: file, path: path}, nil
+}
+
+func (s *Store) Open(name string) (io.ReadCloser, error) {
+	const operation = "FileStore.Open"
+
+	path, err := s.path(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		err = erring.ErrFileNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("%s (%s) -> %w", operation, name, err)
+	}
+
+	return file, nil
+}
+
+func (s *Store) Delete(name string) error {
+	const operation = "FileStore.Delete"
+
+	path, err := s.path(name)
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("%s (%s) -> %w", operation, name, err)
+	}
+
+	return nil
+}
+
+// DeleteOlderThan deletes the files last written before before, including
+// the ones left half written, and returns how many were deleted.
+func (s *Store) DeleteOlderThan(before time.Time) (int, error) {
+	const operation = "FileStore.DeleteOlderThan"
+
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return 0, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	var (
+		deleted int
+		errs    []error
+	)
+
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		// Files may be deleted meanwhile, by another worker.
+		info, err := entry.Info()
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		if !info.ModTime().Before(before) {
+			continue
+		}
+
+		err = os.Remove(filepath.Join(s.dir, entry.Name()))
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+
+		deleted++
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return deleted, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return deleted, nil
+}
+
+// path keeps names inside the store directory.
+func (s *Store) path(name string) (string, error) {
+	if name == "" || name != filepath.Base(name) || name[0] == '.' {
+		return "", fmt.Errorf("%w: invalid file name %q", erring.ErrFileNotFound, name)
+	}
+
+	return filepath.Join(s.dir, name), nil
+}
+
+type pendingFile struct {
+	*os.File
+	path string
+}
+
+func (f *pendingFile) Close() error {
+	if err := f.File.Close(); err != nil {
+		_ = os.Remove(f.File.Name())
+
+		return fmt.Errorf("close: %w", err)
+	}
+
+	if err := os.Rename(f.File.Name(), f.path); err != nil {
+		_ = os.Remove(f.File.Name())
+
+		return fmt.Errorf("rename: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package filestore
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-api-template/app/domain/erring"
+)
+
+func TestStore_DeleteOlderThan(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+
+	store, err := New(dir)
+	require.NoError(t, err)
+
+	now := time.Now()
+
+	write := func(name string, modTime time.Time) {
+		t.Helper()
+
+		file, err := store.Create(name)
+		require.NoError(t, err)
+
+		_, err = io.WriteString(file, "a,b\n")
+		require.NoError(t, err)
+		require.NoError(t, file.Close())
+		require.NoError(t, os.Chtimes(filepath.Join(dir, name), modTime, modTime))
+	}
+
+	write("old.csv", now.Add(-48*time.Hour))
+	write("new.csv", now)
+
+	// Left behind by a writer that never finished.
+	tmp := filepath.Join(dir, ".stale.csv.123.tmp")
+	require.NoError(t, os.WriteFile(tmp, []byte("a"), 0o600))
+	require.NoError(t, os.Chtimes(tmp, now.Add(-48*time.Hour), now.Add(-48*time.Hour)))
+
+	deleted, err := store.DeleteOlderThan(now.Add(-24 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	_, err = store.Open("old.csv")
+	require.ErrorIs(t, err, erring.ErrFileNotFound)
+	assert.NoFileExists(t, tmp)
+
+	file, err := store.Open("new.csv")
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+}
@@ -0,0 +1,20 @@
+package jobs
+
+import (
+	"math/rand/v2"
+	"time"
+)
+
+// backoff is the delay before retrying a job that failed attempt times: it
+// doubles from minDelay up to maxDelay, with up to 20% of jitter so jobs
+// failing together don't retry together.
+func backoff(attempt int, minDelay, maxDelay time.Duration) time.Duration {
+	delay := minDelay
+	for i := 1; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	delay = min(delay, maxDelay)
+
+	return delay - time.Duration(rand.Int64N(int64(delay)/5+1)) //nolint:gosec
+}
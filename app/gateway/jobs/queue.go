@@ -0,0 +1,80 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/types"
+	"github.com/go-api-template/app/telemetry"
+)
+
+type store interface {
+	Enqueue(ctx context.Context, job entity.Job) error
+	Claim(ctx context.Context, jobTypes []types.Job, limit int, visibilityTimeout time.Duration) ([]entity.Job, error)
+	Complete(ctx context.Context, job entity.Job) error
+	Retry(ctx context.Context, job entity.Job, runAt time.Time, reason string) error
+	DeadLetter(ctx context.Context, job entity.Job, reason string) error
+}
+
+// Queue enqueues background jobs for the workers.
+type Queue struct {
+	store store
+	cfg   config.Jobs
+}
+
+func NewQueue(store store, cfg config.Jobs) *Queue {
+	return &Queue{
+		store: store,
+		cfg:   cfg,
+	}
+}
+
+// Enqueue queues a job of jobType with payload encoded as JSON. The payload
+// must match the type the job was registered with on the workers.
+func (q *Queue) Enqueue(ctx context.Context, jobType types.Job, payload any) error {
+	const operation = "Jobs.Queue.Enqueue"
+
+	ctx, span := telemetry.StartProducerSpan(ctx, "job.enqueue "+string(jobType))
+	defer span.End()
+
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("%s (%s) -> marshal payload: %w", operation, jobType, err)
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, jobType, err)
+	}
+
+	job := entity.Job{
+		ID:           id.String(),
+		Type:         jobType,
+		Payload:      raw,
+		TraceCarrier: map[string]string{},
+		MaxAttempts:  q.cfg.MaxAttempts,
+		RunAt:        time.Now(),
+	}
+
+	// Injected after the producer span starts, so consumers are its children.
+	otel.GetTextMapPropagator().Inject(ctx, propagation.MapCarrier(job.TraceCarrier))
+
+	span.SetAttributes(attribute.String("job.id", job.ID), attribute.String("job.type", string(jobType)))
+
+	if err := q.store.Enqueue(ctx, job); err != nil {
+		span.RecordError(err)
+
+		return fmt.Errorf("%s (%s) -> %w", operation, jobType, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,328 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+
+	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/types"
+	"github.com/go-api-template/app/library/ctxkey"
+	"github.com/go-api-template/app/telemetry"
+)
+
+// Jobs failing with these errors are dead-lettered right away, since
+// retrying them can't help.
+var (
+	errPayloadInvalid = errors.New("invalid job payload")
+	errHandlerMissing = errors.New("no handler for job type")
+)
+
+type handlerFunc func(ctx context.Context, payload json.RawMessage) error
+
+type periodicTask struct {
+	name     string
+	interval time.Duration
+	fn       func(ctx context.Context) error
+}
+
+// Worker claims jobs from the queue and runs the handlers registered for
+// their types.
+type Worker struct {
+	store    store
+	cfg      config.Jobs
+	handlers map[types.Job]handlerFunc
+	tasks    []periodicTask
+
+	// deadLetterHandlers are run once jobs of their type are dead-lettered.
+	deadLetterHandlers map[types.Job]handlerFunc
+
+	mu         sync.Mutex
+	stop       chan struct{}
+	stopOnce   sync.Once
+	done       chan struct{}
+	cancelJobs context.CancelFunc
+}
+
+func NewWorker(store store, cfg config.Jobs) *Worker {
+	return &Worker{
+		store:    store,
+		cfg:      cfg,
+		handlers: make(map[types.Job]handlerFunc),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+
+		deadLetterHandlers: make(map[types.Job]handlerFunc),
+	}
+}
+
+// Register runs handler for the jobs of jobType, with their payload decoded
+// into T. It must be called before Run.
+func Register[T any](w *Worker, jobType types.Job, handler func(ctx context.Context, payload T) error) {
+	w.handlers[jobType] = decodePayload(handler)
+}
+
+// OnDeadLetter runs handler, with the payload decoded into T, once a job of
+// jobType is dead-lettered, whether its last attempt failed or timed out. It
+// lets whoever waits for the job know it won't finish. It must be called
+// before Run.
+func OnDeadLetter[T any](w *Worker, jobType types.Job, handler func(ctx context.Context, payload T) error) {
+	w.deadLetterHandlers[jobType] = decodePayload(handler)
+}
+
+func decodePayload[T any](handler func(ctx context.Context, payload T) error) handlerFunc {
+	return func(ctx context.Context, raw json.RawMessage) error {
+		var payload T
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			return fmt.Errorf("%w: %w", errPayloadInvalid, err)
+		}
+
+		return handler(ctx, payload)
+	}
+}
+
+// Schedule runs fn every interval while the worker runs. Every worker runs
+// it, so fn must be safe to run concurrently from several processes. It must
+// be called before Run.
+func (w *Worker) Schedule(name string, interval time.Duration, fn func(ctx context.Context) error) {
+	w.tasks = append(w.tasks, periodicTask{name: name, interval: interval, fn: fn})
+}
+
+// Run claims and runs jobs until Shutdown is called. Only the registered job
+// types are claimed, so workers of different versions can share the queue.
+func (w *Worker) Run(ctx context.Context) error {
+	const operation = "Jobs.Worker.Run"
+
+	defer close(w.done)
+
+	// Jobs outlive ctx: Shutdown lets them finish before cancelling them.
+	jobsCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+
+	w.mu.Lock()
+	w.cancelJobs = cancel
+	w.mu.Unlock()
+
+	jobTypes := make([]types.Job, 0, len(w.handlers))
+	for jobType := range w.handlers {
+		jobTypes = append(jobTypes, jobType)
+	}
+
+	var running sync.WaitGroup
+	defer running.Wait()
+
+	for _, task := range w.tasks {
+		running.Add(1)
+
+		go func() {
+			defer running.Done()
+
+			w.runPeriodic(jobsCtx, task)
+		}()
+	}
+
+	slots := make(chan struct{}, w.cfg.Concurrency)
+
+	ticker := time.NewTicker(w.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		claimed, free := 0, w.cfg.Concurrency-len(slots)
+
+		if free > 0 {
+			jobs, err := w.store.Claim(jobsCtx, jobTypes, free, w.cfg.VisibilityTimeout)
+			if err != nil {
+				slog.ErrorContext(ctx, fmt.Sprintf("%s -> %v", operation, err))
+			}
+
+			claimed = len(jobs)
+
+			for _, job := range jobs {
+				slots <- struct{}{}
+
+				running.Add(1)
+
+				go func() {
+					defer func() {
+						<-slots
+						running.Done()
+					}()
+
+					w.process(jobsCtx, job)
+				}()
+			}
+		}
+
+		// A full batch means more jobs are likely due: claim again right away.
+		if claimed > 0 && claimed == free {
+			select {
+			case <-w.stop:
+				return nil
+			default:
+				continue
+			}
+		}
+
+		select {
+		case <-w.stop:
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown stops claiming jobs and waits for the running ones to finish. If
+// ctx ends first their contexts are cancelled; jobs that still don't finish
+// are claimed again once their visibility timeout expires.
+func (w *Worker) Shutdown(ctx context.Context) error {
+	w.stopOnce.Do(func() { close(w.stop) })
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		w.mu.Lock()
+		if w.cancelJobs != nil {
+			w.cancelJobs()
+		}
+		w.mu.Unlock()
+
+		<-w.done
+
+		return ctx.Err() //nolint:wrapcheck
+	}
+}
+
+func (w *Worker) runPeriodic(ctx context.Context, task periodicTask) {
+	const operation = "Jobs.Worker.runPeriodic"
+
+	ticker := time.NewTicker(task.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+		}
+
+		taskCtx, span := telemetry.StartInternalSpan(ctx, "job.periodic "+task.name)
+
+		if err := task.fn(taskCtx); err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+
+			slog.ErrorContext(taskCtx, fmt.Sprintf("%s (%s) -> %v", operation, task.name, err))
+		}
+
+		span.End()
+	}
+}
+
+func (w *Worker) process(ctx context.Context, job entity.Job) {
+	const operation = "Jobs.Worker.process"
+
+	ctx = otel.GetTextMapPropagator().Extract(ctx, propagation.MapCarrier(job.TraceCarrier))
+
+	ctx, span := telemetry.StartConsumerSpan(ctx, "job.process "+string(job.Type))
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("job.id", job.ID),
+		attribute.String("job.type", string(job.Type)),
+		attribute.Int("job.attempt", job.Attempts),
+	)
+
+	err := w.run(ctx, job)
+	if err == nil {
+		if err := w.store.Complete(ctx, job); err != nil {
+			slog.ErrorContext(ctx, fmt.Sprintf("%s (%s) -> %v", operation, job.ID, err))
+		}
+
+		return
+	}
+
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+
+	if errors.Is(err, errPayloadInvalid) || errors.Is(err, errHandlerMissing) || job.Attempts >= job.MaxAttempts {
+		slog.ErrorContext(ctx, fmt.Sprintf("%s (%s %s) -> dead-lettered after %d attempts: %v", operation, job.Type, job.ID, job.Attempts, err))
+
+		if err := w.store.DeadLetter(ctx, job, err.Error()); err != nil {
+			slog.ErrorContext(ctx, fmt.Sprintf("%s (%s) -> %v", operation, job.ID, err))
+		}
+
+		w.runDeadLetterHandler(ctx, job)
+
+		return
+	}
+
+	delay := backoff(job.Attempts, w.cfg.BackoffMin, w.cfg.BackoffMax)
+
+	slog.WarnContext(ctx, fmt.Sprintf("%s (%s %s) -> attempt %d failed, retrying in %s: %v", operation, job.Type, job.ID, job.Attempts, delay, err))
+
+	if err := w.store.Retry(ctx, job, time.Now().Add(delay), err.Error()); err != nil {
+		slog.ErrorContext(ctx, fmt.Sprintf("%s (%s) -> %v", operation, job.ID, err))
+	}
+}
+
+// runDeadLetterHandler calls the dead letter handler of the job type, if
+// any. The job context may be done already, e.g. after a timeout.
+func (w *Worker) runDeadLetterHandler(ctx context.Context, job entity.Job) {
+	const operation = "Jobs.Worker.runDeadLetterHandler"
+
+	handler, ok := w.deadLetterHandlers[job.Type]
+	if !ok {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), w.cfg.VisibilityTimeout)
+	defer cancel()
+
+	defer func() {
+		if r := recover(); r != nil {
+			slog.ErrorContext(ctx, fmt.Sprintf("%s (%s %s) -> panic: %v", operation, job.Type, job.ID, r))
+		}
+	}()
+
+	if err := handler(ctx, job.Payload); err != nil {
+		slog.ErrorContext(ctx, fmt.Sprintf("%s (%s %s) -> %v", operation, job.Type, job.ID, err))
+	}
+}
+
+// run calls the job handler, bounded by the visibility timeout so it doesn't
+// keep running once another worker may have claimed the job.
+func (w *Worker) run(ctx context.Context, job entity.Job) (err error) {
+	// A job reclaimed after its last attempt timed out is not run again.
+	if job.Attempts > job.MaxAttempts {
+		return errors.New("visibility timeout exceeded")
+	}
+
+	handler, ok := w.handlers[job.Type]
+	if !ok {
+		return fmt.Errorf("%w %q", errHandlerMissing, job.Type)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, w.cfg.VisibilityTimeout)
+	defer cancel()
+
+	ctx = ctxkey.PutJobLastAttempt(ctx, job.Attempts >= job.MaxAttempts)
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return handler(ctx, job.Payload)
+}
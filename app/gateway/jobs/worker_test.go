@@ -0,0 +1,274 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/types"
+	"github.com/go-api-template/app/library/ctxkey"
+	"github.com/go-api-template/app/telemetry"
+)
+
+// memoryStore is a queue without visibility timeouts, enough to drive the
+// worker through the lifecycle of its jobs.
+type memoryStore struct {
+	mu   sync.Mutex
+	jobs map[string]*entity.Job
+}
+
+func (s *memoryStore) Enqueue(_ context.Context, job entity.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	job.Status = entity.JobStatusQueued
+	s.jobs[job.ID] = &job
+
+	return nil
+}
+
+func (s *memoryStore) Claim(_ context.Context, jobTypes []types.Job, limit int, _ time.Duration) ([]entity.Job, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var claimed []entity.Job
+
+	for _, job := range s.jobs {
+		if len(claimed) == limit {
+			break
+		}
+
+		if job.Status != entity.JobStatusQueued || job.RunAt.After(time.Now()) || !containsType(jobTypes, job.Type) {
+			continue
+		}
+
+		job.Status = entity.JobStatusRunning
+		job.Attempts++
+		claimed = append(claimed, *job)
+	}
+
+	return claimed, nil
+}
+
+func (s *memoryStore) Complete(_ context.Context, job entity.Job) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.jobs, job.ID)
+
+	return nil
+}
+
+func (s *memoryStore) Retry(_ context.Context, job entity.Job, _ time.Time, reason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Due right away, so tests don't wait for the backoff.
+	s.jobs[job.ID].Status = entity.JobStatusQueued
+	s.jobs[job.ID].LastError = reason
+
+	return nil
+}
+
+func (s *memoryStore) DeadLetter(_ context.Context, job entity.Job, reason string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.jobs[job.ID].Status = entity.JobStatusDead
+	s.jobs[job.ID].LastError = reason
+
+	return nil
+}
+
+func containsType(jobTypes []types.Job, jobType types.Job) bool {
+	for _, t := range jobTypes {
+		if t == jobType {
+			return true
+		}
+	}
+
+	return false
+}
+
+type testPayload struct {
+	Name string `json:"name"`
+}
+
+func TestWorker(t *testing.T) {
+	t.Parallel()
+
+	const (
+		succeeding types.Job = "succeeding"
+		failing    types.Job = "failing"
+		flaky      types.Job = "flaky"
+		panicking  types.Job = "panicking"
+		unknown    types.Job = "unknown"
+	)
+
+	ctx := telemetry.ContextWithTracer(context.Background(), trace.NewNoopTracerProvider().Tracer("test"))
+
+	cfg := config.Jobs{
+		MaxAttempts:       3,
+		BackoffMin:        time.Millisecond,
+		BackoffMax:        time.Millisecond,
+		VisibilityTimeout: time.Second,
+		PollInterval:      time.Millisecond,
+		Concurrency:       2,
+	}
+
+	store := &memoryStore{jobs: make(map[string]*entity.Job)}
+	queue := NewQueue(store, cfg)
+	worker := NewWorker(store, cfg)
+
+	var (
+		mu           sync.Mutex
+		received     []string
+		lastAttempt  []bool
+		deadLettered []string
+	)
+
+	Register(worker, succeeding, func(_ context.Context, payload testPayload) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		received = append(received, payload.Name)
+
+		return nil
+	})
+
+	Register(worker, failing, func(ctx context.Context, _ testPayload) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		last, _ := ctxkey.GetJobLastAttempt(ctx)
+		lastAttempt = append(lastAttempt, last)
+
+		return errors.New("always fails")
+	})
+
+	flakyRuns := 0
+	Register(worker, flaky, func(_ context.Context, _ testPayload) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if flakyRuns++; flakyRuns < 2 {
+			return errors.New("fails once")
+		}
+
+		return nil
+	})
+
+	Register(worker, panicking, func(_ context.Context, _ testPayload) error {
+		panic("boom")
+	})
+
+	// Flaky jobs succeed in the end, so only the failing one is reported.
+	for _, jobType := range []types.Job{failing, flaky} {
+		OnDeadLetter(worker, jobType, func(_ context.Context, payload testPayload) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			deadLettered = append(deadLettered, payload.Name)
+
+			return nil
+		})
+	}
+
+	var ticks atomic.Int32
+
+	worker.Schedule("tick", time.Millisecond, func(_ context.Context) error {
+		ticks.Add(1)
+
+		return nil
+	})
+
+	for _, jobType := range []types.Job{succeeding, failing, flaky, panicking, unknown} {
+		require.NoError(t, queue.Enqueue(ctx, jobType, testPayload{Name: string(jobType)}))
+	}
+
+	// Not claimed by this worker, which has no handler for it.
+	var unknownID string
+
+	for id, job := range store.jobs {
+		if job.Type == unknown {
+			unknownID = id
+		}
+	}
+
+	go func() {
+		assert.NoError(t, worker.Run(ctx))
+	}()
+
+	require.Eventually(t, func() bool {
+		if ticks.Load() == 0 {
+			return false
+		}
+
+		store.mu.Lock()
+		defer store.mu.Unlock()
+
+		for _, job := range store.jobs {
+			if job.Type != unknown && job.Status != entity.JobStatusDead {
+				return false
+			}
+		}
+
+		return true
+	}, 5*time.Second, time.Millisecond)
+
+	require.NoError(t, worker.Shutdown(context.Background()))
+	require.NoError(t, worker.Shutdown(context.Background()), "shutting down twice")
+
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	assert.Equal(t, []string{"succeeding"}, received)
+	assert.Equal(t, []bool{false, false, true}, lastAttempt)
+	assert.Equal(t, []string{"failing"}, deadLettered)
+	assert.Equal(t, 2, flakyRuns)
+
+	// Completed jobs are removed from the queue.
+	remaining := map[types.Job]entity.Job{}
+	for _, job := range store.jobs {
+		remaining[job.Type] = *job
+	}
+
+	assert.Len(t, remaining, 3)
+	assert.Equal(t, entity.JobStatusDead, remaining[failing].Status)
+	assert.Equal(t, 3, remaining[failing].Attempts)
+	assert.Equal(t, "always fails", remaining[failing].LastError)
+	assert.Equal(t, entity.JobStatusDead, remaining[panicking].Status)
+	assert.Equal(t, 3, remaining[panicking].Attempts)
+	assert.Equal(t, "panic: boom", remaining[panicking].LastError)
+	assert.Equal(t, entity.JobStatusQueued, store.jobs[unknownID].Status)
+}
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: time.Second},
+		{attempt: 2, want: 2 * time.Second},
+		{attempt: 4, want: 8 * time.Second},
+		{attempt: 10, want: time.Minute},
+	}
+
+	for _, tt := range tests {
+		got := backoff(tt.attempt, time.Second, time.Minute)
+
+		assert.LessOrEqual(t, got, tt.want)
+		assert.GreaterOrEqual(t, got, tt.want*4/5)
+	}
+}
@@ -0,0 +1,104 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+
+	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/types"
+	"github.com/go-api-template/app/library/ctxkey"
+	"github.com/go-api-template/app/telemetry"
+)
+
+type handlerFunc func(ctx context.Context, payload json.RawMessage) error
+
+// Relay dispatches the events committed to the outbox, in order, to the
+// handlers subscribed to their types. Events are delivered at least once.
+type Relay struct {
+	store    store
+	cfg      config.Outbox
+	handlers map[types.Event][]handlerFunc
+}
+
+func NewRelay(store store, cfg config.Outbox) *Relay {
+	return &Relay{
+		store:    store,
+		cfg:      cfg,
+		handlers: make(map[types.Event][]handlerFunc),
+	}
+}
+
+// Subscribe runs handler for the events of eventType, with their payload
+// decoded into T. An event is relayed again, to all its handlers, when any of
+// them fails, so handlers must be idempotent; ctxkey.GetOutboxEventID helps
+// them tell. It must be called before Run.
+func Subscribe[T any](r *Relay, eventType types.Event, handler func(ctx context.Context, payload T) error) {
+	r.handlers[eventType] = append(r.handlers[eventType], func(ctx context.Context, raw json.RawMessage) error {
+		var payload T
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			return fmt.Errorf("invalid event payload: %w", err)
+		}
+
+		return handler(ctx, payload)
+	})
+}
+
+// Run relays the pending events until the outbox is drained. It is meant to
+// be scheduled on the workers; only one of them relays at a time.
+func (r *Relay) Run(ctx context.Context) error {
+	const operation = "Outbox.Relay.Run"
+
+	for {
+		processed, err := r.store.Relay(ctx, r.cfg.BatchSize, r.cfg.MaxAttempts, r.dispatch)
+		if err != nil {
+			return fmt.Errorf("%s -> %w", operation, err)
+		}
+
+		if processed < r.cfg.BatchSize {
+			return nil
+		}
+	}
+}
+
+func (r *Relay) dispatch(ctx context.Context, event entity.OutboxEvent) (err error) {
+	ctx, span := telemetry.StartConsumerSpan(ctx, "outbox.dispatch "+string(event.Type))
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("outbox.event_id", event.ID),
+		attribute.String("outbox.event_type", string(event.Type)),
+		attribute.String("outbox.aggregate_id", event.AggregateID),
+		attribute.Int("outbox.attempt", event.Attempts+1),
+	)
+
+	defer func() {
+		if rec := recover(); rec != nil {
+			err = fmt.Errorf("panic: %v", rec)
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+	}()
+
+	ctx = ctxkey.PutOutboxEventID(ctx, event.ID)
+
+	var errs error
+
+	for _, handler := range r.handlers[event.Type] {
+		errs = errors.Join(errs, handler(ctx, event.Payload))
+	}
+
+	return errs
+}
+
+type store interface {
+	Relay(ctx context.Context, limit, maxAttempts int, fn func(context.Context, entity.OutboxEvent) error) (int, error)
+}
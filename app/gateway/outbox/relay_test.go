@@ -0,0 +1,134 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/types"
+	"github.com/go-api-template/app/library/ctxkey"
+	"github.com/go-api-template/app/telemetry"
+)
+
+// memoryStore follows the relay rules of the Postgres outbox: events in
+// order, stopping at the first failure unless it used its last attempt.
+type memoryStore struct {
+	events    []entity.OutboxEvent
+	delivered []string
+	failed    []string
+}
+
+func (s *memoryStore) Relay(ctx context.Context, limit, maxAttempts int, fn func(context.Context, entity.OutboxEvent) error) (int, error) {
+	processed := 0
+
+	for len(s.events) > 0 && processed < limit {
+		event := s.events[0]
+
+		if err := fn(ctx, event); err != nil {
+			s.events[0].Attempts++
+
+			if s.events[0].Attempts < maxAttempts {
+				return processed, nil
+			}
+
+			s.failed = append(s.failed, event.ID)
+		} else {
+			s.delivered = append(s.delivered, event.ID)
+		}
+
+		s.events = s.events[1:]
+		processed++
+	}
+
+	return processed, nil
+}
+
+type userCreated struct {
+	Name string `json:"name"`
+}
+
+func TestRelay_Run(t *testing.T) {
+	t.Parallel()
+
+	ctx := telemetry.ContextWithTracer(context.Background(), trace.NewNoopTracerProvider().Tracer("test"))
+
+	newEvent := func(id string, eventType types.Event, payload string) entity.OutboxEvent {
+		return entity.OutboxEvent{ID: id, Type: eventType, Payload: json.RawMessage(payload)}
+	}
+
+	t.Run("should dispatch every event, in order, to all its handlers", func(t *testing.T) {
+		t.Parallel()
+
+		store := &memoryStore{events: []entity.OutboxEvent{
+			newEvent("1", types.EventUserCreated, `{"name":"ana"}`),
+			newEvent("2", types.EventLinkCreated, `{}`),
+			newEvent("3", types.EventUserCreated, `{"name":"bia"}`),
+		}}
+		relay := NewRelay(store, config.Outbox{BatchSize: 2, MaxAttempts: 3})
+
+		var first, second []string
+
+		Subscribe(relay, types.EventUserCreated, func(ctx context.Context, payload userCreated) error {
+			id, _ := ctxkey.GetOutboxEventID(ctx)
+			first = append(first, id+":"+payload.Name)
+
+			return nil
+		})
+		Subscribe(relay, types.EventUserCreated, func(_ context.Context, payload userCreated) error {
+			second = append(second, payload.Name)
+
+			return nil
+		})
+
+		require.NoError(t, relay.Run(ctx))
+		assert.Equal(t, []string{"1:ana", "3:bia"}, first)
+		assert.Equal(t, []string{"ana", "bia"}, second)
+		assert.Equal(t, []string{"1", "2", "3"}, store.delivered)
+	})
+
+	t.Run("should hold later events back until a failing one runs out of attempts", func(t *testing.T) {
+		t.Parallel()
+
+		store := &memoryStore{events: []entity.OutboxEvent{
+			newEvent("1", types.EventUserCreated, `{"name":"ana"}`),
+			newEvent("2", types.EventUserCreated, `{"name":"bia"}`),
+		}}
+		relay := NewRelay(store, config.Outbox{BatchSize: 10, MaxAttempts: 2})
+
+		Subscribe(relay, types.EventUserCreated, func(_ context.Context, payload userCreated) error {
+			if payload.Name == "ana" {
+				return errors.New("unavailable")
+			}
+
+			return nil
+		})
+
+		require.NoError(t, relay.Run(ctx))
+		assert.Empty(t, store.delivered)
+
+		require.NoError(t, relay.Run(ctx))
+		assert.Equal(t, []string{"1"}, store.failed)
+		assert.Equal(t, []string{"2"}, store.delivered)
+	})
+
+	t.Run("should recover handler panics as failures", func(t *testing.T) {
+		t.Parallel()
+
+		store := &memoryStore{events: []entity.OutboxEvent{newEvent("1", types.EventLinkUpdated, `{}`)}}
+		relay := NewRelay(store, config.Outbox{BatchSize: 10, MaxAttempts: 1})
+
+		Subscribe(relay, types.EventLinkUpdated, func(context.Context, struct{}) error {
+			panic("boom")
+		})
+
+		require.NoError(t, relay.Run(ctx))
+		assert.Equal(t, []string{"1"}, store.failed)
+	})
+}
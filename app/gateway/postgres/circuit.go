@@ -0,0 +1,81 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/library/circuitbreaker"
+	"github.com/go-api-template/app/library/consistency"
+)
+
+// read runs a read-only query inside the read circuit. Queries in a
+// transaction already run inside the circuit of the transaction.
+func (c *Client) read(ctx context.Context, fn func(context.Context) error) error {
+	if txFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	return circuitbreaker.Execute(ctx, c.readCircuit, fn, isExpectedError) //nolint:wrapcheck
+}
+
+// readReplica runs a read-only query like read, but on a replica when one is
+// healthy and ctx doesn't need to read its own writes. Queries failing on a
+// replica eject it and run again on the primary.
+func (c *Client) readReplica(ctx context.Context, fn func(context.Context) error) error {
+	if r := c.pickReplica(ctx); r != nil {
+		err := circuitbreaker.Execute(ctx, r.circuit, func(ctx context.Context) error {
+			return fn(contextWithReplica(ctx, r))
+		}, isExpectedError)
+		if err == nil || isExpectedError(err) || ctx.Err() != nil {
+			return err //nolint:wrapcheck
+		}
+
+		c.replicas.eject(ctx, r, err)
+	}
+
+	return c.read(ctx, fn)
+}
+
+func (c *Client) pickReplica(ctx context.Context) *replica {
+	if c.replicas == nil || txFromContext(ctx) != nil || consistency.RequiresPrimary(ctx, c.readYourWritesWindow) {
+		return nil
+	}
+
+	return c.replicas.pick()
+}
+
+// write runs a statement that changes data inside the write circuit, and
+// records it so the later reads of ctx go to the primary. Statements in a
+// transaction already run inside the circuit of the transaction.
+func (c *Client) write(ctx context.Context, fn func(context.Context) error) error {
+	if txFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+
+	err := circuitbreaker.Execute(ctx, c.writeCircuit, fn, isExpectedError)
+	if err == nil {
+		consistency.MarkWrite(ctx)
+	}
+
+	return err //nolint:wrapcheck
+}
+
+// tx runs fn in a transaction inside the write circuit, or in a savepoint of
+// the transaction of ctx. The transaction is committed when fn returns nil
+// and rolled back otherwise.
+func (c *Client) tx(ctx context.Context, fn func(context.Context, pgx.Tx) error) error {
+	return c.write(ctx, func(ctx context.Context) error {
+		return c.begin(ctx, pgx.TxOptions{}, fn)
+	})
+}
+
+// isExpectedError reports domain errors (e.g. not found), which say nothing
+// about the health of the database and must not open the circuits.
+func isExpectedError(err error) bool {
+	var appError erring.AppError
+
+	return errors.As(err, &appError)
+}
@@ -0,0 +1,9 @@
+package postgres
+
+type ClicksRepository struct {
+	*Client
+}
+
+func NewClicksRepository(client *Client) *ClicksRepository {
+	return &ClicksRepository{client}
+}
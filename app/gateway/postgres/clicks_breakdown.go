@@ -0,0 +1,113 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+)
+
+// BreakDownClicks returns the limit countries, cities, browsers, operating
+// systems and devices most clicks on a link came from, and how many came from
+// bots. Bot clicks and clicks with an unknown value are left out of the rest.
+func (r *ClicksRepository) BreakDownClicks(ctx context.Context, linkID string, limit int) (entity.ClickBreakdown, error) {
+	const (
+		operation      = "Repository.Clicks.BreakDownClicks"
+		countriesQuery = `
+			SELECT country, '', '', count(*)
+			FROM clicks
+			WHERE link_id = $1 AND NOT bot AND country IS NOT NULL
+			GROUP BY country
+			ORDER BY count(*) DESC, country
+			LIMIT $2
+		`
+		citiesQuery = `
+			SELECT country, COALESCE(region, ''), city, count(*)
+			FROM clicks
+			WHERE link_id = $1 AND NOT bot AND country IS NOT NULL AND city IS NOT NULL
+			GROUP BY country, region, city
+			ORDER BY count(*) DESC, country, region, city
+			LIMIT $2
+		`
+		// The column is one of browser, os and device.
+		valuesQuery = `
+			SELECT %[1]s, count(*)
+			FROM clicks
+			WHERE link_id = $1 AND NOT bot AND %[1]s IS NOT NULL
+			GROUP BY %[1]s
+			ORDER BY count(*) DESC, %[1]s
+			LIMIT $2
+		`
+		botsQuery = `SELECT count(*) FROM clicks WHERE link_id = $1 AND bot`
+	)
+
+	var breakdown entity.ClickBreakdown
+
+	err := r.Client.readReplica(ctx, func(ctx context.Context) error {
+		var err error
+
+		breakdown.Countries, err = r.queryLocationClicks(ctx, countriesQuery, linkID, limit)
+		if err != nil {
+			return err
+		}
+
+		breakdown.Cities, err = r.queryLocationClicks(ctx, citiesQuery, linkID, limit)
+		if err != nil {
+			return err
+		}
+
+		breakdown.Browsers, err = r.queryValueClicks(ctx, fmt.Sprintf(valuesQuery, "browser"), linkID, limit)
+		if err != nil {
+			return err
+		}
+
+		breakdown.OSes, err = r.queryValueClicks(ctx, fmt.Sprintf(valuesQuery, "os"), linkID, limit)
+		if err != nil {
+			return err
+		}
+
+		breakdown.Devices, err = r.queryValueClicks(ctx, fmt.Sprintf(valuesQuery, "device"), linkID, limit)
+		if err != nil {
+			return err
+		}
+
+		return r.Client.db(ctx).QueryRow(ctx, botsQuery, linkID).Scan(&breakdown.BotClicks) //nolint:wrapcheck
+	})
+	if err != nil {
+		return entity.ClickBreakdown{}, fmt.Errorf("%s (%s) -> %w", operation, linkID, err)
+	}
+
+	return breakdown, nil
+}
+
+func (r *ClicksRepository) queryLocationClicks(ctx context.Context, query, linkID string, limit int) ([]entity.LocationClicks, error) {
+	rows, err := r.Client.db(ctx).Query(ctx, query, linkID, limit)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.LocationClicks, error) { //nolint:wrapcheck
+		var location entity.LocationClicks
+
+		err := row.Scan(&location.Country, &location.Region, &location.City, &location.Clicks)
+
+		return location, err //nolint:wrapcheck
+	})
+}
+
+func (r *ClicksRepository) queryValueClicks(ctx context.Context, query, linkID string, limit int) ([]entity.ValueClicks, error) {
+	rows, err := r.Client.db(ctx).Query(ctx, query, linkID, limit)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.ValueClicks, error) { //nolint:wrapcheck
+		var value entity.ValueClicks
+
+		err := row.Scan(&value.Value, &value.Clicks)
+
+		return value, err //nolint:wrapcheck
+	})
+}
@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-api-template/app/domain/entity"
+)
+
+func (r *ClicksRepository) Create(ctx context.Context, click entity.Click) error {
+	const (
+		operation = "Repository.Clicks.Create"
+		query     = `
+			INSERT INTO clicks (id, link_id, code, clicked_at, referrer, user_agent, country, region, city, asn, browser, os, device, bot)
+			VALUES (
+				$1, $2, $3, $4, NULLIF($5, ''), NULLIF($6, ''),
+				NULLIF($7, ''), NULLIF($8, ''), NULLIF($9, ''), NULLIF($10, 0),
+				NULLIF($11, ''), NULLIF($12, ''), NULLIF($13, ''), $14
+			)
+		`
+	)
+
+	err := r.Client.write(ctx, func(ctx context.Context) error {
+		_, err := r.Client.db(ctx).Exec(
+			ctx,
+			query,
+			click.ID,
+			click.LinkID,
+			click.Code,
+			click.ClickedAt,
+			click.Referrer,
+			click.UserAgent,
+			click.Country,
+			click.Region,
+			click.City,
+			int64(click.ASN),
+			click.Browser,
+			click.OS,
+			click.Device,
+			click.Bot,
+		)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
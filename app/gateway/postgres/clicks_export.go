@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+)
+
+const clicksFilterCondition = `
+	c.clicked_at >= $1 AND c.clicked_at < $2
+	AND ($3 = '' OR l.user_id = $3)
+	AND ($4 = '' OR c.code = $4)
+`
+
+func (r *ClicksRepository) CountClicks(ctx context.Context, filter entity.ClicksFilter) (int64, error) {
+	const (
+		operation = "Repository.Clicks.CountClicks"
+		query     = `SELECT count(*) FROM clicks c JOIN links l ON l.id = c.link_id WHERE ` + clicksFilterCondition
+	)
+
+	var count int64
+
+	err := r.Client.readReplica(ctx, func(ctx context.Context) error {
+		return r.Client.db(ctx).QueryRow(ctx, query, filter.From, filter.To, filter.UserID, filter.Code).Scan(&count) //nolint:wrapcheck
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return count, nil
+}
+
+// ExportClicks calls fn for every click matching filter, oldest first.
+func (r *ClicksRepository) ExportClicks(ctx context.Context, filter entity.ClicksFilter, fn func(entity.Click) error) error {
+	const (
+		operation = "Repository.Clicks.ExportClicks"
+		query     = `
+			SELECT
+				c.id,
+				c.link_id,
+				c.code,
+				c.clicked_at,
+				COALESCE(c.referrer, ''),
+				COALESCE(c.user_agent, ''),
+				COALESCE(c.country, ''),
+				COALESCE(c.region, ''),
+				COALESCE(c.city, ''),
+				COALESCE(c.asn, 0),
+				COALESCE(c.browser, ''),
+				COALESCE(c.os, ''),
+				COALESCE(c.device, ''),
+				c.bot
+			FROM clicks c
+			JOIN links l ON l.id = c.link_id
+			WHERE ` + clicksFilterCondition + `
+			ORDER BY c.clicked_at, c.id
+		`
+	)
+
+	args := []any{filter.From, filter.To, filter.UserID, filter.Code}
+
+	err := r.Client.cursor(ctx, query, args, func(rows pgx.Rows) error {
+		var (
+			click entity.Click
+			asn   int64
+		)
+
+		err := rows.Scan(
+			&click.ID,
+			&click.LinkID,
+			&click.Code,
+			&click.ClickedAt,
+			&click.Referrer,
+			&click.UserAgent,
+			&click.Country,
+			&click.Region,
+			&click.City,
+			&asn,
+			&click.Browser,
+			&click.OS,
+			&click.Device,
+			&click.Bot,
+		)
+		if err != nil {
+			return fmt.Errorf("scan: %w", err)
+		}
+
+		click.ASN = uint32(asn)
+
+		return fn(click)
+	})
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
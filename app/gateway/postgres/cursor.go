@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/go-api-template/app/domain/erring"
+)
+
+// Rows fetched per round trip by cursor.
+const cursorFetchSize = 1000
+
+// cursor runs query through a server side cursor in a read-only transaction
+// and calls fn for every row, so exports of any size use bounded memory.
+//
+// Exports take longer than the circuit timeout, so they don't run inside the
+// read circuit; they are only refused while it is open. They run on a
+// replica when one is healthy; rows already passed to fn can't be taken
+// back, so a failing replica is ejected without falling back to the primary.
+func (c *Client) cursor(ctx context.Context, query string, args []any, fn func(pgx.Rows) error) error {
+	if r := c.pickReplica(ctx); r != nil {
+		err := c.runCursor(ctx, r.pool, query, args, fn)
+		if err != nil && ctx.Err() == nil {
+			c.replicas.eject(ctx, r, err)
+		}
+
+		return err
+	}
+
+	if c.readCircuit != nil && c.readCircuit.IsOpen() {
+		return fmt.Errorf("%w: %s", erring.ErrDependencyUnavailable, c.readCircuit.Name())
+	}
+
+	return c.runCursor(ctx, c.Pool, query, args, fn)
+}
+
+func (c *Client) runCursor(ctx context.Context, pool *pgxpool.Pool, query string, args []any, fn func(pgx.Rows) error) error {
+	return pgx.BeginTxFunc(ctx, pool, pgx.TxOptions{AccessMode: pgx.ReadOnly}, func(tx pgx.Tx) error { //nolint:wrapcheck
+		if _, err := tx.Exec(ctx, "DECLARE export_cursor NO SCROLL CURSOR FOR "+query, args...); err != nil {
+			return fmt.Errorf("declare cursor: %w", err)
+		}
+
+		fetch := fmt.Sprintf("FETCH FORWARD %d FROM export_cursor", cursorFetchSize)
+
+		for {
+			rows, err := tx.Query(ctx, fetch)
+			if err != nil {
+				return fmt.Errorf("fetch: %w", err)
+			}
+
+			fetched := 0
+
+			for rows.Next() {
+				fetched++
+
+				if err := fn(rows); err != nil {
+					rows.Close()
+
+					return err
+				}
+			}
+
+			rows.Close()
+
+			if err := rows.Err(); err != nil {
+				return fmt.Errorf("fetch: %w", err)
+			}
+
+			if fetched < cursorFetchSize {
+				return nil
+			}
+		}
+	})
+}
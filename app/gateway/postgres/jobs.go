@@ -0,0 +1,9 @@
+package postgres
+
+type JobsRepository struct {
+	*Client
+}
+
+func NewJobsRepository(client *Client) *JobsRepository {
+	return &JobsRepository{client}
+}
@@ -0,0 +1,75 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/types"
+)
+
+// Claim locks up to limit jobs of the given types that are due, or whose
+// previous run outlived its visibility timeout, for visibilityTimeout.
+// Concurrent workers skip each other's rows instead of waiting on them.
+func (r *JobsRepository) Claim(ctx context.Context, jobTypes []types.Job, limit int, visibilityTimeout time.Duration) ([]entity.Job, error) {
+	const (
+		operation = "Repository.Jobs.Claim"
+		query     = `
+			UPDATE jobs
+			SET status = 'running',
+				attempts = attempts + 1,
+				locked_until = now() + make_interval(secs => $3),
+				updated_at = now()
+			WHERE id IN (
+				SELECT id
+				FROM jobs
+				WHERE type = ANY($1)
+					AND (
+						(status = 'queued' AND run_at <= now())
+						OR (status = 'running' AND locked_until < now())
+					)
+				ORDER BY run_at
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, type, payload, trace_carrier, status, attempts, max_attempts, run_at, created_at
+		`
+	)
+
+	var jobs []entity.Job
+
+	err := r.Client.write(ctx, func(ctx context.Context) error {
+		rows, err := r.Client.db(ctx).Query(ctx, query, jobTypes, limit, visibilityTimeout.Seconds())
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		jobs, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.Job, error) {
+			var job entity.Job
+
+			err := row.Scan(
+				&job.ID,
+				&job.Type,
+				&job.Payload,
+				&job.TraceCarrier,
+				&job.Status,
+				&job.Attempts,
+				&job.MaxAttempts,
+				&job.RunAt,
+				&job.CreatedAt,
+			)
+
+			return job, err //nolint:wrapcheck
+		})
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return jobs, nil
+}
@@ -0,0 +1,38 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-api-template/app/domain/entity"
+)
+
+func (r *JobsRepository) Enqueue(ctx context.Context, job entity.Job) error {
+	const (
+		operation = "Repository.Jobs.Enqueue"
+		query     = `
+			INSERT INTO jobs (id, type, payload, trace_carrier, max_attempts, run_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+		`
+	)
+
+	err := r.Client.write(ctx, func(ctx context.Context) error {
+		_, err := r.Client.db(ctx).Exec(
+			ctx,
+			query,
+			job.ID,
+			job.Type,
+			job.Payload,
+			job.TraceCarrier,
+			job.MaxAttempts,
+			job.RunAt,
+		)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
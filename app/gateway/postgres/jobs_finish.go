@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-api-template/app/domain/entity"
+)
+
+// The statements below only touch the job if it is still held by the claim
+// that ran it (same attempt): a run that outlived its visibility timeout must
+// not finish a job another worker took over.
+
+// Complete removes a job that ran successfully.
+func (r *JobsRepository) Complete(ctx context.Context, job entity.Job) error {
+	const (
+		operation = "Repository.Jobs.Complete"
+		query     = `DELETE FROM jobs WHERE id = $1 AND attempts = $2 AND status = 'running'`
+	)
+
+	err := r.Client.write(ctx, func(ctx context.Context) error {
+		_, err := r.Client.db(ctx).Exec(ctx, query, job.ID, job.Attempts)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
+
+// Retry queues a failed job to run again at runAt.
+func (r *JobsRepository) Retry(ctx context.Context, job entity.Job, runAt time.Time, reason string) error {
+	const (
+		operation = "Repository.Jobs.Retry"
+		query     = `
+			UPDATE jobs
+			SET status = 'queued', run_at = $3, locked_until = NULL, last_error = $4, updated_at = now()
+			WHERE id = $1 AND attempts = $2 AND status = 'running'
+		`
+	)
+
+	err := r.Client.write(ctx, func(ctx context.Context) error {
+		_, err := r.Client.db(ctx).Exec(ctx, query, job.ID, job.Attempts, runAt, reason)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
+
+// DeadLetter parks a job that won't be retried. Dead jobs stay in the table
+// for inspection and can be requeued by setting their status back to queued
+// and their attempts to 0.
+func (r *JobsRepository) DeadLetter(ctx context.Context, job entity.Job, reason string) error {
+	const (
+		operation = "Repository.Jobs.DeadLetter"
+		query     = `
+			UPDATE jobs
+			SET status = 'dead', locked_until = NULL, last_error = $3, updated_at = now()
+			WHERE id = $1 AND attempts = $2 AND status = 'running'
+		`
+	)
+
+	err := r.Client.write(ctx, func(ctx context.Context) error {
+		_, err := r.Client.db(ctx).Exec(ctx, query, job.ID, job.Attempts, reason)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
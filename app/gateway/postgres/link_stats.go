@@ -0,0 +1,9 @@
+package postgres
+
+type LinkStatsRepository struct {
+	*Client
+}
+
+func NewLinkStatsRepository(client *Client) *LinkStatsRepository {
+	return &LinkStatsRepository{client}
+}
@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+)
+
+// GetLinkStats returns the stats of a link, all zero before its first
+// flush.
+func (r *LinkStatsRepository) GetLinkStats(ctx context.Context, linkID string) (entity.LinkStats, error) {
+	const (
+		operation = "Repository.LinkStats.GetLinkStats"
+		query     = `
+			SELECT clicks, unique_visitors, recorded_clicks, reconciled_at
+			FROM link_stats
+			WHERE link_id = $1
+		`
+	)
+
+	stats := entity.LinkStats{LinkID: linkID}
+
+	err := r.Client.readReplica(ctx, func(ctx context.Context) error {
+		err := r.Client.db(ctx).QueryRow(ctx, query, linkID).Scan(
+			&stats.Clicks,
+			&stats.UniqueVisitors,
+			&stats.RecordedClicks,
+			&stats.ReconciledAt,
+		)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return entity.LinkStats{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return stats, nil
+}
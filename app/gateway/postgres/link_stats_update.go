@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+)
+
+// AddClicks adds counts flushed from Redis to the stats of a link. Unique
+// visitors are an estimate over all clicks, so the larger one is kept. The
+// clicks in the clicks table, but for bot ones, are counted again, to compare
+// both stores.
+//
+// The first flush of a link seeds its clicks from the clicks table instead,
+// which holds the clicks from before counting started and, already, most of
+// the ones being flushed.
+func (r *LinkStatsRepository) AddClicks(ctx context.Context, counts entity.ClickCounts) (entity.LinkStats, error) {
+	const (
+		operation = "Repository.LinkStats.AddClicks"
+		query     = `
+			INSERT INTO link_stats (link_id, clicks, unique_visitors, recorded_clicks, reconciled_at)
+			SELECT l.id, r.recorded, $3, r.recorded, now()
+			FROM links l
+			CROSS JOIN LATERAL (SELECT count(*) AS recorded FROM clicks c WHERE c.link_id = l.id AND NOT c.bot) r
+			WHERE l.id = $1
+			ON CONFLICT (link_id) DO UPDATE SET
+				clicks = link_stats.clicks + $2,
+				unique_visitors = GREATEST(link_stats.unique_visitors, EXCLUDED.unique_visitors),
+				recorded_clicks = EXCLUDED.recorded_clicks,
+				reconciled_at = EXCLUDED.reconciled_at
+			RETURNING clicks, unique_visitors, recorded_clicks, reconciled_at
+		`
+	)
+
+	stats := entity.LinkStats{LinkID: counts.LinkID}
+
+	err := r.Client.write(ctx, func(ctx context.Context) error {
+		err := r.Client.db(ctx).QueryRow(
+			ctx,
+			query,
+			counts.LinkID,
+			counts.Clicks,
+			counts.UniqueVisitors,
+		).Scan(
+			&stats.Clicks,
+			&stats.UniqueVisitors,
+			&stats.RecordedClicks,
+			&stats.ReconciledAt,
+		)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return erring.ErrLinkNotFound
+		}
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return entity.LinkStats{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,19 @@
+package postgres
+
+import "github.com/go-api-template/app/domain/entity"
+
+type LinksRepository struct {
+	*Client
+}
+
+func NewLinksRepository(client *Client) *LinksRepository {
+	return &LinksRepository{client}
+}
+
+// outboxLink is the payload of link events, which must not carry the
+// password hash.
+func outboxLink(link entity.Link) entity.Link {
+	link.PasswordHash = ""
+
+	return link
+}
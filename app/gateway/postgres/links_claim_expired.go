@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+)
+
+// ClaimExpiredLinks marks up to limit expired links of users as published
+// and returns them, so each link.expired event is only published once even
+// with several workers looking for them.
+func (r *LinksRepository) ClaimExpiredLinks(ctx context.Context, limit int) ([]entity.Link, error) {
+	const (
+		operation = "Repository.Links.ClaimExpiredLinks"
+		query     = `
+			UPDATE links
+			SET expired_event_at = now()
+			WHERE id IN (
+				SELECT id
+				FROM links
+				WHERE user_id IS NOT NULL
+					AND expired_event_at IS NULL
+					AND expires_at <= now()
+				ORDER BY expires_at
+				LIMIT $1
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, code, user_id, target_url, tags, expires_at, require_signature, created_at, updated_at
+		`
+	)
+
+	var links []entity.Link
+
+	err := r.Client.write(ctx, func(ctx context.Context) error {
+		rows, err := r.Client.db(ctx).Query(ctx, query, limit)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		links, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.Link, error) {
+			var link entity.Link
+
+			err := row.Scan(
+				&link.ID,
+				&link.Code,
+				&link.UserID,
+				&link.TargetURL,
+				&link.Tags,
+				&link.ExpiresAt,
+				&link.RequireSignature,
+				&link.CreatedAt,
+				&link.UpdatedAt,
+			)
+
+			return link, err //nolint:wrapcheck
+		})
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return links, nil
+}
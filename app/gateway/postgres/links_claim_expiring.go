@@ -0,0 +1,60 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+)
+
+// ClaimExpiringLinks marks up to limit links of users expiring before until
+// as notified and returns them, so each link is only notified once even with
+// several workers looking for them.
+func (r *LinksRepository) ClaimExpiringLinks(ctx context.Context, until time.Time, limit int) ([]entity.Link, error) {
+	const (
+		operation = "Repository.Links.ClaimExpiringLinks"
+		query     = `
+			UPDATE links
+			SET expiry_notified_at = now()
+			WHERE id IN (
+				SELECT id
+				FROM links
+				WHERE user_id IS NOT NULL
+					AND expiry_notified_at IS NULL
+					AND expires_at > now()
+					AND expires_at <= $1
+				ORDER BY expires_at
+				LIMIT $2
+				FOR UPDATE SKIP LOCKED
+			)
+			RETURNING id, code, user_id, expires_at
+		`
+	)
+
+	var links []entity.Link
+
+	err := r.Client.write(ctx, func(ctx context.Context) error {
+		rows, err := r.Client.db(ctx).Query(ctx, query, until, limit)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		links, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.Link, error) {
+			var link entity.Link
+
+			err := row.Scan(&link.ID, &link.Code, &link.UserID, &link.ExpiresAt)
+
+			return link, err //nolint:wrapcheck
+		})
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return links, nil
+}
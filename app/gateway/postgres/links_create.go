@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/domain/types"
+)
+
+// Create inserts a link along with its types.EventLinkCreated event.
+func (r *LinksRepository) Create(ctx context.Context, link entity.Link) error {
+	const (
+		operation = "Repository.Links.Create"
+		query     = `
+			INSERT INTO links (id, code, user_id, target_url, tags, expires_at, password_hash, require_signature)
+			VALUES ($1, $2, NULLIF($3, ''), $4, COALESCE($5, '{}'::text[]), $6, NULLIF($7, ''), $8)
+			ON CONFLICT (code) DO NOTHING
+		`
+	)
+
+	err := r.Client.tx(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		tag, err := tx.Exec(
+			ctx,
+			query,
+			link.ID,
+			link.Code,
+			link.UserID,
+			link.TargetURL,
+			link.Tags,
+			link.ExpiresAt,
+			link.PasswordHash,
+			link.RequireSignature,
+		)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		if tag.RowsAffected() == 0 {
+			return erring.ErrLinkCodeAlreadyExists
+		}
+
+		return writeOutboxEvent(ctx, tx, types.EventLinkCreated, link.ID, outboxLink(link))
+	})
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
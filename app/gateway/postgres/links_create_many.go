@@ -0,0 +1,78 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/types"
+)
+
+// CreateMany inserts links in a single transaction, along with a
+// types.EventLinkCreated event per inserted link. Links whose code is already
+// taken are skipped instead of failing the whole batch; created reports, by
+// position, which links were inserted.
+func (r *LinksRepository) CreateMany(ctx context.Context, links []entity.Link) ([]bool, error) {
+	const (
+		operation = "Repository.Links.CreateMany"
+		query     = `
+			INSERT INTO links (id, code, user_id, target_url, tags, expires_at, password_hash, require_signature)
+			VALUES ($1, $2, NULLIF($3, ''), $4, COALESCE($5, '{}'::text[]), $6, NULLIF($7, ''), $8)
+			ON CONFLICT (code) DO NOTHING
+		`
+	)
+
+	batch := &pgx.Batch{}
+	for _, link := range links {
+		batch.Queue(
+			query,
+			link.ID,
+			link.Code,
+			link.UserID,
+			link.TargetURL,
+			link.Tags,
+			link.ExpiresAt,
+			link.PasswordHash,
+			link.RequireSignature,
+		)
+	}
+
+	created := make([]bool, len(links))
+
+	err := r.Client.tx(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		results := tx.SendBatch(ctx, batch)
+		defer results.Close()
+
+		for i := range links {
+			tag, err := results.Exec()
+			if err != nil {
+				return fmt.Errorf("link %d: %w", i, err)
+			}
+
+			created[i] = tag.RowsAffected() == 1
+		}
+
+		if err := results.Close(); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		for i, link := range links {
+			if !created[i] {
+				continue
+			}
+
+			if err := writeOutboxEvent(ctx, tx, types.EventLinkCreated, link.ID, outboxLink(link)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return created, nil
+}
@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+)
+
+func (r *LinksRepository) CountLinks(ctx context.Context, filter entity.LinksFilter) (int64, error) {
+	const (
+		operation = "Repository.Links.CountLinks"
+		query     = `SELECT count(*) FROM links WHERE user_id = $1`
+	)
+
+	var count int64
+
+	err := r.Client.readReplica(ctx, func(ctx context.Context) error {
+		return r.Client.db(ctx).QueryRow(ctx, query, filter.UserID).Scan(&count) //nolint:wrapcheck
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return count, nil
+}
+
+// ExportLinks calls fn for every link matching filter, oldest first.
+func (r *LinksRepository) ExportLinks(ctx context.Context, filter entity.LinksFilter, fn func(entity.Link) error) error {
+	const (
+		operation = "Repository.Links.ExportLinks"
+		query     = `
+			SELECT
+				id,
+				code,
+				COALESCE(user_id, ''),
+				target_url,
+				tags,
+				expires_at,
+				COALESCE(password_hash, ''),
+				require_signature,
+				created_at,
+				updated_at
+			FROM links
+			WHERE user_id = $1
+			ORDER BY created_at, id
+		`
+	)
+
+	err := r.Client.cursor(ctx, query, []any{filter.UserID}, func(rows pgx.Rows) error {
+		var (
+			link         entity.Link
+			passwordHash string
+		)
+
+		err := rows.Scan(
+			&link.ID,
+			&link.Code,
+			&link.UserID,
+			&link.TargetURL,
+			&link.Tags,
+			&link.ExpiresAt,
+			&passwordHash,
+			&link.RequireSignature,
+			&link.CreatedAt,
+			&link.UpdatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("scan: %w", err)
+		}
+
+		link.SetPasswordHash(passwordHash)
+
+		return fn(link)
+	})
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
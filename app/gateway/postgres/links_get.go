@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+)
+
+func (r *LinksRepository) GetLinkByCode(ctx context.Context, code string) (entity.Link, error) {
+	const (
+		operation = "Repository.Links.GetLinkByCode"
+		query     = `
+			SELECT
+				id,
+				COALESCE(user_id, ''),
+				target_url,
+				tags,
+				expires_at,
+				COALESCE(password_hash, ''),
+				require_signature,
+				created_at,
+				updated_at
+			FROM links
+			WHERE code = $1
+		`
+	)
+
+	link := entity.Link{Code: code}
+
+	var passwordHash string
+
+	err := r.Client.readReplica(ctx, func(ctx context.Context) error {
+		err := r.Client.db(ctx).QueryRow(
+			ctx,
+			query,
+			code,
+		).Scan(
+			&link.ID,
+			&link.UserID,
+			&link.TargetURL,
+			&link.Tags,
+			&link.ExpiresAt,
+			&passwordHash,
+			&link.RequireSignature,
+			&link.CreatedAt,
+			&link.UpdatedAt,
+		)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return erring.ErrLinkNotFound
+		}
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return entity.Link{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	link.SetPasswordHash(passwordHash)
+
+	return link, nil
+}
@@ -0,0 +1,69 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/domain/types"
+)
+
+// Update changes the target and expiry of a link, along with a
+// types.EventLinkUpdated event. The password hash is only replaced when
+// updatePassword is set; an empty hash removes the protection.
+func (r *LinksRepository) Update(ctx context.Context, link entity.Link, updatePassword bool) error {
+	const (
+		operation = "Repository.Links.Update"
+		query     = `
+			UPDATE links SET
+				target_url = $1,
+				expires_at = $2,
+				password_hash = CASE WHEN $4 THEN NULLIF($5, '') ELSE password_hash END,
+				updated_at = now()
+			WHERE code = $3
+			RETURNING id, COALESCE(user_id, ''), tags, COALESCE(password_hash, ''), require_signature, created_at, updated_at
+		`
+	)
+
+	err := r.Client.tx(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		var passwordHash string
+
+		err := tx.QueryRow(
+			ctx,
+			query,
+			link.TargetURL,
+			link.ExpiresAt,
+			link.Code,
+			updatePassword,
+			link.PasswordHash,
+		).Scan(
+			&link.ID,
+			&link.UserID,
+			&link.Tags,
+			&passwordHash,
+			&link.RequireSignature,
+			&link.CreatedAt,
+			&link.UpdatedAt,
+		)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return erring.ErrLinkNotFound
+		}
+
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		link.SetPasswordHash(passwordHash)
+
+		return writeOutboxEvent(ctx, tx, types.EventLinkUpdated, link.ID, outboxLink(link))
+	})
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
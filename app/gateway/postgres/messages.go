@@ -0,0 +1,9 @@
+package postgres
+
+type MessagesRepository struct {
+	*Client
+}
+
+func NewMessagesRepository(client *Client) *MessagesRepository {
+	return &MessagesRepository{client}
+}
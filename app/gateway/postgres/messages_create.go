@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-api-template/app/domain/entity"
+)
+
+// Create inserts a message. Creating a message that already exists is a
+// no-op, so retried jobs can create their message again.
+func (r *MessagesRepository) Create(ctx context.Context, message entity.Message) error {
+	const (
+		operation = "Repository.Messages.Create"
+		query     = `
+			INSERT INTO messages (id, user_id, link_id, channel, recipient, template, status)
+			VALUES ($1, $2, NULLIF($3, ''), $4, $5, $6, $7)
+			ON CONFLICT (id) DO NOTHING
+		`
+	)
+
+	err := r.Client.write(ctx, func(ctx context.Context) error {
+		_, err := r.Client.db(ctx).Exec(
+			ctx,
+			query,
+			message.ID,
+			message.UserID,
+			message.LinkID,
+			message.Channel,
+			message.To,
+			message.Template,
+			message.Status,
+		)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+)
+
+func (r *MessagesRepository) GetMessageByID(ctx context.Context, id string) (entity.Message, error) {
+	const (
+		operation = "Repository.Messages.GetMessageByID"
+		query     = `
+			SELECT
+				user_id,
+				COALESCE(link_id, ''),
+				channel,
+				recipient,
+				template,
+				COALESCE(provider_id, ''),
+				status,
+				COALESCE(error_code, ''),
+				created_at,
+				updated_at
+			FROM messages
+			WHERE id = $1
+		`
+	)
+
+	message := entity.Message{ID: id}
+
+	err := r.Client.read(ctx, func(ctx context.Context) error {
+		err := r.Client.db(ctx).QueryRow(
+			ctx,
+			query,
+			id,
+		).Scan(
+			&message.UserID,
+			&message.LinkID,
+			&message.Channel,
+			&message.To,
+			&message.Template,
+			&message.ProviderID,
+			&message.Status,
+			&message.ErrorCode,
+			&message.CreatedAt,
+			&message.UpdatedAt,
+		)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return erring.ErrMessageNotFound
+		}
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return entity.Message{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return message, nil
+}
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+)
+
+// Order of the message statuses. Twilio may report them out of order, so a
+// status never replaces a later one.
+const messageStatusOrder = `ARRAY['pending', 'queued', 'sending', 'sent', 'undelivered', 'failed', 'delivered', 'read']`
+
+// SetSendResult records the outcome of handing a message to the provider:
+// its provider ID and status, or a failed status when it was rejected.
+func (r *MessagesRepository) SetSendResult(ctx context.Context, id, providerID string, status entity.MessageStatus) error {
+	const (
+		operation = "Repository.Messages.SetSendResult"
+		query     = `
+			UPDATE messages SET
+				provider_id = NULLIF($2, ''),
+				status = $3,
+				updated_at = now()
+			WHERE id = $1
+		`
+	)
+
+	err := r.Client.write(ctx, func(ctx context.Context) error {
+		_, err := r.Client.db(ctx).Exec(ctx, query, id, providerID, status)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
+
+// UpdateStatus records a delivery status reported by the provider.
+func (r *MessagesRepository) UpdateStatus(ctx context.Context, providerID string, status entity.MessageStatus, errorCode string) error {
+	const (
+		operation = "Repository.Messages.UpdateStatus"
+		query     = `
+			WITH message AS (
+				SELECT id, status FROM messages WHERE provider_id = $1
+			), updated AS (
+				UPDATE messages SET
+					status = $2,
+					error_code = NULLIF($3, ''),
+					updated_at = now()
+				FROM message
+				WHERE messages.id = message.id
+					AND array_position(` + messageStatusOrder + `, message.status::text)
+						< COALESCE(array_position(` + messageStatusOrder + `, $2::text), 0)
+			)
+			SELECT count(*) FROM message
+		`
+	)
+
+	err := r.Client.write(ctx, func(ctx context.Context) error {
+		var found int
+
+		if err := r.Client.db(ctx).QueryRow(ctx, query, providerID, status, errorCode).Scan(&found); err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		if found == 0 {
+			return erring.ErrMessageNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,16 @@
+begin;
+
+create table if not exists links
+(
+    id         varchar primary key,
+    code       varchar     not null unique,
+    user_id    varchar references users (id) on delete set null,
+    target_url text        not null,
+    expires_at timestamptz,
+    created_at timestamptz not null default now(),
+    updated_at timestamptz not null default now()
+);
+
+create index if not exists links_user_id_idx on links (user_id);
+
+commit;
@@ -0,0 +1,6 @@
+begin;
+
+alter table links
+    drop column if exists password_hash;
+
+commit;
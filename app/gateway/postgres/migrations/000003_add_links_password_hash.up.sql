@@ -0,0 +1,6 @@
+begin;
+
+alter table links
+    add column if not exists password_hash varchar;
+
+commit;
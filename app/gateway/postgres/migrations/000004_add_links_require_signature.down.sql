@@ -0,0 +1,6 @@
+begin;
+
+alter table links
+    drop column if exists require_signature;
+
+commit;
@@ -0,0 +1,6 @@
+begin;
+
+alter table links
+    add column if not exists require_signature boolean not null default false;
+
+commit;
@@ -0,0 +1,8 @@
+begin;
+
+drop index if exists links_tags_idx;
+
+alter table links
+    drop column if exists tags;
+
+commit;
@@ -0,0 +1,8 @@
+begin;
+
+alter table links
+    add column if not exists tags text[] not null default '{}';
+
+create index if not exists links_tags_idx on links using gin (tags);
+
+commit;
@@ -0,0 +1,16 @@
+begin;
+
+create table if not exists clicks
+(
+    id         varchar primary key,
+    link_id    varchar     not null references links (id) on delete cascade,
+    code       varchar     not null,
+    clicked_at timestamptz not null default now(),
+    referrer   text,
+    user_agent text
+);
+
+create index if not exists clicks_link_id_clicked_at_idx on clicks (link_id, clicked_at);
+create index if not exists clicks_clicked_at_idx on clicks (clicked_at);
+
+commit;
@@ -0,0 +1,23 @@
+begin;
+
+create table if not exists jobs
+(
+    id            varchar primary key,
+    type          varchar     not null,
+    payload       jsonb       not null,
+    trace_carrier jsonb       not null default '{}',
+    status        varchar     not null default 'queued',
+    attempts      integer     not null default 0,
+    max_attempts  integer     not null,
+    run_at        timestamptz not null default now(),
+    locked_until  timestamptz,
+    last_error    text,
+    created_at    timestamptz not null default now(),
+    updated_at    timestamptz not null default now()
+);
+
+create index if not exists jobs_queued_run_at_idx on jobs (run_at) where status = 'queued';
+create index if not exists jobs_running_locked_until_idx on jobs (locked_until) where status = 'running';
+create index if not exists jobs_dead_idx on jobs (type, updated_at) where status = 'dead';
+
+commit;
@@ -0,0 +1,11 @@
+begin;
+
+drop table if exists messages;
+
+drop index if exists links_expiry_notice_idx;
+alter table links drop column if exists expiry_notified_at;
+
+alter table users drop column if exists message_channel;
+alter table users drop column if exists phone;
+
+commit;
@@ -0,0 +1,26 @@
+begin;
+
+alter table users add column if not exists phone varchar;
+alter table users add column if not exists message_channel varchar;
+
+alter table links add column if not exists expiry_notified_at timestamptz;
+
+create index if not exists links_expiry_notice_idx on links (expires_at)
+    where user_id is not null and expires_at is not null and expiry_notified_at is null;
+
+create table if not exists messages
+(
+    id          varchar primary key,
+    user_id     varchar     not null references users (id) on delete cascade,
+    link_id     varchar     references links (id) on delete set null,
+    channel     varchar     not null,
+    recipient   varchar     not null,
+    template    varchar     not null,
+    provider_id varchar unique,
+    status      varchar     not null,
+    error_code  varchar,
+    created_at  timestamptz not null default now(),
+    updated_at  timestamptz not null default now()
+);
+
+commit;
@@ -0,0 +1,9 @@
+begin;
+
+drop index if exists links_expired_event_idx;
+alter table links drop column if exists expired_event_at;
+
+drop table if exists webhook_deliveries;
+drop table if exists webhooks;
+
+commit;
@@ -0,0 +1,43 @@
+begin;
+
+create table if not exists webhooks
+(
+    id                   varchar primary key,
+    user_id              varchar     not null references users (id) on delete cascade,
+    url                  text        not null,
+    secret               varchar     not null,
+    events               text[]      not null,
+    enabled              boolean     not null default true,
+    consecutive_failures integer     not null default 0,
+    disabled_at          timestamptz,
+    created_at           timestamptz not null default now(),
+    updated_at           timestamptz not null default now()
+);
+
+create index if not exists webhooks_user_id_idx on webhooks (user_id);
+
+create table if not exists webhook_deliveries
+(
+    id              varchar primary key,
+    webhook_id      varchar     not null references webhooks (id) on delete cascade,
+    event_id        varchar     not null,
+    event_type      varchar     not null,
+    payload         jsonb       not null,
+    status          varchar     not null default 'pending',
+    attempts        integer     not null default 0,
+    response_status integer,
+    response_body   text,
+    error           text,
+    created_at      timestamptz not null default now(),
+    updated_at      timestamptz not null default now(),
+    delivered_at    timestamptz
+);
+
+create index if not exists webhook_deliveries_webhook_id_id_idx on webhook_deliveries (webhook_id, id desc);
+
+alter table links add column if not exists expired_event_at timestamptz;
+
+create index if not exists links_expired_event_idx on links (expires_at)
+    where user_id is not null and expires_at is not null and expired_event_at is null;
+
+commit;
@@ -0,0 +1,20 @@
+begin;
+
+create table if not exists outbox
+(
+    seq          bigserial primary key,
+    id           varchar     not null unique,
+    type         varchar     not null,
+    aggregate_id varchar     not null,
+    payload      jsonb       not null,
+    attempts     integer     not null default 0,
+    last_error   text,
+    created_at   timestamptz not null default now(),
+    delivered_at timestamptz,
+    failed_at    timestamptz
+);
+
+create index if not exists outbox_pending_idx on outbox (seq)
+    where delivered_at is null and failed_at is null;
+
+commit;
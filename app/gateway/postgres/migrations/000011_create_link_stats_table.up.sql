@@ -0,0 +1,12 @@
+begin;
+
+create table if not exists link_stats
+(
+    link_id         varchar primary key references links (id) on delete cascade,
+    clicks          bigint      not null default 0,
+    unique_visitors bigint      not null default 0,
+    recorded_clicks bigint      not null default 0,
+    reconciled_at   timestamptz not null default now()
+);
+
+commit;
@@ -0,0 +1,11 @@
+begin;
+
+drop index if exists clicks_link_id_location_idx;
+
+alter table clicks
+    drop column if exists country,
+    drop column if exists region,
+    drop column if exists city,
+    drop column if exists asn;
+
+commit;
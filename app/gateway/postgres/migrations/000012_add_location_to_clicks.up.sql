@@ -0,0 +1,11 @@
+begin;
+
+alter table clicks
+    add column if not exists country varchar(2),
+    add column if not exists region  varchar,
+    add column if not exists city    varchar,
+    add column if not exists asn     bigint;
+
+create index if not exists clicks_link_id_location_idx on clicks (link_id, country, region, city);
+
+commit;
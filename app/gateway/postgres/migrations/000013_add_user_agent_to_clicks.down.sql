@@ -0,0 +1,9 @@
+begin;
+
+alter table clicks
+    drop column if exists browser,
+    drop column if exists os,
+    drop column if exists device,
+    drop column if exists bot;
+
+commit;
@@ -0,0 +1,9 @@
+begin;
+
+alter table clicks
+    add column if not exists browser varchar,
+    add column if not exists os      varchar,
+    add column if not exists device  varchar,
+    add column if not exists bot     boolean not null default false;
+
+commit;
@@ -0,0 +1,8 @@
+begin;
+
+drop index if exists users_api_key_hash_idx;
+
+alter table users
+    drop column if exists api_key_hash;
+
+commit;
@@ -0,0 +1,8 @@
+begin;
+
+alter table users
+    add column if not exists api_key_hash varchar;
+
+create unique index if not exists users_api_key_hash_idx on users (api_key_hash);
+
+commit;
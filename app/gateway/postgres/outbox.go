@@ -0,0 +1,45 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/go-api-template/app/domain/types"
+)
+
+type OutboxRepository struct {
+	*Client
+}
+
+func NewOutboxRepository(client *Client) *OutboxRepository {
+	return &OutboxRepository{client}
+}
+
+// writeOutboxEvent adds an event to the outbox within tx, so it is only
+// relayed if the change it describes is committed.
+func writeOutboxEvent(ctx context.Context, tx pgx.Tx, event types.Event, aggregateID string, payload any) error {
+	const query = `
+		INSERT INTO outbox (id, type, aggregate_id, payload)
+		VALUES ($1, $2, $3, $4)
+	`
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("outbox %s payload: %w", event, err)
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return fmt.Errorf("outbox %s id: %w", event, err)
+	}
+
+	if _, err := tx.Exec(ctx, query, id.String(), event, aggregateID, data); err != nil {
+		return fmt.Errorf("outbox %s: %w", event, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,107 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+)
+
+// Key of the advisory lock held by the relaying worker.
+const outboxRelayLock = 0x6f7574626f78 // "outbox"
+
+// Relay passes up to limit pending events, in order, to fn, marks them
+// delivered and returns how many it went through. Only one worker relays at a
+// time; the others go through no events.
+//
+// Events are ordered by seq, so one committed after a later seq was already
+// relayed is relayed late rather than lost.
+//
+// When fn fails, the failure is recorded and relaying stops, so later events
+// wait for the failed one. Events failing maxAttempts times are marked failed
+// and skipped instead. Either way, the events passed to fn before are only
+// marked delivered on commit: fn must be idempotent.
+//
+// Relaying takes longer than the circuit timeout, so it doesn't run inside
+// the write circuit; it is only refused while it is open.
+func (r *OutboxRepository) Relay(ctx context.Context, limit, maxAttempts int, fn func(context.Context, entity.OutboxEvent) error) (int, error) {
+	const (
+		operation   = "Repository.Outbox.Relay"
+		selectQuery = `
+			SELECT seq, id, type, aggregate_id, payload, attempts, created_at
+			FROM outbox
+			WHERE delivered_at IS NULL AND failed_at IS NULL
+			ORDER BY seq
+			LIMIT $1
+		`
+		deliveredQuery = `UPDATE outbox SET delivered_at = now() WHERE seq = $1`
+		failedQuery    = `
+			UPDATE outbox SET
+				attempts = attempts + 1,
+				last_error = $2,
+				failed_at = CASE WHEN attempts + 1 >= $3 THEN now() END
+			WHERE seq = $1
+		`
+	)
+
+	if r.Client.writeCircuit != nil && r.Client.writeCircuit.IsOpen() {
+		return 0, fmt.Errorf("%s -> %w: %s", operation, erring.ErrDependencyUnavailable, r.Client.writeCircuit.Name())
+	}
+
+	processed := 0
+
+	err := pgx.BeginFunc(ctx, r.Client.Pool, func(tx pgx.Tx) error {
+		var locked bool
+		if err := tx.QueryRow(ctx, `SELECT pg_try_advisory_xact_lock($1)`, outboxRelayLock).Scan(&locked); err != nil || !locked {
+			return err //nolint:wrapcheck
+		}
+
+		rows, err := tx.Query(ctx, selectQuery, limit)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entity.OutboxEvent, error) {
+			var event entity.OutboxEvent
+
+			err := row.Scan(&event.Seq, &event.ID, &event.Type, &event.AggregateID, &event.Payload, &event.Attempts, &event.CreatedAt)
+
+			return event, err //nolint:wrapcheck
+		})
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		for _, event := range events {
+			if fnErr := fn(ctx, event); fnErr != nil {
+				if _, err := tx.Exec(ctx, failedQuery, event.Seq, fnErr.Error(), maxAttempts); err != nil {
+					return err //nolint:wrapcheck
+				}
+
+				if event.Attempts+1 < maxAttempts {
+					return nil
+				}
+
+				processed++
+
+				continue
+			}
+
+			if _, err := tx.Exec(ctx, deliveredQuery, event.Seq); err != nil {
+				return err //nolint:wrapcheck
+			}
+
+			processed++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return processed, nil
+}
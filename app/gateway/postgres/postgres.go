@@ -5,7 +5,9 @@ import (
 	"embed"
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/cep21/circuit/v4"
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database/postgres"
 	"github.com/golang-migrate/migrate/v4/source/httpfs"
@@ -13,21 +15,41 @@ import (
 	"github.com/jackc/pgx/v5/stdlib"
 
 	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/library/circuitbreaker"
 )
 
 //go:embed migrations
 var MigrationsFS embed.FS
 
+const (
+	readCircuitName  = "postgres-read"
+	writeCircuitName = "postgres-write"
+)
+
 type Client struct {
 	Pool *pgxpool.Pool
+
+	readCircuit  *circuit.Circuit
+	writeCircuit *circuit.Circuit
+
+	// Nil without replicas.
+	replicas             *replicaSet
+	readYourWritesWindow time.Duration
 }
 
 func (c *Client) Close() {
+	if c.replicas != nil {
+		c.replicas.close()
+	}
+
 	c.Pool.Close()
 }
 
 // New connects to the Postgres database and performs migrations.
-func New(ctx context.Context, config config.Postgres) (*Client, error) {
+// Queries are protected by one circuit per operation class (reads and writes)
+// registered on circuitManager. Replicas, when configured, get a circuit each
+// and are health checked in the background until Close.
+func New(ctx context.Context, config config.Postgres, circuitManager *circuit.Manager) (*Client, error) {
 	const operation = "Postgres.New"
 
 	connString := fmt.Sprintf("user=%s password=%s host=%s port=%s dbname=%s", config.User, config.Password, config.Host, config.Port, config.DatabaseName)
@@ -73,5 +95,31 @@ func New(ctx context.Context, config config.Postgres) (*Client, error) {
 		return nil, fmt.Errorf("%s: %w", operation, err)
 	}
 
-	return &Client{pool}, nil
+	readCircuit, err := circuitbreaker.GetOrCreate(circuitManager, readCircuitName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	writeCircuit, err := circuitbreaker.GetOrCreate(circuitManager, writeCircuitName)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+
+	client := &Client{
+		Pool:                 pool,
+		readCircuit:          readCircuit,
+		writeCircuit:         writeCircuit,
+		readYourWritesWindow: config.ReadYourWritesWindow,
+	}
+
+	if len(config.ReplicaDSNs) > 0 {
+		client.replicas, err = newReplicaSet(config, circuitManager)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", operation, err)
+		}
+
+		go client.replicas.watch(context.WithoutCancel(ctx), config.ReplicaHealthCheckInterval)
+	}
+
+	return client, nil
 }
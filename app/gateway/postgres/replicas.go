@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/cep21/circuit/v4"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/library/circuitbreaker"
+)
+
+// Replay lag of a replica, 0 when it replayed all it received.
+const replicaLagQuery = `
+	SELECT CASE
+		WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+	END
+`
+
+type replica struct {
+	name    string
+	pool    *pgxpool.Pool
+	circuit *circuit.Circuit
+
+	// Replicas start ejected until their first health check passes.
+	healthy atomic.Bool
+}
+
+// replicaSet spreads reads over the healthy replicas. Replicas failing a
+// query or a health check are ejected until a later check passes.
+type replicaSet struct {
+	replicas []*replica
+	next     atomic.Uint64
+	maxLag   time.Duration
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newReplicaSet(cfg config.Postgres, circuitManager *circuit.Manager) (*replicaSet, error) {
+	set := &replicaSet{
+		maxLag: cfg.ReplicaMaxLag,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+
+	for i, dsn := range cfg.ReplicaDSNs {
+		name := fmt.Sprintf("postgres-replica-%d", i)
+
+		pgxConfig, err := pgxpool.ParseConfig(dsn)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		// Connections are opened lazily, so a replica that is down doesn't
+		// keep the service from starting.
+		pool, err := pgxpool.NewWithConfig(context.Background(), pgxConfig)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		circ, err := circuitbreaker.GetOrCreate(circuitManager, name)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		set.replicas = append(set.replicas, &replica{name: name, pool: pool, circuit: circ})
+	}
+
+	return set, nil
+}
+
+// pick returns the next healthy replica, or nil when there is none.
+func (s *replicaSet) pick() *replica {
+	for range s.replicas {
+		r := s.replicas[s.next.Add(1)%uint64(len(s.replicas))]
+		if r.healthy.Load() && (r.circuit == nil || !r.circuit.IsOpen()) {
+			return r
+		}
+	}
+
+	return nil
+}
+
+func (s *replicaSet) eject(ctx context.Context, r *replica, reason error) {
+	if r.healthy.Swap(false) {
+		slog.WarnContext(ctx, fmt.Sprintf("Postgres.Replicas -> %s ejected: %v", r.name, reason))
+	}
+}
+
+// watch health checks the replicas every interval until close.
+func (s *replicaSet) watch(ctx context.Context, interval time.Duration) {
+	defer close(s.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for _, r := range s.replicas {
+			s.check(ctx, r, interval)
+		}
+
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *replicaSet) check(ctx context.Context, r *replica, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	var lagSeconds float64
+	if err := r.pool.QueryRow(ctx, replicaLagQuery).Scan(&lagSeconds); err != nil {
+		s.eject(ctx, r, err)
+
+		return
+	}
+
+	if lag := time.Duration(lagSeconds * float64(time.Second)); s.maxLag > 0 && lag > s.maxLag {
+		s.eject(ctx, r, fmt.Errorf("lagging %s behind", lag.Round(time.Millisecond)))
+
+		return
+	}
+
+	if !r.healthy.Swap(true) {
+		slog.InfoContext(ctx, fmt.Sprintf("Postgres.Replicas -> %s healthy", r.name))
+	}
+}
+
+func (s *replicaSet) close() {
+	close(s.stop)
+	<-s.done
+
+	for _, r := range s.replicas {
+		r.pool.Close()
+	}
+}
@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"github.com/go-api-template/app/domain/types"
+)
+
+// Postgres error codes of transactions that can succeed when run again.
+const (
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+type (
+	txCtxKey      struct{}
+	replicaCtxKey struct{}
+)
+
+// querier runs statements on the pool or on a transaction.
+type querier interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// db returns the transaction started by TxManager.WithinTx for ctx, if any,
+// so repositories join it transparently, then the replica picked by
+// readReplica, then the primary pool.
+func (c *Client) db(ctx context.Context) querier {
+	if tx := txFromContext(ctx); tx != nil {
+		return tx
+	}
+
+	if r, ok := ctx.Value(replicaCtxKey{}).(*replica); ok {
+		return r.pool
+	}
+
+	return c.Pool
+}
+
+func contextWithReplica(ctx context.Context, r *replica) context.Context {
+	return context.WithValue(ctx, replicaCtxKey{}, r)
+}
+
+func txFromContext(ctx context.Context) pgx.Tx {
+	tx, _ := ctx.Value(txCtxKey{}).(pgx.Tx)
+
+	return tx
+}
+
+func contextWithTx(ctx context.Context, tx pgx.Tx) context.Context {
+	return context.WithValue(ctx, txCtxKey{}, tx)
+}
+
+// begin runs fn in a transaction started with opts, or in a savepoint of the
+// transaction of ctx when there is one.
+func (c *Client) begin(ctx context.Context, opts pgx.TxOptions, fn func(context.Context, pgx.Tx) error) error {
+	run := func(tx pgx.Tx) error {
+		return fn(contextWithTx(ctx, tx), tx)
+	}
+
+	if tx := txFromContext(ctx); tx != nil {
+		return pgx.BeginFunc(ctx, tx, run) //nolint:wrapcheck
+	}
+
+	return pgx.BeginTxFunc(ctx, c.Pool, opts, run) //nolint:wrapcheck
+}
+
+// TxManager runs use case steps in a single transaction.
+type TxManager struct {
+	client     *Client
+	maxRetries int
+}
+
+func NewTxManager(client *Client, maxRetries int) *TxManager {
+	return &TxManager{
+		client:     client,
+		maxRetries: maxRetries,
+	}
+}
+
+// WithinTx runs fn in a transaction that the repositories called with the
+// ctx it receives join. The transaction is committed when fn returns nil and
+// rolled back otherwise.
+//
+// Transactions run at READ COMMITTED unless an isolation level is given.
+// Nested calls run in a savepoint of the outer transaction, at its level, so
+// a failing inner fn only rolls back its own changes when the outer one
+// handles its error.
+//
+// Transactions aborted by deadlocks, or by serialization failures at
+// REPEATABLE READ and SERIALIZABLE, are run again up to maxRetries times, so
+// fn must not have effects outside the database it can't repeat.
+//
+// The whole transaction runs inside the write circuit and its timeout.
+func (m *TxManager) WithinTx(ctx context.Context, fn func(ctx context.Context) error, isolation ...types.TxIsolation) error {
+	const operation = "Postgres.TxManager.WithinTx"
+
+	var opts pgx.TxOptions
+	if len(isolation) > 0 {
+		opts.IsoLevel = pgx.TxIsoLevel(isolation[0])
+	}
+
+	run := func(ctx context.Context) error {
+		return m.client.begin(ctx, opts, func(ctx context.Context, _ pgx.Tx) error {
+			return fn(ctx)
+		})
+	}
+
+	// Only the outermost transaction can be retried; savepoints are rolled
+	// back along with it.
+	if txFromContext(ctx) != nil {
+		return run(ctx)
+	}
+
+	err := retryTx(ctx, m.maxRetries, func() error {
+		return m.client.write(ctx, run)
+	})
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
+
+// retryTx calls run until it succeeds, fails with an error that isn't
+// retryable or has been retried maxRetries times.
+func retryTx(ctx context.Context, maxRetries int, run func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := run()
+		if err == nil {
+			return nil
+		}
+
+		if !isRetryableTxError(err) || attempt >= maxRetries {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(retryTxDelay(attempt)):
+		}
+	}
+}
+
+func isRetryableTxError(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+
+	return pgErr.Code == serializationFailure || pgErr.Code == deadlockDetected
+}
+
+// retryTxDelay spreads retries of conflicting transactions apart.
+func retryTxDelay(attempt int) time.Duration {
+	base := 10 * time.Millisecond << attempt
+
+	return base + rand.N(base) //nolint:gosec
+}
@@ -0,0 +1,181 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeTx records the savepoints started in it and how they ended.
+type fakeTx struct {
+	pgx.Tx
+
+	savepoints []*fakeTx
+	committed  bool
+	rolledBack bool
+}
+
+func (tx *fakeTx) Begin(context.Context) (pgx.Tx, error) {
+	savepoint := &fakeTx{}
+	tx.savepoints = append(tx.savepoints, savepoint)
+
+	return savepoint, nil
+}
+
+func (tx *fakeTx) Commit(context.Context) error {
+	tx.committed = true
+
+	return nil
+}
+
+func (tx *fakeTx) Rollback(context.Context) error {
+	if tx.committed || tx.rolledBack {
+		return pgx.ErrTxClosed
+	}
+
+	tx.rolledBack = true
+
+	return nil
+}
+
+func TestTxManager_WithinTx_Nested(t *testing.T) {
+	t.Parallel()
+
+	serializationErr := &pgconn.PgError{Code: serializationFailure}
+
+	tests := []struct {
+		name           string
+		fnErr          error
+		wantCalls      int
+		wantCommitted  bool
+		wantRolledBack bool
+	}{
+		{
+			name:          "savepoint is released when fn succeeds",
+			wantCalls:     1,
+			wantCommitted: true,
+		},
+		{
+			name:           "savepoint is rolled back when fn fails",
+			fnErr:          errors.New("insert failed"),
+			wantCalls:      1,
+			wantRolledBack: true,
+		},
+		{
+			name:           "savepoint is not retried on its own",
+			fnErr:          serializationErr,
+			wantCalls:      1,
+			wantRolledBack: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			outer := &fakeTx{}
+			m := NewTxManager(&Client{}, 3)
+
+			calls := 0
+
+			err := m.WithinTx(contextWithTx(context.Background(), outer), func(ctx context.Context) error {
+				calls++
+
+				// Repositories join the savepoint, not the outer transaction.
+				require.Len(t, outer.savepoints, 1)
+				assert.Same(t, outer.savepoints[0], txFromContext(ctx))
+
+				return tt.fnErr
+			})
+			require.ErrorIs(t, err, tt.fnErr)
+
+			assert.Equal(t, tt.wantCalls, calls)
+			require.Len(t, outer.savepoints, 1)
+			assert.Equal(t, tt.wantCommitted, outer.savepoints[0].committed)
+			assert.Equal(t, tt.wantRolledBack, outer.savepoints[0].rolledBack)
+			assert.False(t, outer.committed || outer.rolledBack, "outer transaction is left to its caller")
+		})
+	}
+}
+
+func TestRetryTx(t *testing.T) {
+	t.Parallel()
+
+	serializationErr := &pgconn.PgError{Code: serializationFailure}
+	deadlockErr := &pgconn.PgError{Code: deadlockDetected}
+	uniqueErr := &pgconn.PgError{Code: "23505"}
+
+	tests := []struct {
+		name      string
+		errs      []error
+		wantErr   error
+		wantCalls int
+	}{
+		{
+			name:      "success is not retried",
+			errs:      []error{nil},
+			wantCalls: 1,
+		},
+		{
+			name:      "serialization failure is retried",
+			errs:      []error{serializationErr, nil},
+			wantCalls: 2,
+		},
+		{
+			name:      "wrapped deadlock is retried",
+			errs:      []error{fmt.Errorf("commit -> %w", deadlockErr), nil},
+			wantCalls: 2,
+		},
+		{
+			name:      "other errors are returned right away",
+			errs:      []error{uniqueErr, nil},
+			wantErr:   uniqueErr,
+			wantCalls: 1,
+		},
+		{
+			name:      "retries stop after maxRetries",
+			errs:      []error{deadlockErr, deadlockErr, deadlockErr, nil},
+			wantErr:   deadlockErr,
+			wantCalls: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			calls := 0
+
+			err := retryTx(context.Background(), 2, func() error {
+				calls++
+
+				return tt.errs[calls-1]
+			})
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestRetryTx_Canceled(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+
+	err := retryTx(ctx, 5, func() error {
+		calls++
+
+		return &pgconn.PgError{Code: serializationFailure}
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, calls)
+}
@@ -4,25 +4,40 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/jackc/pgx/v5"
+
 	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/types"
 )
 
+// Create inserts a user and, when it didn't exist yet, its types.EventUserCreated
+// event.
 func (r *UsersRepository) Create(ctx context.Context, user entity.User) error {
 	const (
 		operation = "Repository.Users.Create"
 		query     = `
-			INSERT INTO users (id, name)
-			VALUES ($1, $2)
+			INSERT INTO users (id, name, phone, message_channel, api_key_hash)
+			VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''))
 			ON CONFLICT DO NOTHING
 		`
 	)
 
-	_, err := r.Client.Pool.Exec(
-		ctx,
-		query,
-		user.ID,
-		user.Name,
-	)
+	err := r.Client.tx(ctx, func(ctx context.Context, tx pgx.Tx) error {
+		tag, err := tx.Exec(
+			ctx,
+			query,
+			user.ID,
+			user.Name,
+			user.Phone,
+			user.MessageChannel,
+			user.APIKeyHash,
+		)
+		if err != nil || tag.RowsAffected() == 0 {
+			return err //nolint:wrapcheck
+		}
+
+		return writeOutboxEvent(ctx, tx, types.EventUserCreated, user.ID, user)
+	})
 	if err != nil {
 		return fmt.Errorf("%s -> %w", operation, err)
 	}
@@ -17,6 +17,9 @@ func (r *UsersRepository) GetUserByID(ctx context.Context, id string) (entity.Us
 		query     = `
 			SELECT
 				name,
+				COALESCE(phone, ''),
+				COALESCE(message_channel, ''),
+				COALESCE(api_key_hash, ''),
 				created_at,
 				updated_at
 			FROM users
@@ -24,24 +27,55 @@ func (r *UsersRepository) GetUserByID(ctx context.Context, id string) (entity.Us
 		`
 	)
 
-	var User entity.User
+	User := entity.User{ID: id}
 
-	err := r.Client.Pool.QueryRow(
-		ctx,
-		query,
-		id,
-	).Scan(
-		&User.Name,
-		&User.CreatedAt,
-		&User.UpdatedAt,
-	)
-	if err != nil {
-		if errors.Is(pgx.ErrNoRows, err) {
-			return entity.User{}, fmt.Errorf("%s -> %w", operation, erring.ErrUserNotFound)
+	err := r.Client.readReplica(ctx, func(ctx context.Context) error {
+		err := r.Client.db(ctx).QueryRow(
+			ctx,
+			query,
+			id,
+		).Scan(
+			&User.Name,
+			&User.Phone,
+			&User.MessageChannel,
+			&User.APIKeyHash,
+			&User.CreatedAt,
+			&User.UpdatedAt,
+		)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return erring.ErrUserNotFound
 		}
 
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
 		return entity.User{}, fmt.Errorf("%s -> %w", operation, err)
 	}
 
 	return User, nil
 }
+
+// GetUserIDByAPIKeyHash returns the ID of the user with an API key. It reads
+// from the primary, so keys work right after being issued.
+func (r *UsersRepository) GetUserIDByAPIKeyHash(ctx context.Context, hash string) (string, error) {
+	const (
+		operation = "Repository.Users.GetUserIDByAPIKeyHash"
+		query     = `SELECT id FROM users WHERE api_key_hash = $1`
+	)
+
+	var id string
+
+	err := r.Client.read(ctx, func(ctx context.Context) error {
+		err := r.Client.db(ctx).QueryRow(ctx, query, hash).Scan(&id)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return erring.ErrUserUnauthenticated
+		}
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return "", fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return id, nil
+}
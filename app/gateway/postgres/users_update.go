@@ -5,24 +5,56 @@ import (
 	"fmt"
 
 	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
 )
 
 func (r *UsersRepository) Update(ctx context.Context, user entity.User) error {
 	const (
 		operation = "Repository.Users.Update"
 		query     = `
-			UPDATE users SET 
+			UPDATE users SET
 				name = $1,
-			WHERE id = $2
+				phone = NULLIF($2, ''),
+				message_channel = NULLIF($3, ''),
+				updated_at = now()
+			WHERE id = $4
 		`
 	)
 
-	_, err := r.Client.Pool.Exec(
-		ctx,
-		query,
-		user.Name,
-		user.ID,
+	err := r.Client.write(ctx, func(ctx context.Context) error {
+		_, err := r.Client.db(ctx).Exec(
+			ctx,
+			query,
+			user.Name,
+			user.Phone,
+			user.MessageChannel,
+			user.ID,
+		)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
+
+// SetAPIKeyHash replaces the API key of a user.
+func (r *UsersRepository) SetAPIKeyHash(ctx context.Context, id, hash string) error {
+	const (
+		operation = "Repository.Users.SetAPIKeyHash"
+		query     = `UPDATE users SET api_key_hash = $1, updated_at = now() WHERE id = $2`
 	)
+
+	err := r.Client.write(ctx, func(ctx context.Context) error {
+		tag, err := r.Client.db(ctx).Exec(ctx, query, hash, id)
+		if err == nil && tag.RowsAffected() == 0 {
+			return erring.ErrUserNotFound
+		}
+
+		return err //nolint:wrapcheck
+	})
 	if err != nil {
 		return fmt.Errorf("%s -> %w", operation, err)
 	}
@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-api-template/app/domain/entity"
+)
+
+func (r *WebhooksRepository) CreateDelivery(ctx context.Context, delivery entity.WebhookDelivery) error {
+	const (
+		operation = "Repository.Webhooks.CreateDelivery"
+		query     = `
+			INSERT INTO webhook_deliveries (id, webhook_id, event_id, event_type, payload)
+			VALUES ($1, $2, $3, $4, $5)
+		`
+	)
+
+	err := r.Client.write(ctx, func(ctx context.Context) error {
+		_, err := r.Client.db(ctx).Exec(
+			ctx,
+			query,
+			delivery.ID,
+			delivery.WebhookID,
+			delivery.EventID,
+			delivery.EventType,
+			delivery.Payload,
+		)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+)
+
+func (r *WebhooksRepository) GetDeliveryByID(ctx context.Context, id string) (entity.WebhookDelivery, error) {
+	const operation = "Repository.Webhooks.GetDeliveryByID"
+
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE id = $1`
+
+	var delivery entity.WebhookDelivery
+
+	err := r.Client.read(ctx, func(ctx context.Context) error {
+		rows, err := r.Client.db(ctx).Query(ctx, query, id)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		delivery, err = pgx.CollectOneRow(rows, scanWebhookDelivery)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return erring.ErrWebhookDeliveryNotFound
+		}
+
+		return err
+	})
+	if err != nil {
+		return entity.WebhookDelivery{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return delivery, nil
+}
+
+// ListDeliveries returns a page of the deliveries of a webhook, newest first.
+func (r *WebhooksRepository) ListDeliveries(ctx context.Context, filter entity.WebhookDeliveriesFilter) ([]entity.WebhookDelivery, error) {
+	const operation = "Repository.Webhooks.ListDeliveries"
+
+	conditions := []string{"webhook_id = $1"}
+	args := []any{filter.WebhookID}
+
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, "status = $"+strconv.Itoa(len(args)))
+	}
+
+	if filter.Before != "" {
+		args = append(args, filter.Before)
+		conditions = append(conditions, "id < $"+strconv.Itoa(len(args)))
+	}
+
+	args = append(args, filter.Limit)
+
+	query := `SELECT ` + webhookDeliveryColumns + ` FROM webhook_deliveries WHERE ` +
+		strings.Join(conditions, " AND ") +
+		` ORDER BY id DESC LIMIT $` + strconv.Itoa(len(args))
+
+	var deliveries []entity.WebhookDelivery
+
+	err := r.Client.read(ctx, func(ctx context.Context) error {
+		rows, err := r.Client.db(ctx).Query(ctx, query, args...)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		deliveries, err = pgx.CollectRows(rows, scanWebhookDelivery)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return deliveries, nil
+}
+
+func scanWebhookDelivery(row pgx.CollectableRow) (entity.WebhookDelivery, error) {
+	var delivery entity.WebhookDelivery
+
+	err := row.Scan(
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.EventID,
+		&delivery.EventType,
+		&delivery.Payload,
+		&delivery.Status,
+		&delivery.Attempts,
+		&delivery.ResponseStatus,
+		&delivery.ResponseBody,
+		&delivery.Error,
+		&delivery.CreatedAt,
+		&delivery.UpdatedAt,
+		&delivery.DeliveredAt,
+	)
+
+	return delivery, err //nolint:wrapcheck
+}
@@ -0,0 +1,46 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-api-template/app/domain/entity"
+)
+
+// RecordAttempt stores the outcome of a delivery attempt. A succeeded
+// delivery also gets its delivered_at set.
+func (r *WebhooksRepository) RecordAttempt(ctx context.Context, delivery entity.WebhookDelivery) error {
+	const (
+		operation = "Repository.Webhooks.RecordAttempt"
+		query     = `
+			UPDATE webhook_deliveries SET
+				status = $2,
+				attempts = attempts + 1,
+				response_status = NULLIF($3, 0),
+				response_body = NULLIF($4, ''),
+				error = NULLIF($5, ''),
+				delivered_at = CASE WHEN $2 = 'succeeded' THEN now() ELSE delivered_at END,
+				updated_at = now()
+			WHERE id = $1
+		`
+	)
+
+	err := r.Client.write(ctx, func(ctx context.Context) error {
+		_, err := r.Client.db(ctx).Exec(
+			ctx,
+			query,
+			delivery.ID,
+			delivery.Status,
+			delivery.ResponseStatus,
+			delivery.ResponseBody,
+			delivery.Error,
+		)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
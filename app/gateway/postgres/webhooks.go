@@ -0,0 +1,38 @@
+package postgres
+
+type WebhooksRepository struct {
+	*Client
+}
+
+func NewWebhooksRepository(client *Client) *WebhooksRepository {
+	return &WebhooksRepository{client}
+}
+
+const webhookColumns = `
+	id,
+	user_id,
+	url,
+	secret,
+	events,
+	enabled,
+	consecutive_failures,
+	disabled_at,
+	created_at,
+	updated_at
+`
+
+const webhookDeliveryColumns = `
+	id,
+	webhook_id,
+	event_id,
+	event_type,
+	payload,
+	status,
+	attempts,
+	COALESCE(response_status, 0),
+	COALESCE(response_body, ''),
+	COALESCE(error, ''),
+	created_at,
+	updated_at,
+	delivered_at
+`
@@ -0,0 +1,37 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-api-template/app/domain/entity"
+)
+
+func (r *WebhooksRepository) Create(ctx context.Context, webhook entity.Webhook) error {
+	const (
+		operation = "Repository.Webhooks.Create"
+		query     = `
+			INSERT INTO webhooks (id, user_id, url, secret, events)
+			VALUES ($1, $2, $3, $4, $5)
+		`
+	)
+
+	err := r.Client.write(ctx, func(ctx context.Context) error {
+		_, err := r.Client.db(ctx).Exec(
+			ctx,
+			query,
+			webhook.ID,
+			webhook.UserID,
+			webhook.URL,
+			webhook.Secret,
+			webhook.Events,
+		)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/domain/types"
+)
+
+func (r *WebhooksRepository) GetWebhookByID(ctx context.Context, id string) (entity.Webhook, error) {
+	const operation = "Repository.Webhooks.GetWebhookByID"
+
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1`
+
+	var webhook entity.Webhook
+
+	err := r.Client.read(ctx, func(ctx context.Context) error {
+		rows, err := r.Client.db(ctx).Query(ctx, query, id)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		webhook, err = pgx.CollectOneRow(rows, scanWebhook)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return erring.ErrWebhookNotFound
+		}
+
+		return err
+	})
+	if err != nil {
+		return entity.Webhook{}, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return webhook, nil
+}
+
+// ListWebhooks returns the webhooks of a user, oldest first.
+func (r *WebhooksRepository) ListWebhooks(ctx context.Context, userID string) ([]entity.Webhook, error) {
+	const operation = "Repository.Webhooks.ListWebhooks"
+
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE user_id = $1 ORDER BY id`
+
+	webhooks, err := r.listWebhooks(ctx, query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return webhooks, nil
+}
+
+// ListSubscribedWebhooks returns the enabled webhooks of a user subscribed
+// to event.
+func (r *WebhooksRepository) ListSubscribedWebhooks(ctx context.Context, userID string, event types.WebhookEvent) ([]entity.Webhook, error) {
+	const operation = "Repository.Webhooks.ListSubscribedWebhooks"
+
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE user_id = $1 AND enabled AND $2 = ANY(events)`
+
+	webhooks, err := r.listWebhooks(ctx, query, userID, event)
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return webhooks, nil
+}
+
+func (r *WebhooksRepository) listWebhooks(ctx context.Context, query string, args ...any) ([]entity.Webhook, error) {
+	var webhooks []entity.Webhook
+
+	err := r.Client.read(ctx, func(ctx context.Context) error {
+		rows, err := r.Client.db(ctx).Query(ctx, query, args...)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		webhooks, err = pgx.CollectRows(rows, scanWebhook)
+
+		return err //nolint:wrapcheck
+	})
+
+	return webhooks, err
+}
+
+func scanWebhook(row pgx.CollectableRow) (entity.Webhook, error) {
+	var webhook entity.Webhook
+
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.UserID,
+		&webhook.URL,
+		&webhook.Secret,
+		&webhook.Events,
+		&webhook.Enabled,
+		&webhook.ConsecutiveFailures,
+		&webhook.DisabledAt,
+		&webhook.CreatedAt,
+		&webhook.UpdatedAt,
+	)
+
+	return webhook, err //nolint:wrapcheck
+}
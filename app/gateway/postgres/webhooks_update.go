@@ -0,0 +1,121 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+)
+
+// Update changes the URL, events and enabled flag of a webhook. Enabling it
+// again clears its failures.
+func (r *WebhooksRepository) Update(ctx context.Context, webhook entity.Webhook) error {
+	const (
+		operation = "Repository.Webhooks.Update"
+		query     = `
+			UPDATE webhooks SET
+				url = $2,
+				events = $3,
+				consecutive_failures = CASE WHEN $4 AND NOT enabled THEN 0 ELSE consecutive_failures END,
+				disabled_at = CASE WHEN $4 THEN NULL ELSE COALESCE(disabled_at, now()) END,
+				enabled = $4,
+				updated_at = now()
+			WHERE id = $1
+		`
+	)
+
+	err := r.Client.write(ctx, func(ctx context.Context) error {
+		tag, err := r.Client.db(ctx).Exec(ctx, query, webhook.ID, webhook.URL, webhook.Events, webhook.Enabled)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		if tag.RowsAffected() == 0 {
+			return erring.ErrWebhookNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
+
+func (r *WebhooksRepository) Delete(ctx context.Context, id string) error {
+	const (
+		operation = "Repository.Webhooks.Delete"
+		query     = `DELETE FROM webhooks WHERE id = $1`
+	)
+
+	err := r.Client.write(ctx, func(ctx context.Context) error {
+		tag, err := r.Client.db(ctx).Exec(ctx, query, id)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		if tag.RowsAffected() == 0 {
+			return erring.ErrWebhookNotFound
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
+
+// RecordFailure counts a delivery that failed all its attempts, and disables
+// the webhook once disableAfter deliveries in a row failed. It reports
+// whether the webhook got disabled.
+func (r *WebhooksRepository) RecordFailure(ctx context.Context, id string, disableAfter int) (bool, error) {
+	const (
+		operation = "Repository.Webhooks.RecordFailure"
+		query     = `
+			UPDATE webhooks SET
+				consecutive_failures = consecutive_failures + 1,
+				enabled = enabled AND consecutive_failures + 1 < $2,
+				disabled_at = CASE
+					WHEN enabled AND consecutive_failures + 1 >= $2 THEN now()
+					ELSE disabled_at
+				END,
+				updated_at = now()
+			WHERE id = $1
+			RETURNING COALESCE(disabled_at = now(), false)
+		`
+	)
+
+	var disabled bool
+
+	err := r.Client.write(ctx, func(ctx context.Context) error {
+		return r.Client.db(ctx).QueryRow(ctx, query, id, disableAfter).Scan(&disabled) //nolint:wrapcheck
+	})
+	if err != nil {
+		return false, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return disabled, nil
+}
+
+// ResetFailures clears the failures of a webhook after a successful delivery.
+func (r *WebhooksRepository) ResetFailures(ctx context.Context, id string) error {
+	const (
+		operation = "Repository.Webhooks.ResetFailures"
+		query     = `UPDATE webhooks SET consecutive_failures = 0 WHERE id = $1 AND consecutive_failures > 0`
+	)
+
+	err := r.Client.write(ctx, func(ctx context.Context) error {
+		_, err := r.Client.db(ctx).Exec(ctx, query, id)
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
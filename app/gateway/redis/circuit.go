@@ -0,0 +1,27 @@
+package redis
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/library/circuitbreaker"
+)
+
+// read runs a read command inside the read circuit.
+func (c *Client) read(ctx context.Context, fn func(context.Context) error) error {
+	return circuitbreaker.Execute(ctx, c.readCircuit, fn, isExpectedError) //nolint:wrapcheck
+}
+
+// write runs a command that changes data inside the write circuit.
+func (c *Client) write(ctx context.Context, fn func(context.Context) error) error {
+	return circuitbreaker.Execute(ctx, c.writeCircuit, fn, isExpectedError) //nolint:wrapcheck
+}
+
+// isExpectedError reports cache misses and other domain errors, which must
+// not open the circuits.
+func isExpectedError(err error) bool {
+	var appError erring.AppError
+
+	return errors.Is(err, errCacheKeyDoesNotExist) || errors.As(err, &appError)
+}
@@ -0,0 +1,133 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/go-api-template/app/domain/entity"
+)
+
+// Per link, clicks not flushed into Postgres yet and a HyperLogLog of its
+// visitors; links with clicks to flush are in a set.
+const (
+	clickCountKeyPrefix    = "clicks:count:"
+	clickVisitorsKeyPrefix = "clicks:visitors:"
+	clickDirtyLinksKey     = "clicks:dirty-links"
+)
+
+// CountClick counts a click on a link by visitorID, in a single round trip.
+func (c *Client) CountClick(ctx context.Context, linkID, visitorID string) error {
+	const operation = "Redis.CountClick"
+
+	err := c.write(ctx, func(ctx context.Context) error {
+		_, err := c.Client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+			pipe.Incr(ctx, c.key(clickCountKeyPrefix+linkID))
+			pipe.PFAdd(ctx, c.key(clickVisitorsKeyPrefix+linkID), visitorID)
+			pipe.SAdd(ctx, c.key(clickDirtyLinksKey), linkID)
+
+			return nil
+		})
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, linkID, err)
+	}
+
+	return nil
+}
+
+// GetClickCounts returns the live counts of a link.
+func (c *Client) GetClickCounts(ctx context.Context, linkID string) (entity.ClickCounts, error) {
+	const operation = "Redis.GetClickCounts"
+
+	var clicks *goredis.StringCmd
+	var visitors *goredis.IntCmd
+
+	err := c.read(ctx, func(ctx context.Context) error {
+		_, err := c.Client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+			clicks = pipe.Get(ctx, c.key(clickCountKeyPrefix+linkID))
+			visitors = pipe.PFCount(ctx, c.key(clickVisitorsKeyPrefix+linkID))
+
+			return nil
+		})
+		if errors.Is(err, errCacheKeyDoesNotExist) {
+			return visitors.Err() //nolint:wrapcheck
+		}
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return entity.ClickCounts{}, fmt.Errorf("%s (%s) -> %w", operation, linkID, err)
+	}
+
+	// Missing before the first click and right after a flush.
+	count, _ := clicks.Int64()
+
+	return entity.ClickCounts{
+		LinkID:         linkID,
+		Clicks:         count,
+		UniqueVisitors: visitors.Val(),
+	}, nil
+}
+
+// PopDirtyLinks takes up to limit links with clicks to flush. Links whose
+// flush fails must be put back with MarkDirtyLinks.
+func (c *Client) PopDirtyLinks(ctx context.Context, limit int) ([]string, error) {
+	const operation = "Redis.PopDirtyLinks"
+
+	var linkIDs []string
+
+	err := c.write(ctx, func(ctx context.Context) error {
+		var err error
+
+		linkIDs, err = c.Client.SPopN(ctx, c.key(clickDirtyLinksKey), int64(limit)).Result()
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return linkIDs, nil
+}
+
+func (c *Client) MarkDirtyLinks(ctx context.Context, linkIDs ...string) error {
+	const operation = "Redis.MarkDirtyLinks"
+
+	if len(linkIDs) == 0 {
+		return nil
+	}
+
+	members := make([]any, len(linkIDs))
+	for i, id := range linkIDs {
+		members[i] = id
+	}
+
+	err := c.write(ctx, func(ctx context.Context) error {
+		return c.Client.SAdd(ctx, c.key(clickDirtyLinksKey), members...).Err() //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
+
+// SettleClicks subtracts clicks flushed into Postgres from the live count of
+// a link, keeping the ones counted meanwhile.
+func (c *Client) SettleClicks(ctx context.Context, linkID string, clicks int64) error {
+	const operation = "Redis.SettleClicks"
+
+	err := c.write(ctx, func(ctx context.Context) error {
+		return c.Client.DecrBy(ctx, c.key(clickCountKeyPrefix+linkID), clicks).Err() //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, linkID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,404 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/domain/entity"
+)
+
+// The clicks of each link are published on a channel for live viewers, and
+// kept in a capped stream for viewers to catch up on the ones they missed.
+const (
+	clickEventsStreamPrefix  = "clicks:events:"
+	clickEventsChannelPrefix = "clicks:live:"
+
+	// Live events queued per viewer; a viewer falling further behind catches
+	// up from the stream.
+	clickEventsQueueLength = 64
+)
+
+// Appends the event to the stream and publishes it with its stream ID, in a
+// single round trip.
+var publishClickEventScript = goredis.NewScript(`
+	local id = redis.call("XADD", KEYS[1], "MAXLEN", "~", ARGV[1], "*", "event", ARGV[2])
+	redis.call("PEXPIRE", KEYS[1], ARGV[3])
+	redis.call("PUBLISH", ARGV[4], id .. " " .. ARGV[2])
+	return id
+`)
+
+// ClickEvents streams the clicks of links live to their viewers, through any
+// instance. An instance holds a single subscription, to the channels of the
+// links being viewed on it, and fans their clicks out to the viewers.
+type ClickEvents struct {
+	client       *Client
+	bufferLength int64
+	bufferTTL    time.Duration
+
+	pubsub *goredis.PubSub
+
+	mu sync.Mutex
+	// Viewers by channel.
+	viewers map[string]map[*clickEventsViewer]struct{}
+}
+
+type clickEventsViewer struct {
+	events chan entity.ClickEvent
+
+	// Signaled when events may have been missed: when joining, when the
+	// subscription is made again and when events is full.
+	missed chan struct{}
+}
+
+func (v *clickEventsViewer) signalMissed() {
+	select {
+	case v.missed <- struct{}{}:
+	default:
+	}
+}
+
+func NewClickEvents(client *Client, cfg config.ClickEvents) *ClickEvents {
+	return &ClickEvents{
+		client:       client,
+		bufferLength: cfg.BufferLength,
+		bufferTTL:    cfg.BufferTTL,
+		// Connects with the first channel subscribed to.
+		pubsub:  client.Client.Subscribe(context.Background()),
+		viewers: make(map[string]map[*clickEventsViewer]struct{}),
+	}
+}
+
+// Publish sends a click on a link to its viewers, on every instance.
+func (e *ClickEvents) Publish(ctx context.Context, linkID string, event entity.ClickEvent) error {
+	const operation = "Redis.ClickEvents.Publish"
+
+	data, err := e.client.codec.Encode(event)
+	if err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, linkID, err)
+	}
+
+	err = e.client.write(ctx, func(ctx context.Context) error {
+		return publishClickEventScript.Run( //nolint:wrapcheck
+			ctx,
+			e.client.Client,
+			[]string{e.client.key(clickEventsStreamPrefix + linkID)},
+			e.bufferLength,
+			data,
+			e.bufferTTL.Milliseconds(),
+			e.client.key(clickEventsChannelPrefix+linkID),
+		).Err()
+	})
+	if err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, linkID, err)
+	}
+
+	return nil
+}
+
+// Subscribe returns the clicks on a link after the one with ID lastEventID,
+// still buffered, then the new ones as they happen, until ctx is done. Without
+// a valid lastEventID, only new clicks are returned.
+func (e *ClickEvents) Subscribe(ctx context.Context, linkID, lastEventID string) (<-chan entity.ClickEvent, error) {
+	const operation = "Redis.ClickEvents.Subscribe"
+
+	lastID, ok := parseStreamID(lastEventID)
+	if !ok {
+		var err error
+
+		lastID, err = e.lastID(ctx, linkID)
+		if err != nil {
+			return nil, fmt.Errorf("%s (%s) -> %w", operation, linkID, err)
+		}
+	}
+
+	channel := e.client.key(clickEventsChannelPrefix + linkID)
+
+	viewer, err := e.join(ctx, channel)
+	if err != nil {
+		return nil, fmt.Errorf("%s (%s) -> %w", operation, linkID, err)
+	}
+
+	events := make(chan entity.ClickEvent)
+
+	go func() {
+		defer close(events)
+		defer e.leave(context.WithoutCancel(ctx), channel, viewer)
+
+		e.forward(ctx, linkID, viewer, lastID, events)
+	}()
+
+	return events, nil
+}
+
+// Watch fans out the clicks received on the subscription until ctx is done.
+func (e *ClickEvents) Watch(ctx context.Context) {
+	const operation = "Redis.ClickEvents.Watch"
+
+	defer e.pubsub.Close()
+
+	messages := e.pubsub.ChannelWithSubscriptions()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			switch msg := msg.(type) {
+			case *goredis.Subscription:
+				// Clicks published while the subscription was down are lost.
+				if msg.Kind == "subscribe" {
+					e.each(msg.Channel, (*clickEventsViewer).signalMissed)
+				}
+			case *goredis.Message:
+				event, err := e.decodeMessage(msg.Payload)
+				if err != nil {
+					slog.WarnContext(ctx, fmt.Sprintf("%s (%s) -> %v", operation, msg.Channel, err))
+
+					continue
+				}
+
+				e.each(msg.Channel, func(viewer *clickEventsViewer) {
+					select {
+					case viewer.events <- event:
+					default:
+						viewer.signalMissed()
+					}
+				})
+			}
+		}
+	}
+}
+
+func (e *ClickEvents) join(ctx context.Context, channel string) (*clickEventsViewer, error) {
+	viewer := &clickEventsViewer{
+		events: make(chan entity.ClickEvent, clickEventsQueueLength),
+		missed: make(chan struct{}, 1),
+	}
+
+	// Clicks published before joining are read from the stream.
+	viewer.signalMissed()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if len(e.viewers[channel]) == 0 {
+		if err := e.pubsub.Subscribe(ctx, channel); err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+
+		e.viewers[channel] = make(map[*clickEventsViewer]struct{})
+	}
+
+	e.viewers[channel][viewer] = struct{}{}
+
+	return viewer, nil
+}
+
+func (e *ClickEvents) leave(ctx context.Context, channel string, viewer *clickEventsViewer) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	delete(e.viewers[channel], viewer)
+
+	if len(e.viewers[channel]) == 0 {
+		delete(e.viewers, channel)
+
+		_ = e.pubsub.Unsubscribe(ctx, channel)
+	}
+}
+
+func (e *ClickEvents) each(channel string, fn func(*clickEventsViewer)) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for viewer := range e.viewers[channel] {
+		fn(viewer)
+	}
+}
+
+// forward sends the events of a viewer in order, catching up from the stream
+// before anything else whenever some may have been missed. Events at or
+// before lastID were already sent.
+func (e *ClickEvents) forward(ctx context.Context, linkID string, viewer *clickEventsViewer, lastID streamID, events chan<- entity.ClickEvent) {
+	const operation = "Redis.ClickEvents.forward"
+
+	send := func(event entity.ClickEvent) bool {
+		id, ok := parseStreamID(event.ID)
+		if !ok || !lastID.less(id) {
+			return true
+		}
+
+		select {
+		case <-ctx.Done():
+			return false
+		case events <- event:
+			lastID = id
+
+			return true
+		}
+	}
+
+	catchUp := func() bool {
+		missed, err := e.since(ctx, linkID, lastID)
+		if err != nil {
+			slog.WarnContext(ctx, fmt.Sprintf("%s (%s) -> events missed: %v", operation, linkID, err))
+
+			return ctx.Err() == nil
+		}
+
+		for _, event := range missed {
+			if !send(event) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	for {
+		select {
+		case <-viewer.missed:
+			if !catchUp() {
+				return
+			}
+
+			continue
+		default:
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-viewer.missed:
+			if !catchUp() {
+				return
+			}
+		case event := <-viewer.events:
+			if !send(event) {
+				return
+			}
+		}
+	}
+}
+
+// lastID returns the ID of the last click buffered for a link, or the zero ID
+// when none is.
+func (e *ClickEvents) lastID(ctx context.Context, linkID string) (streamID, error) {
+	var messages []goredis.XMessage
+
+	err := e.client.read(ctx, func(ctx context.Context) error {
+		var err error
+
+		messages, err = e.client.Client.XRevRangeN(ctx, e.client.key(clickEventsStreamPrefix+linkID), "+", "-", 1).Result()
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil || len(messages) == 0 {
+		return streamID{}, err
+	}
+
+	id, _ := parseStreamID(messages[0].ID)
+
+	return id, nil
+}
+
+// since returns the clicks buffered for a link after lastID.
+func (e *ClickEvents) since(ctx context.Context, linkID string, lastID streamID) ([]entity.ClickEvent, error) {
+	var messages []goredis.XMessage
+
+	err := e.client.read(ctx, func(ctx context.Context) error {
+		var err error
+
+		messages, err = e.client.Client.XRange(ctx, e.client.key(clickEventsStreamPrefix+linkID), lastID.next().String(), "+").Result()
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]entity.ClickEvent, 0, len(messages))
+
+	for _, msg := range messages {
+		data, _ := msg.Values["event"].(string)
+
+		var event entity.ClickEvent
+		if err := e.client.codec.Decode([]byte(data), &event); err != nil {
+			return nil, fmt.Errorf("decode event %s: %w", msg.ID, err)
+		}
+
+		event.ID = msg.ID
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// decodeMessage decodes a published click: its stream ID, a space and the
+// encoded event.
+func (e *ClickEvents) decodeMessage(payload string) (entity.ClickEvent, error) {
+	id, data, _ := strings.Cut(payload, " ")
+
+	var event entity.ClickEvent
+	if err := e.client.codec.Decode([]byte(data), &event); err != nil {
+		return entity.ClickEvent{}, fmt.Errorf("decode event %s: %w", id, err)
+	}
+
+	event.ID = id
+
+	return event, nil
+}
+
+// streamID is the ID of a stream entry: the time it was added, in
+// milliseconds, and a sequence number within that millisecond.
+type streamID struct {
+	ms  uint64
+	seq uint64
+}
+
+func parseStreamID(s string) (streamID, bool) {
+	ms, seq, ok := strings.Cut(s, "-")
+	if !ok {
+		return streamID{}, false
+	}
+
+	var (
+		id     streamID
+		errMs  error
+		errSeq error
+	)
+
+	id.ms, errMs = strconv.ParseUint(ms, 10, 64)
+	id.seq, errSeq = strconv.ParseUint(seq, 10, 64)
+
+	return id, errMs == nil && errSeq == nil
+}
+
+func (id streamID) String() string {
+	return strconv.FormatUint(id.ms, 10) + "-" + strconv.FormatUint(id.seq, 10)
+}
+
+func (id streamID) less(other streamID) bool {
+	return id.ms < other.ms || id.ms == other.ms && id.seq < other.seq
+}
+
+// next is the smallest ID after id, as XRANGE bounds are inclusive.
+func (id streamID) next() streamID {
+	if id.seq == ^uint64(0) {
+		return streamID{ms: id.ms + 1}
+	}
+
+	return streamID{ms: id.ms, seq: id.seq + 1}
+}
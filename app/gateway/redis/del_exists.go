@@ -8,7 +8,15 @@ import (
 func (c *Client) Del(ctx context.Context, key string) (bool, error) {
 	const operation = "Redis.Del"
 
-	count, err := c.Client.Del(ctx, key).Result()
+	var count int64
+
+	err := c.write(ctx, func(ctx context.Context) error {
+		var err error
+
+		count, err = c.Client.Del(ctx, c.key(key)).Result()
+
+		return err //nolint:wrapcheck
+	})
 	if err != nil {
 		return false, fmt.Errorf("%s (%s) -> %w", operation, key, err)
 	}
@@ -19,21 +27,18 @@ func (c *Client) Del(ctx context.Context, key string) (bool, error) {
 func (c *Client) Exists(ctx context.Context, key string) (bool, error) {
 	const operation = "Redis.Exists"
 
-	count, err := c.Client.Exists(ctx, key).Result()
-	if err != nil {
-		return false, fmt.Errorf("%s (%s) -> %w", operation, key, err)
-	}
+	var count int64
 
-	return count > 0, nil
-}
+	err := c.read(ctx, func(ctx context.Context) error {
+		var err error
 
-func (c *Client) Keys(ctx context.Context, pattern string) ([]string, error) {
-	const operation = "Redis.Keys"
+		count, err = c.Client.Exists(ctx, c.key(key)).Result()
 
-	keys, err := c.Client.Keys(ctx, pattern).Result()
+		return err //nolint:wrapcheck
+	})
 	if err != nil {
-		return nil, fmt.Errorf("%s (%s) -> %w", operation, pattern, err)
+		return false, fmt.Errorf("%s (%s) -> %w", operation, key, err)
 	}
 
-	return keys, nil
+	return count > 0, nil
 }
@@ -0,0 +1,310 @@
+package redis
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/go-api-template/app/domain/erring"
+)
+
+const (
+	// XFetch beta: above 1 favors refreshing earlier.
+	earlyRefreshBeta = 1.0
+
+	// How long the lock to load a key outlives an instance that stopped
+	// extending it, e.g. because it died mid-load. It is extended every
+	// loadLockTTL/3 while the load runs, however long it takes.
+	loadLockTTL      = 5 * time.Second
+	loadLockPollWait = 50 * time.Millisecond
+)
+
+// Deletes the lock only when it is still held by its token.
+var unlockScript = goredis.NewScript(`
+	if redis.call("GET", KEYS[1]) == ARGV[1] then
+		return redis.call("DEL", KEYS[1])
+	end
+	return 0
+`)
+
+// Extends the lock only when it is still held by its token.
+var extendLockScript = goredis.NewScript(`
+	if redis.call("GET", KEYS[1]) == ARGV[1] then
+		return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	end
+	return 0
+`)
+
+// loadedEntry is what GetOrLoad keeps in the cache: the encoded value, when
+// it stops being fresh and how long it took to load, to schedule its early
+// refresh. It is stored as a version byte, both times, in nanoseconds, then
+// the value.
+type loadedEntry struct {
+	Value     []byte
+	ExpiresAt time.Time
+	Delta     time.Duration
+}
+
+const (
+	// Bumped whenever the layout of entries changes, so entries written by
+	// other versions of the service are loaded again rather than misread.
+	loadedEntryVersion byte = 1
+
+	loadedEntryHeaderLength = 17
+)
+
+func (e loadedEntry) marshal() []byte {
+	data := make([]byte, loadedEntryHeaderLength, loadedEntryHeaderLength+len(e.Value))
+	data[0] = loadedEntryVersion
+	binary.BigEndian.PutUint64(data[1:], uint64(e.ExpiresAt.UnixNano()))
+	binary.BigEndian.PutUint64(data[9:], uint64(e.Delta))
+
+	return append(data, e.Value...)
+}
+
+func (e *loadedEntry) unmarshal(data []byte) bool {
+	if len(data) <= loadedEntryHeaderLength || data[0] != loadedEntryVersion {
+		return false
+	}
+
+	e.ExpiresAt = time.Unix(0, int64(binary.BigEndian.Uint64(data[1:])))
+	e.Delta = time.Duration(binary.BigEndian.Uint64(data[9:]))
+	e.Value = data[loadedEntryHeaderLength:]
+
+	return true
+}
+
+// shouldRefresh implements XFetch: the closer the entry is to expiring and
+// the longer it takes to load, the likelier it is refreshed ahead of time, so
+// hot keys are reloaded by a single caller before they expire for everyone.
+func (e loadedEntry) shouldRefresh(now time.Time) bool {
+	early := time.Duration(float64(e.Delta) * earlyRefreshBeta * -math.Log(1-rand.Float64())) //nolint:gosec
+
+	return !now.Add(early).Before(e.ExpiresAt)
+}
+
+// rawStore reads and writes the encoded values of GetOrLoad, so layered
+// caches can serve them from memory too.
+type rawStore interface {
+	get(ctx context.Context, key string) ([]byte, error)
+	set(ctx context.Context, key string, bytes []byte, ttl time.Duration) error
+}
+
+// GetOrLoad reads key into objByRef, loading and caching it with load when
+// missing or no longer fresh. The value is fresh for ttl, and served stale for
+// Redis.StaleFor more while load fails for reasons other than a domain error.
+//
+// Concurrent loads of a key are coalesced: within an instance, and across
+// instances with a lock in Redis, while the others wait for its result.
+func (c *Client) GetOrLoad(ctx context.Context, key string, objByRef any, ttl time.Duration, load func(context.Context) (any, error)) error {
+	return c.getOrLoad(ctx, c, key, objByRef, ttl, load)
+}
+
+func (c *Client) getOrLoad(ctx context.Context, store rawStore, key string, objByRef any, ttl time.Duration, load func(context.Context) (any, error)) error {
+	const operation = "Redis.GetOrLoad"
+
+	entry, found := getEntry(ctx, store, key)
+	if found && !entry.shouldRefresh(time.Now()) {
+		return c.decodeValue(operation, key, entry.Value, objByRef)
+	}
+
+	// The load outlives callers giving up, as others may be waiting for it.
+	results := c.loads.DoChan(key, func() (any, error) {
+		return c.load(context.WithoutCancel(ctx), store, key, ttl, load, entry, found)
+	})
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("%s (%s) -> %w", operation, key, ctx.Err())
+	case res := <-results:
+		if res.Err != nil {
+			return fmt.Errorf("%s (%s) -> %w", operation, key, res.Err)
+		}
+
+		return c.decodeValue(operation, key, res.Val.([]byte), objByRef) //nolint:forcetypeassert
+	}
+}
+
+func (c *Client) load(
+	ctx context.Context,
+	store rawStore,
+	key string,
+	ttl time.Duration,
+	load func(context.Context) (any, error),
+	current loadedEntry,
+	found bool,
+) ([]byte, error) {
+	const operation = "Redis.load"
+
+	lockKey := "lock:" + key
+
+	locked, unlock := c.lock(ctx, lockKey)
+	defer unlock()
+
+	if !locked {
+		// Another instance is refreshing the value: the current one is good
+		// enough until it is done.
+		if found && time.Now().Before(current.ExpiresAt) {
+			return current.Value, nil
+		}
+
+		if entry, ok := c.waitForEntry(ctx, store, key, lockKey, current); ok {
+			return entry.Value, nil
+		}
+	}
+
+	start := time.Now()
+
+	obj, err := load(ctx)
+	if err != nil {
+		var appError erring.AppError
+		if found && !errors.As(err, &appError) {
+			slog.WarnContext(ctx, fmt.Sprintf("%s (%s) -> serving stale value: %v", operation, key, err))
+
+			return current.Value, nil
+		}
+
+		return nil, err
+	}
+
+	value, err := c.codec.Encode(obj)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	entry := loadedEntry{
+		Value:     value,
+		ExpiresAt: time.Now().Add(ttl),
+		Delta:     time.Since(start),
+	}
+
+	// Failing to cache only means loading again next time.
+	_ = store.set(ctx, key, entry.marshal(), ttl+c.staleFor)
+
+	return value, nil
+}
+
+// lock takes the load lock of a key. Errors count as taking it: when Redis
+// is failing, loading is better than waiting on it.
+func (c *Client) lock(ctx context.Context, lockKey string) (bool, func()) {
+	token := uuid.NewString()
+
+	var locked bool
+
+	err := c.write(ctx, func(ctx context.Context) error {
+		var err error
+
+		locked, err = c.Client.SetNX(ctx, c.key(lockKey), token, loadLockTTL).Result()
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return true, func() {}
+	}
+
+	if !locked {
+		return false, func() {}
+	}
+
+	done := make(chan struct{})
+	go c.keepLock(ctx, lockKey, token, done)
+
+	return true, func() {
+		close(done)
+
+		_ = c.write(ctx, func(ctx context.Context) error {
+			return unlockScript.Run(ctx, c.Client, []string{c.key(lockKey)}, token).Err() //nolint:wrapcheck
+		})
+	}
+}
+
+// keepLock extends the lock of a load until done, so other instances keep
+// waiting for loads slower than loadLockTTL instead of loading the key too.
+func (c *Client) keepLock(ctx context.Context, lockKey, token string, done <-chan struct{}) {
+	ticker := time.NewTicker(loadLockTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			_ = c.write(ctx, func(ctx context.Context) error {
+				return extendLockScript.Run(ctx, c.Client, []string{c.key(lockKey)}, token, loadLockTTL.Milliseconds()).Err() //nolint:wrapcheck
+			})
+		}
+	}
+}
+
+// waitForEntry polls key until the instance holding its lock stores a new
+// entry. It gives up once the lock is gone without one, as the load failed
+// or its instance died, or when Redis fails, so the key is loaded instead.
+func (c *Client) waitForEntry(ctx context.Context, store rawStore, key, lockKey string, current loadedEntry) (loadedEntry, bool) {
+	for {
+		select {
+		case <-ctx.Done():
+			return loadedEntry{}, false
+		case <-time.After(loadLockPollWait):
+		}
+
+		// Checked before the entry, which is stored before the lock is
+		// released, so the entry of a load that just finished isn't missed.
+		locked := c.isLocked(ctx, lockKey)
+
+		if entry, ok := getEntry(ctx, store, key); ok && entry.ExpiresAt.After(current.ExpiresAt) {
+			return entry, true
+		}
+
+		if !locked {
+			return loadedEntry{}, false
+		}
+	}
+}
+
+// isLocked reports whether the load lock of a key is held. Errors count as
+// not held, like they count as taking it.
+func (c *Client) isLocked(ctx context.Context, lockKey string) bool {
+	var exists int64
+
+	err := c.read(ctx, func(ctx context.Context) error {
+		var err error
+
+		exists, err = c.Client.Exists(ctx, c.key(lockKey)).Result()
+
+		return err //nolint:wrapcheck
+	})
+
+	return err == nil && exists > 0
+}
+
+// getEntry reads the entry of key. Misses, failures and values not written
+// by GetOrLoad all mean it has to be loaded.
+func getEntry(ctx context.Context, store rawStore, key string) (loadedEntry, bool) {
+	res, err := store.get(ctx, key)
+	if err != nil {
+		return loadedEntry{}, false
+	}
+
+	var entry loadedEntry
+	if !entry.unmarshal(res) {
+		return loadedEntry{}, false
+	}
+
+	return entry, true
+}
+
+func (c *Client) decodeValue(operation, key string, value []byte, objByRef any) error {
+	if err := c.codec.Decode(value, objByRef); err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, key, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/library/codec"
+)
+
+type memoryStore struct {
+	mu     sync.Mutex
+	values map[string][]byte
+}
+
+func (s *memoryStore) get(_ context.Context, key string) ([]byte, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	value, ok := s.values[key]
+	if !ok {
+		return nil, erring.ErrCacheKeyDoesNotExist
+	}
+
+	return value, nil
+}
+
+func (s *memoryStore) set(_ context.Context, key string, bytes []byte, _ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.values[key] = bytes
+
+	return nil
+}
+
+// newUnreachableClient returns a client whose Redis commands all fail, so the
+// load locks are always taken.
+func newUnreachableClient(t *testing.T) *Client {
+	t.Helper()
+
+	client := goredis.NewClient(&goredis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+
+	return &Client{
+		Client:   client,
+		codec:    codec.NewEncoder(codec.JSON, codec.CompressionNone, 0),
+		staleFor: time.Minute,
+	}
+}
+
+func TestLoadedEntry_ShouldRefresh(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	tests := []struct {
+		name  string
+		entry loadedEntry
+		want  bool
+	}{
+		{
+			name:  "fresh entry quick to load is kept",
+			entry: loadedEntry{ExpiresAt: now.Add(time.Hour), Delta: time.Millisecond},
+			want:  false,
+		},
+		{
+			name:  "entry without load time is kept until it expires",
+			entry: loadedEntry{ExpiresAt: now.Add(time.Nanosecond)},
+			want:  false,
+		},
+		{
+			name:  "expired entry is refreshed",
+			entry: loadedEntry{ExpiresAt: now, Delta: time.Millisecond},
+			want:  true,
+		},
+		{
+			name:  "entry slow to load is refreshed before it expires",
+			entry: loadedEntry{ExpiresAt: now.Add(time.Millisecond), Delta: time.Hour},
+			want:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, tt.entry.shouldRefresh(now))
+		})
+	}
+}
+
+func TestLoadedEntry_Unmarshal(t *testing.T) {
+	t.Parallel()
+
+	entry := loadedEntry{
+		Value:     []byte(`"value"`),
+		ExpiresAt: time.Unix(0, time.Now().UnixNano()),
+		Delta:     time.Second,
+	}
+
+	var got loadedEntry
+	require.True(t, got.unmarshal(entry.marshal()))
+	assert.Equal(t, entry, got)
+
+	// Entries of older versions are misses.
+	assert.False(t, got.unmarshal([]byte(`{"value":"IkpTT04gZW5jb2RlZCI="}`)))
+	assert.False(t, got.unmarshal(append([]byte{0}, entry.marshal()[1:]...)))
+	assert.False(t, got.unmarshal(nil))
+}
+
+func TestClient_Load(t *testing.T) {
+	t.Parallel()
+
+	staleValue, err := newUnreachableClient(t).codec.Encode("stale")
+	require.NoError(t, err)
+
+	stale := loadedEntry{Value: staleValue, ExpiresAt: time.Now().Add(-time.Second)}
+	errLoad := errors.New("database down")
+
+	tests := []struct {
+		name      string
+		load      func(context.Context) (any, error)
+		found     bool
+		want      string
+		wantErr   error
+		wantCache bool
+	}{
+		{
+			name:      "loaded value is cached",
+			load:      func(context.Context) (any, error) { return "fresh", nil },
+			found:     true,
+			want:      "fresh",
+			wantCache: true,
+		},
+		{
+			name:  "stale value is served while loads fail",
+			load:  func(context.Context) (any, error) { return nil, errLoad },
+			found: true,
+			want:  "stale",
+		},
+		{
+			name:    "failure is returned without a stale value",
+			load:    func(context.Context) (any, error) { return nil, errLoad },
+			wantErr: errLoad,
+		},
+		{
+			name:    "domain error is returned over the stale value",
+			load:    func(context.Context) (any, error) { return nil, erring.ErrLinkNotFound },
+			found:   true,
+			wantErr: erring.ErrLinkNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newUnreachableClient(t)
+			store := &memoryStore{values: map[string][]byte{}}
+
+			value, err := client.load(context.Background(), store, "key", time.Minute, tt.load, stale, tt.found)
+			require.ErrorIs(t, err, tt.wantErr)
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			var got string
+			require.NoError(t, client.codec.Decode(value, &got))
+			assert.Equal(t, tt.want, got)
+
+			entry, ok := getEntry(context.Background(), store, "key")
+			require.Equal(t, tt.wantCache, ok)
+
+			if tt.wantCache {
+				assert.Equal(t, value, entry.Value)
+			}
+		})
+	}
+}
+
+func TestClient_WaitForEntry(t *testing.T) {
+	t.Parallel()
+
+	current := loadedEntry{Value: []byte(`"old"`), ExpiresAt: time.Now()}
+	next := loadedEntry{Value: []byte(`"new"`), ExpiresAt: time.Now().Add(time.Minute)}
+
+	tests := []struct {
+		name   string
+		values map[string][]byte
+		want   loadedEntry
+		wantOK bool
+	}{
+		{
+			name:   "new entry is returned",
+			values: map[string][]byte{"key": next.marshal()},
+			want:   next,
+			wantOK: true,
+		},
+		{
+			name:   "gives up once the lock is gone without a new entry",
+			values: map[string][]byte{"key": current.marshal()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := newUnreachableClient(t)
+			store := &memoryStore{values: tt.values}
+
+			got, ok := client.waitForEntry(context.Background(), store, "key", "lock:key", current)
+			assert.Equal(t, tt.wantOK, ok)
+
+			if tt.wantOK {
+				assert.Equal(t, tt.want.Value, got.Value)
+			}
+		})
+	}
+}
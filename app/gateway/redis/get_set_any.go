@@ -2,7 +2,6 @@ package redis
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
@@ -14,16 +13,12 @@ import (
 func (c *Client) Get(ctx context.Context, key string, objByRef any) error {
 	const operation = "Redis.Get"
 
-	res, err := c.Client.Get(ctx, key).Result()
+	res, err := c.get(ctx, key)
 	if err != nil {
-		if errors.Is(err, errCacheKeyDoesNotExist) {
-			return fmt.Errorf("%s (%s) -> %w", operation, key, erring.ErrCacheKeyDoesNotExist)
-		}
-
 		return fmt.Errorf("%s (%s) -> %w", operation, key, err)
 	}
 
-	err = json.Unmarshal([]byte(res), &objByRef)
+	err = c.codec.Decode(res, objByRef)
 	if err != nil {
 		return fmt.Errorf("%s (%s) -> %w", operation, key, err)
 	}
@@ -34,15 +29,37 @@ func (c *Client) Get(ctx context.Context, key string, objByRef any) error {
 func (c *Client) Set(ctx context.Context, key string, obj any, ttl time.Duration) error {
 	const operation = "Redis.Set"
 
-	bytes, err := json.Marshal(obj)
+	bytes, err := c.codec.Encode(obj)
 	if err != nil {
 		return fmt.Errorf("%s (%s) -> %w", operation, key, err)
 	}
 
-	err = c.Client.Set(ctx, key, string(bytes), ttl).Err()
-	if err != nil {
+	if err := c.set(ctx, key, bytes, ttl); err != nil {
 		return fmt.Errorf("%s (%s) -> %w", operation, key, err)
 	}
 
 	return nil
 }
+
+func (c *Client) get(ctx context.Context, key string) ([]byte, error) {
+	var res []byte
+
+	err := c.read(ctx, func(ctx context.Context) error {
+		var err error
+
+		res, err = c.Client.Get(ctx, c.key(key)).Bytes()
+		if errors.Is(err, errCacheKeyDoesNotExist) {
+			return erring.ErrCacheKeyDoesNotExist
+		}
+
+		return err //nolint:wrapcheck
+	})
+
+	return res, err
+}
+
+func (c *Client) set(ctx context.Context, key string, bytes []byte, ttl time.Duration) error {
+	return c.write(ctx, func(ctx context.Context) error {
+		return c.Client.Set(ctx, c.key(key), bytes, ttl).Err() //nolint:wrapcheck
+	})
+}
@@ -17,12 +17,19 @@ import (
 func (c *Client) GetResp(ctx context.Context, key string) (*http.Response, error) {
 	const operation = "Redis.GetResp"
 
-	res, err := c.Client.Get(ctx, key).Result()
-	if err != nil {
+	var res string
+
+	err := c.read(ctx, func(ctx context.Context) error {
+		var err error
+
+		res, err = c.Client.Get(ctx, c.key(key)).Result()
 		if errors.Is(err, errCacheKeyDoesNotExist) {
-			return nil, fmt.Errorf("%s (%s) -> %w", operation, key, erring.ErrCacheKeyDoesNotExist)
+			return erring.ErrCacheKeyDoesNotExist
 		}
 
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
 		return nil, fmt.Errorf("%s (%s) -> %w", operation, key, err)
 	}
 
@@ -42,7 +49,9 @@ func (c *Client) SetResp(ctx context.Context, key string, resp *http.Response, t
 		return fmt.Errorf("%s (%s) -> %w", operation, key, err)
 	}
 
-	err = c.Client.Set(ctx, key, string(bytes), ttl).Err()
+	err = c.write(ctx, func(ctx context.Context) error {
+		return c.Client.Set(ctx, c.key(key), string(bytes), ttl).Err() //nolint:wrapcheck
+	})
 	if err != nil {
 		return fmt.Errorf("%s (%s) -> %w", operation, key, err)
 	}
@@ -0,0 +1,40 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Increments the counter and sets its TTL when it has none, in a single
+// step, so a counter is never left without one.
+var incrScript = goredis.NewScript(`
+	local count = redis.call("INCR", KEYS[1])
+	if redis.call("PTTL", KEYS[1]) < 0 then
+		redis.call("PEXPIRE", KEYS[1], ARGV[1])
+	end
+	return count
+`)
+
+// Incr increments the counter at key and returns its new value. The ttl is
+// only set when the counter is created, so it works as a fixed window.
+func (c *Client) Incr(ctx context.Context, key string, ttl time.Duration) (int64, error) {
+	const operation = "Redis.Incr"
+
+	var count int64
+
+	err := c.write(ctx, func(ctx context.Context) error {
+		var err error
+
+		count, err = incrScript.Run(ctx, c.Client, []string{c.key(key)}, ttl.Milliseconds()).Int64()
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s (%s) -> %w", operation, key, err)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,321 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/library/lru"
+)
+
+// Invalidation of every key, as no key is empty.
+const purgeAll = ""
+
+// PTTL reply for keys without an expiry.
+const noExpiry time.Duration = -1
+
+// LayeredCache is a Client keeping the hottest keys in memory too, so
+// serving them doesn't round-trip to Redis. Keys changed or deleted through
+// any instance are dropped from the memory of all others over pub/sub.
+type LayeredCache struct {
+	*Client
+
+	// Nil when disabled.
+	local      *lru.Cache
+	prefixes   []string
+	ttl        time.Duration
+	channel    string
+	instanceID string
+
+	// Bumped whenever keys are dropped from memory, so values read from
+	// Redis meanwhile, which may predate the change, aren't kept.
+	mu         sync.Mutex
+	generation uint64
+}
+
+func NewLayeredCache(client *Client, cfg config.LocalCache) *LayeredCache {
+	cache := &LayeredCache{
+		Client:     client,
+		prefixes:   cfg.Prefixes,
+		ttl:        cfg.TTL,
+		channel:    cfg.InvalidationChannel,
+		instanceID: uuid.NewString(),
+	}
+
+	if cfg.MaxBytes > 0 && cfg.TTL > 0 && len(cfg.Prefixes) > 0 {
+		cache.local = lru.New(cfg.MaxBytes)
+	}
+
+	return cache
+}
+
+func (c *LayeredCache) Get(ctx context.Context, key string, objByRef any) error {
+	const operation = "Redis.LayeredCache.Get"
+
+	res, err := c.get(ctx, key)
+	if err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, key, err)
+	}
+
+	if err := c.codec.Decode(res, objByRef); err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, key, err)
+	}
+
+	return nil
+}
+
+func (c *LayeredCache) Set(ctx context.Context, key string, obj any, ttl time.Duration) error {
+	const operation = "Redis.LayeredCache.Set"
+
+	bytes, err := c.codec.Encode(obj)
+	if err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, key, err)
+	}
+
+	if err := c.set(ctx, key, bytes, ttl); err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, key, err)
+	}
+
+	return nil
+}
+
+// GetOrLoad is Client.GetOrLoad, also keeping the loaded values in memory.
+func (c *LayeredCache) GetOrLoad(ctx context.Context, key string, objByRef any, ttl time.Duration, load func(context.Context) (any, error)) error {
+	return c.Client.getOrLoad(ctx, c, key, objByRef, ttl, load)
+}
+
+func (c *LayeredCache) Del(ctx context.Context, key string) (bool, error) {
+	if !c.isLocal(key) {
+		return c.Client.Del(ctx, key)
+	}
+
+	// Dropped even if Redis fails, so at least this instance sees the change.
+	c.drop(key)
+
+	deleted, err := c.Client.Del(ctx, key)
+	if err != nil {
+		return false, err
+	}
+
+	c.invalidate(ctx, key)
+
+	return deleted, nil
+}
+
+// MSet is Client.MSet, also dropping the keys from memory everywhere.
+func (c *LayeredCache) MSet(ctx context.Context, objs map[string]any, ttl time.Duration) error {
+	err := c.Client.MSet(ctx, objs, ttl)
+
+	for key := range objs {
+		if c.isLocal(key) {
+			c.drop(key)
+
+			if err == nil {
+				c.invalidate(ctx, key)
+			}
+		}
+	}
+
+	return err
+}
+
+// DelPattern is Client.DelPattern. Patterns aren't matched in memory, so
+// everything is dropped from memory everywhere instead.
+func (c *LayeredCache) DelPattern(ctx context.Context, pattern string) (int64, error) {
+	deleted, err := c.Client.DelPattern(ctx, pattern)
+
+	if c.local != nil {
+		c.purge()
+		c.invalidate(ctx, purgeAll)
+	}
+
+	return deleted, err
+}
+
+// Watch drops from memory the keys changed by other instances until ctx is
+// done. Everything is dropped whenever the subscription is made again, as
+// messages sent while it was down are lost.
+func (c *LayeredCache) Watch(ctx context.Context) {
+	const operation = "Redis.LayeredCache.Watch"
+
+	if c.local == nil {
+		return
+	}
+
+	pubsub := c.Client.Client.Subscribe(ctx, c.key(c.channel))
+	defer pubsub.Close()
+
+	messages := pubsub.ChannelWithSubscriptions()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			switch msg := msg.(type) {
+			case *goredis.Subscription:
+				c.purge()
+
+				slog.InfoContext(ctx, fmt.Sprintf("%s -> subscribed to %s", operation, msg.Channel))
+			case *goredis.Message:
+				instanceID, key, ok := strings.Cut(msg.Payload, " ")
+
+				switch {
+				case !ok || instanceID == c.instanceID:
+				case key == purgeAll:
+					c.purge()
+				default:
+					c.drop(key)
+				}
+			}
+		}
+	}
+}
+
+func (c *LayeredCache) get(ctx context.Context, key string) ([]byte, error) {
+	if !c.isLocal(key) {
+		return c.Client.get(ctx, key)
+	}
+
+	if res, ok := c.local.Get(key); ok {
+		return res, nil
+	}
+
+	generation := c.currentGeneration()
+
+	res, remaining, err := c.getWithTTL(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Never kept in memory past its expiry in Redis.
+	ttl := c.ttl
+	if remaining != noExpiry {
+		ttl = min(ttl, remaining)
+	}
+
+	c.fill(generation, key, res, ttl)
+
+	return res, nil
+}
+
+// getWithTTL is Client.get, also returning the time key has left in Redis.
+func (c *LayeredCache) getWithTTL(ctx context.Context, key string) ([]byte, time.Duration, error) {
+	var (
+		get *goredis.StringCmd
+		ttl *goredis.DurationCmd
+	)
+
+	err := c.read(ctx, func(ctx context.Context) error {
+		_, err := c.Client.Client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+			get = pipe.Get(ctx, c.key(key))
+			ttl = pipe.PTTL(ctx, c.key(key))
+
+			return nil
+		})
+		if errors.Is(err, errCacheKeyDoesNotExist) {
+			return erring.ErrCacheKeyDoesNotExist
+		}
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	res, err := get.Bytes()
+	if err != nil {
+		return nil, 0, err //nolint:wrapcheck
+	}
+
+	return res, ttl.Val(), nil
+}
+
+func (c *LayeredCache) set(ctx context.Context, key string, bytes []byte, ttl time.Duration) error {
+	if !c.isLocal(key) {
+		return c.Client.set(ctx, key, bytes, ttl)
+	}
+
+	c.drop(key)
+
+	generation := c.currentGeneration()
+
+	if err := c.Client.set(ctx, key, bytes, ttl); err != nil {
+		return err
+	}
+
+	c.fill(generation, key, bytes, min(ttl, c.ttl))
+	c.invalidate(ctx, key)
+
+	return nil
+}
+
+func (c *LayeredCache) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.generation
+}
+
+// fill keeps value in memory, unless keys were dropped since generation.
+func (c *LayeredCache) fill(generation uint64, key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.generation == generation {
+		c.local.Set(key, value, ttl)
+	}
+}
+
+func (c *LayeredCache) drop(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.local.Delete(key)
+}
+
+func (c *LayeredCache) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.generation++
+	c.local.Purge()
+}
+
+func (c *LayeredCache) isLocal(key string) bool {
+	if c.local == nil {
+		return false
+	}
+
+	for _, prefix := range c.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// invalidate tells the other instances key changed. A lost message only
+// leaves them serving the old value until the local TTL.
+func (c *LayeredCache) invalidate(ctx context.Context, key string) {
+	const operation = "Redis.LayeredCache.invalidate"
+
+	if err := c.Publish(ctx, c.channel, c.instanceID+" "+key); err != nil {
+		slog.WarnContext(ctx, fmt.Sprintf("%s -> %v", operation, err))
+	}
+}
@@ -0,0 +1,104 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// MGet reads keys in a single pipelined round trip and returns the values
+// found, by key. Missing keys are left out. Unlike the MGET command, keys
+// may belong to different Cluster slots.
+func MGet[T any](ctx context.Context, c *Client, keys []string) (map[string]T, error) {
+	const operation = "Redis.MGet"
+
+	if len(keys) == 0 {
+		return map[string]T{}, nil
+	}
+
+	cmds := make([]*goredis.StringCmd, len(keys))
+
+	err := c.read(ctx, func(ctx context.Context) error {
+		_, err := c.Client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+			for i, key := range keys {
+				cmds[i] = pipe.Get(ctx, c.key(key))
+			}
+
+			return nil
+		})
+		if err == nil {
+			return nil
+		}
+
+		// Missing keys fail their GET without failing the others.
+		for _, cmd := range cmds {
+			if err := cmd.Err(); err != nil && !errors.Is(err, errCacheKeyDoesNotExist) {
+				return err //nolint:wrapcheck
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	values := make(map[string]T, len(keys))
+
+	for i, cmd := range cmds {
+		res, err := cmd.Bytes()
+		if err != nil {
+			continue
+		}
+
+		var value T
+		if err := c.codec.Decode(res, &value); err != nil {
+			return nil, fmt.Errorf("%s (%s) -> %w", operation, keys[i], err)
+		}
+
+		values[keys[i]] = value
+	}
+
+	return values, nil
+}
+
+// MSet writes objs, by key, in a single pipelined round trip. Unlike the MSET
+// command, every key gets ttl.
+func (c *Client) MSet(ctx context.Context, objs map[string]any, ttl time.Duration) error {
+	const operation = "Redis.MSet"
+
+	if len(objs) == 0 {
+		return nil
+	}
+
+	values := make(map[string][]byte, len(objs))
+
+	for key, obj := range objs {
+		bytes, err := c.codec.Encode(obj)
+		if err != nil {
+			return fmt.Errorf("%s (%s) -> %w", operation, key, err)
+		}
+
+		values[key] = bytes
+	}
+
+	err := c.write(ctx, func(ctx context.Context) error {
+		_, err := c.Client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+			for key, bytes := range values {
+				pipe.Set(ctx, c.key(key), bytes, ttl)
+			}
+
+			return nil
+		})
+
+		return err //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
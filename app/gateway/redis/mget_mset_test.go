@@ -0,0 +1,55 @@
+package redis
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_MSet_MGet(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cluster bool
+	}{
+		{name: "standalone"},
+		{name: "cluster", cluster: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, server := newMiniredisClient(t, tt.cluster)
+
+			// Keys of different Cluster slots.
+			err := client.MSet(context.Background(), map[string]any{"links:a": 1, "users:b": 2}, time.Minute)
+			require.NoError(t, err)
+
+			for _, key := range []string{"links:a", "users:b"} {
+				assert.True(t, server.Exists(testNamespace+key), "keys are namespaced")
+				assert.Equal(t, time.Minute, server.TTL(testNamespace+key), "every key gets the ttl")
+			}
+
+			got, err := MGet[int](context.Background(), client, []string{"links:a", "users:b", "missing"})
+			require.NoError(t, err)
+			assert.Equal(t, map[string]int{"links:a": 1, "users:b": 2}, got)
+		})
+	}
+}
+
+func TestMGet_Empty(t *testing.T) {
+	t.Parallel()
+
+	client, _ := newMiniredisClient(t, false)
+
+	got, err := MGet[int](context.Background(), client, nil)
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	require.NoError(t, client.MSet(context.Background(), nil, time.Minute))
+}
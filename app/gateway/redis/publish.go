@@ -0,0 +1,20 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+)
+
+// Publish sends message to the subscribers of channel.
+func (c *Client) Publish(ctx context.Context, channel, message string) error {
+	const operation = "Redis.Publish"
+
+	err := c.write(ctx, func(ctx context.Context) error {
+		return c.Client.Publish(ctx, c.key(channel), message).Err() //nolint:wrapcheck
+	})
+	if err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, channel, err)
+	}
+
+	return nil
+}
@@ -3,50 +3,75 @@ package redis
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
+	"time"
 
+	"github.com/cep21/circuit/v4"
 	goredisotel "github.com/redis/go-redis/extra/redisotel/v9"
 	goredis "github.com/redis/go-redis/v9"
 	"go.opentelemetry.io/otel"
 	semconv "go.opentelemetry.io/otel/semconv/v1.20.0"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/library/circuitbreaker"
+	"github.com/go-api-template/app/library/codec"
 )
 
 const errCacheKeyDoesNotExist = goredis.Nil
 
+var errInvalidConfig = errors.New("invalid redis config")
+
+const (
+	readCircuitName  = "redis-read"
+	writeCircuitName = "redis-write"
+)
+
 type Client struct {
-	Client *goredis.Client
+	// A *goredis.Client, *goredis.ClusterClient or, with Sentinel, a
+	// failover *goredis.Client.
+	Client goredis.UniversalClient
+
+	readCircuit  *circuit.Circuit
+	writeCircuit *circuit.Circuit
+
+	// Prepended to every key and channel, so services and environments can
+	// share a Redis instance.
+	namespace string
+
+	codec    *codec.Encoder
+	loads    singleflight.Group
+	staleFor time.Duration
 }
 
 func (c *Client) Close() error {
 	return c.Client.Close() //nolint:wrapcheck
 }
 
-func New(ctx context.Context, cfg config.Redis) (*Client, error) {
+// New connects to Redis, standalone, through Sentinel or to a Cluster
+// depending on cfg.Mode. Commands are protected by one circuit per operation
+// class (reads and writes) registered on circuitManager. Keys are stored
+// under namespace, transparently to callers.
+func New(ctx context.Context, cfg config.Redis, namespace string, circuitManager *circuit.Manager) (*Client, error) {
 	const operation = "Redis.New"
 
-	opts := &goredis.Options{
-		Addr:     cfg.Address(),
-		Username: cfg.User,
-		Password: cfg.Password,
+	client, err := newUniversalClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
 	}
 
-	if cfg.UseTLS {
-		opts.TLSConfig = &tls.Config{
-			ServerName: cfg.Host,
-			MinVersion: tls.VersionTLS12,
-		}
+	// Sentinel and Cluster nodes are only known once connected; their
+	// addresses are in the span of each command.
+	attrs := goredisotel.WithAttributes()
+	if cfg.Mode == config.RedisModeStandalone {
+		attrs = goredisotel.WithAttributes(
+			semconv.NetPeerNameKey.String(cfg.Host),
+			semconv.NetPeerPortKey.String(cfg.Port),
+		)
 	}
 
-	client := goredis.NewClient(opts)
-
-	attrs := goredisotel.WithAttributes(
-		semconv.NetPeerNameKey.String(cfg.Host),
-		semconv.NetPeerPortKey.String(cfg.Port),
-	)
-
-	err := goredisotel.InstrumentTracing(
+	err = goredisotel.InstrumentTracing(
 		client,
 		attrs,
 		goredisotel.WithTracerProvider(otel.GetTracerProvider()),
@@ -70,7 +95,81 @@ func New(ctx context.Context, cfg config.Redis) (*Client, error) {
 		return nil, fmt.Errorf("%s -> %w", operation, err)
 	}
 
+	valueCodec, err := codec.ParseCodec(cfg.Codec)
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	compression, err := codec.ParseCompression(cfg.Compression)
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	readCircuit, err := circuitbreaker.GetOrCreate(circuitManager, readCircuitName)
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	writeCircuit, err := circuitbreaker.GetOrCreate(circuitManager, writeCircuitName)
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
 	return &Client{
-		Client: client,
+		Client:       client,
+		readCircuit:  readCircuit,
+		writeCircuit: writeCircuit,
+		namespace:    namespace,
+		codec:        codec.NewEncoder(valueCodec, compression, cfg.CompressionThreshold),
+		staleFor:     cfg.StaleFor,
 	}, nil
 }
+
+func newUniversalClient(cfg config.Redis) (goredis.UniversalClient, error) {
+	opts := &goredis.UniversalOptions{
+		Addrs:            cfg.Addrs,
+		Username:         cfg.User,
+		Password:         cfg.Password,
+		MasterName:       cfg.MasterName,
+		SentinelPassword: cfg.SentinelPassword,
+	}
+
+	if cfg.UseTLS {
+		opts.TLSConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+		}
+	}
+
+	switch cfg.Mode {
+	case config.RedisModeStandalone:
+		if cfg.Host == "" {
+			return nil, fmt.Errorf("%w: standalone mode needs REDIS_ADDR", errInvalidConfig)
+		}
+
+		opts.Addrs = []string{cfg.Address()}
+
+		if opts.TLSConfig != nil {
+			opts.TLSConfig.ServerName = cfg.Host
+		}
+
+		return goredis.NewClient(opts.Simple()), nil
+	case config.RedisModeSentinel:
+		if cfg.MasterName == "" || len(cfg.Addrs) == 0 {
+			return nil, fmt.Errorf("%w: sentinel mode needs REDIS_MASTER_NAME and REDIS_ADDRS", errInvalidConfig)
+		}
+
+		return goredis.NewFailoverClient(opts.Failover()), nil
+	case config.RedisModeCluster:
+		if len(cfg.Addrs) == 0 {
+			return nil, fmt.Errorf("%w: cluster mode needs REDIS_ADDRS", errInvalidConfig)
+		}
+
+		return goredis.NewClusterClient(opts.Cluster()), nil
+	}
+
+	return nil, fmt.Errorf("%w: unknown mode %q", errInvalidConfig, cfg.Mode)
+}
+
+func (c *Client) key(key string) string {
+	return c.namespace + key
+}
@@ -0,0 +1,163 @@
+package redis
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/library/circuitbreaker"
+)
+
+func TestNewUniversalClient(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		cfg         config.Redis
+		wantErr     error
+		wantCluster bool
+		wantAddr    string
+	}{
+		{
+			name:     "standalone connects to the server",
+			cfg:      config.Redis{Mode: config.RedisModeStandalone, Host: "redis", Port: "6379"},
+			wantAddr: "redis:6379",
+		},
+		{
+			name:    "standalone needs an address",
+			cfg:     config.Redis{Mode: config.RedisModeStandalone, Port: "6379"},
+			wantErr: errInvalidConfig,
+		},
+		{
+			name: "sentinel connects to the master through the sentinels",
+			cfg: config.Redis{
+				Mode:       config.RedisModeSentinel,
+				Addrs:      []string{"sentinel-1:26379", "sentinel-2:26379"},
+				MasterName: "main",
+			},
+			wantAddr: "FailoverClient",
+		},
+		{
+			name:    "sentinel needs a master name",
+			cfg:     config.Redis{Mode: config.RedisModeSentinel, Addrs: []string{"sentinel-1:26379"}},
+			wantErr: errInvalidConfig,
+		},
+		{
+			name:    "sentinel needs sentinel addresses",
+			cfg:     config.Redis{Mode: config.RedisModeSentinel, MasterName: "main"},
+			wantErr: errInvalidConfig,
+		},
+		{
+			name:        "cluster connects to the seed nodes",
+			cfg:         config.Redis{Mode: config.RedisModeCluster, Addrs: []string{"node-1:6379", "node-2:6379"}},
+			wantCluster: true,
+		},
+		{
+			name:    "cluster needs seed nodes",
+			cfg:     config.Redis{Mode: config.RedisModeCluster},
+			wantErr: errInvalidConfig,
+		},
+		{
+			name:    "unknown mode",
+			cfg:     config.Redis{Mode: "replicated", Host: "redis"},
+			wantErr: errInvalidConfig,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, err := newUniversalClient(tt.cfg)
+			require.ErrorIs(t, err, tt.wantErr)
+
+			if tt.wantErr != nil {
+				return
+			}
+
+			t.Cleanup(func() { _ = client.Close() })
+
+			if tt.wantCluster {
+				cluster, ok := client.(*goredis.ClusterClient)
+				require.True(t, ok, "got %T", client)
+				assert.Equal(t, tt.cfg.Addrs, cluster.Options().Addrs)
+
+				return
+			}
+
+			single, ok := client.(*goredis.Client)
+			require.True(t, ok, "got %T", client)
+			assert.Equal(t, tt.wantAddr, single.Options().Addr)
+		})
+	}
+}
+
+func TestNewUniversalClient_TLS(t *testing.T) {
+	t.Parallel()
+
+	client, err := newUniversalClient(config.Redis{
+		Mode:   config.RedisModeStandalone,
+		Host:   "redis",
+		Port:   "6379",
+		UseTLS: true,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = client.Close() })
+
+	single, ok := client.(*goredis.Client)
+	require.True(t, ok, "got %T", client)
+	require.NotNil(t, single.Options().TLSConfig)
+	assert.Equal(t, "redis", single.Options().TLSConfig.ServerName)
+}
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		mode config.RedisMode
+	}{
+		{name: "standalone", mode: config.RedisModeStandalone},
+		{name: "cluster", mode: config.RedisModeCluster},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			server := miniredis.RunT(t)
+
+			host, port, err := net.SplitHostPort(server.Addr())
+			require.NoError(t, err)
+
+			cfg := config.Redis{
+				Mode:        tt.mode,
+				Codec:       "json",
+				Compression: "none",
+			}
+			if tt.mode == config.RedisModeCluster {
+				cfg.Addrs = []string{server.Addr()}
+			} else {
+				cfg.Host, cfg.Port = host, port
+			}
+
+			manager := circuitbreaker.NewManager(config.CircuitBreaker{MaxConcurrentRequests: 10})
+
+			client, err := New(context.Background(), cfg, testNamespace, manager)
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = client.Close() })
+
+			_, isCluster := client.Client.(*goredis.ClusterClient)
+			assert.Equal(t, tt.mode == config.RedisModeCluster, isCluster)
+
+			require.NoError(t, client.MSet(context.Background(), map[string]any{"key": "value"}, 0))
+			assert.True(t, server.Exists(testNamespace+"key"))
+		})
+	}
+}
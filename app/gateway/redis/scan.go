@@ -0,0 +1,120 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// Keys asked for on each SCAN round trip. SCAN may return more or fewer.
+const scanCount = 1000
+
+// ScanKeys calls fn for every key matching pattern, a glob as in KEYS.
+// Unlike KEYS, it doesn't block Redis on large keyspaces: keys are fetched in
+// batches, each in its own call to the read circuit. Keys added or removed
+// while scanning may be missed, and a key may be passed more than once.
+func (c *Client) ScanKeys(ctx context.Context, pattern string, fn func(key string) error) error {
+	const operation = "Redis.ScanKeys"
+
+	err := c.scan(ctx, pattern, func(keys []string) error {
+		for _, key := range keys {
+			if err := fn(strings.TrimPrefix(key, c.namespace)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("%s (%s) -> %w", operation, pattern, err)
+	}
+
+	return nil
+}
+
+// DelPattern deletes every key matching pattern, returning how many were
+// deleted. Keys are deleted batch by batch as they are scanned, one DEL per
+// key, as keys of a batch may belong to different Cluster slots.
+func (c *Client) DelPattern(ctx context.Context, pattern string) (int64, error) {
+	const operation = "Redis.DelPattern"
+
+	var deleted int64
+
+	err := c.scan(ctx, pattern, func(keys []string) error {
+		return c.write(ctx, func(ctx context.Context) error {
+			cmds, err := c.Client.Pipelined(ctx, func(pipe goredis.Pipeliner) error {
+				for _, key := range keys {
+					pipe.Del(ctx, key)
+				}
+
+				return nil
+			})
+
+			for _, cmd := range cmds {
+				if del, ok := cmd.(*goredis.IntCmd); ok {
+					deleted += del.Val()
+				}
+			}
+
+			return err //nolint:wrapcheck
+		})
+	})
+	if err != nil {
+		return deleted, fmt.Errorf("%s (%s) -> %w", operation, pattern, err)
+	}
+
+	return deleted, nil
+}
+
+// scan calls fn with each batch of namespaced keys matching pattern. In a
+// Cluster, every master is scanned, concurrently, but fn is never called
+// concurrently.
+func (c *Client) scan(ctx context.Context, pattern string, fn func(keys []string) error) error {
+	cluster, ok := c.Client.(*goredis.ClusterClient)
+	if !ok {
+		return c.scanNode(ctx, c.Client, pattern, fn)
+	}
+
+	var mu sync.Mutex
+
+	return cluster.ForEachMaster(ctx, func(ctx context.Context, node *goredis.Client) error { //nolint:wrapcheck
+		return c.scanNode(ctx, node, pattern, func(keys []string) error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			return fn(keys)
+		})
+	})
+}
+
+func (c *Client) scanNode(ctx context.Context, node goredis.Cmdable, pattern string, fn func(keys []string) error) error {
+	var cursor uint64
+
+	for {
+		var keys []string
+
+		err := c.read(ctx, func(ctx context.Context) error {
+			var err error
+
+			keys, cursor, err = node.Scan(ctx, cursor, c.key(pattern), scanCount).Result()
+
+			return err //nolint:wrapcheck
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(keys) > 0 {
+			if err := fn(keys); err != nil {
+				return err
+			}
+		}
+
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
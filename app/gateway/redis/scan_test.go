@@ -0,0 +1,117 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-api-template/app/library/codec"
+)
+
+const testNamespace = "test:"
+
+// newMiniredisClient returns a client of a miniredis server, talking to it
+// as a Cluster of a single master when cluster is set.
+func newMiniredisClient(t *testing.T, cluster bool) (*Client, *miniredis.Miniredis) {
+	t.Helper()
+
+	server := miniredis.RunT(t)
+
+	var client goredis.UniversalClient = goredis.NewClient(&goredis.Options{Addr: server.Addr()})
+	if cluster {
+		client = goredis.NewClusterClient(&goredis.ClusterOptions{Addrs: []string{server.Addr()}})
+	}
+
+	t.Cleanup(func() { _ = client.Close() })
+
+	return &Client{
+		Client:    client,
+		namespace: testNamespace,
+		codec:     codec.NewEncoder(codec.JSON, codec.CompressionNone, 0),
+	}, server
+}
+
+func TestClient_ScanKeys(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cluster bool
+	}{
+		{name: "standalone"},
+		{name: "cluster", cluster: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, server := newMiniredisClient(t, tt.cluster)
+
+			// More keys than a SCAN round trip returns.
+			var want []string
+
+			for i := range 2 * scanCount {
+				key := fmt.Sprintf("links:%d", i)
+				want = append(want, key)
+				require.NoError(t, server.Set(testNamespace+key, "1"))
+			}
+
+			require.NoError(t, server.Set(testNamespace+"users:1", "1"))
+			require.NoError(t, server.Set("other:links:1", "1"))
+
+			var got []string
+
+			err := client.ScanKeys(context.Background(), "links:*", func(key string) error {
+				got = append(got, key)
+
+				return nil
+			})
+			require.NoError(t, err)
+
+			sort.Strings(got)
+			sort.Strings(want)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestClient_DelPattern(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		cluster bool
+	}{
+		{name: "standalone"},
+		{name: "cluster", cluster: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			client, server := newMiniredisClient(t, tt.cluster)
+
+			require.NoError(t, server.Set(testNamespace+"links:1", "1"))
+			require.NoError(t, server.Set(testNamespace+"links:2", "1"))
+			require.NoError(t, server.Set(testNamespace+"users:1", "1"))
+			require.NoError(t, server.Set("other:links:1", "1"))
+
+			deleted, err := client.DelPattern(context.Background(), "links:*")
+			require.NoError(t, err)
+			assert.Equal(t, int64(2), deleted)
+
+			assert.False(t, server.Exists(testNamespace+"links:1"))
+			assert.False(t, server.Exists(testNamespace+"links:2"))
+			assert.True(t, server.Exists(testNamespace+"users:1"))
+			assert.True(t, server.Exists("other:links:1"), "keys of other namespaces are kept")
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package twilio
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" //nolint:gosec
+	"encoding/base64"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// SignatureHeader carries the signature of the requests made by Twilio.
+const SignatureHeader = "X-Twilio-Signature"
+
+// Signature computes the X-Twilio-Signature of a request to rawURL with the
+// given form params: the base64 HMAC-SHA1, keyed by the auth token, of the
+// URL followed by every param name and value sorted by name.
+// https://www.twilio.com/docs/usage/security#validating-requests
+func Signature(authToken, rawURL string, params url.Values) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	var data strings.Builder
+
+	data.WriteString(rawURL)
+
+	for _, key := range keys {
+		for _, value := range params[key] {
+			data.WriteString(key)
+			data.WriteString(value)
+		}
+	}
+
+	mac := hmac.New(sha1.New, []byte(authToken))
+	mac.Write([]byte(data.String()))
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateSignature reports whether signature was made with authToken for a
+// request to rawURL with the given form params.
+func ValidateSignature(authToken, rawURL string, params url.Values, signature string) bool {
+	if authToken == "" || signature == "" {
+		return false
+	}
+
+	return hmac.Equal([]byte(Signature(authToken, rawURL, params)), []byte(signature))
+}
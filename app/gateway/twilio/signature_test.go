@@ -0,0 +1,36 @@
+package twilio
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSignature(t *testing.T) {
+	t.Parallel()
+
+	// Example from Twilio's security documentation.
+	const (
+		authToken = "12345"
+		rawURL    = "https://mycompany.com/myapp.php?foo=1&bar=2"
+		signature = "0/KCTR6DLpKmkAf8muzZqo1nDgQ="
+	)
+
+	params := url.Values{
+		"CallSid": {"CA1234567890ABCDE"},
+		"Caller":  {"+12349013030"},
+		"Digits":  {"1234"},
+		"From":    {"+12349013030"},
+		"To":      {"+18005551212"},
+	}
+
+	assert.Equal(t, signature, Signature(authToken, rawURL, params))
+	assert.True(t, ValidateSignature(authToken, rawURL, params, signature))
+
+	assert.False(t, ValidateSignature("54321", rawURL, params, signature))
+	assert.False(t, ValidateSignature(authToken, "https://mycompany.com/myapp.php?foo=1", params, signature))
+	assert.False(t, ValidateSignature(authToken, rawURL, url.Values{"Digits": {"1234"}}, signature))
+	assert.False(t, ValidateSignature(authToken, rawURL, params, ""))
+	assert.False(t, ValidateSignature("", rawURL, params, signature))
+}
@@ -0,0 +1,158 @@
+package twilio
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/cep21/circuit/v4"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/library/circuitbreaker"
+	"github.com/go-api-template/app/telemetry"
+)
+
+const circuitName = "twilio"
+
+// Client sends templated messages through the Twilio Messaging API.
+type Client struct {
+	cfg        config.Twilio
+	httpClient *http.Client
+	circuit    *circuit.Circuit
+}
+
+func New(cfg config.Twilio, circuitManager *circuit.Manager) (*Client, error) {
+	const operation = "Twilio.New"
+
+	circ, err := circuitbreaker.GetOrCreate(circuitManager, circuitName)
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return &Client{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: cfg.Timeout},
+		circuit:    circ,
+	}, nil
+}
+
+type sendResponse struct {
+	SID    string `json:"sid"`
+	Status string `json:"status"`
+}
+
+type errorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Send sends message with its content template and returns the message SID.
+// Messages refused by Twilio (invalid number, unapproved template...) fail
+// with erring.ErrMessageRejected.
+func (c *Client) Send(ctx context.Context, message entity.Message) (string, error) {
+	const operation = "Twilio.Send"
+
+	ctx, span := telemetry.StartClientSpan(ctx, "twilio.send")
+	defer span.End()
+
+	form, err := c.sendForm(message)
+	if err != nil {
+		return "", fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", strings.TrimSuffix(c.cfg.BaseURL, "/"), url.PathEscape(c.cfg.AccountSID))
+
+	var sent sendResponse
+
+	err = circuitbreaker.Execute(ctx, c.circuit, func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		req.SetBasicAuth(c.cfg.AccountSID, c.cfg.AuthToken)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+		defer resp.Body.Close()
+
+		body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		if err != nil {
+			return err //nolint:wrapcheck
+		}
+
+		if resp.StatusCode >= http.StatusBadRequest {
+			var apiErr errorResponse
+			_ = json.Unmarshal(body, &apiErr)
+
+			err := fmt.Errorf("status %d, code %d: %s", resp.StatusCode, apiErr.Code, apiErr.Message)
+
+			// Authentication and rate limit errors are on our side of the
+			// integration, not the message's.
+			if resp.StatusCode < http.StatusInternalServerError &&
+				resp.StatusCode != http.StatusUnauthorized && resp.StatusCode != http.StatusTooManyRequests {
+				return errors.Join(erring.ErrMessageRejected, err)
+			}
+
+			return err
+		}
+
+		return json.Unmarshal(body, &sent) //nolint:wrapcheck
+	}, isExpectedError)
+	if err != nil {
+		span.RecordError(err)
+
+		return "", fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	span.SetAttributes(attribute.String("twilio.message_sid", sent.SID))
+
+	return sent.SID, nil
+}
+
+func (c *Client) sendForm(message entity.Message) (url.Values, error) {
+	variables, err := json.Marshal(message.Variables)
+	if err != nil {
+		return nil, fmt.Errorf("content variables: %w", err)
+	}
+
+	form := url.Values{
+		"ContentSid":       {string(message.Template)},
+		"ContentVariables": {string(variables)},
+	}
+
+	switch message.Channel {
+	case entity.MessageChannelWhatsApp:
+		form.Set("From", "whatsapp:"+c.cfg.WhatsAppFrom)
+		form.Set("To", "whatsapp:"+message.To)
+	case entity.MessageChannelSMS:
+		form.Set("From", c.cfg.SMSFrom)
+		form.Set("To", message.To)
+	default:
+		return nil, fmt.Errorf("unknown channel %q", message.Channel)
+	}
+
+	if c.cfg.StatusCallbackURL != "" {
+		form.Set("StatusCallback", c.cfg.StatusCallbackURL)
+	}
+
+	return form, nil
+}
+
+// isExpectedError reports messages rejected by Twilio, which say nothing
+// about its health and must not open the circuit.
+func isExpectedError(err error) bool {
+	return errors.Is(err, erring.ErrMessageRejected)
+}
@@ -0,0 +1,94 @@
+package twilio_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/domain/erring"
+	"github.com/go-api-template/app/domain/types"
+	"github.com/go-api-template/app/gateway/twilio"
+	"github.com/go-api-template/app/gateway/twilio/twiliotest"
+	"github.com/go-api-template/app/telemetry"
+)
+
+func TestClient_Send(t *testing.T) {
+	t.Parallel()
+
+	ctx := telemetry.ContextWithTracer(context.Background(), trace.NewNoopTracerProvider().Tracer("test"))
+
+	server := twiliotest.NewServer("AC123", "auth-token")
+	t.Cleanup(server.Close)
+
+	callbacks := make(chan url.Values, 1)
+	callbackServer := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		require.NoError(t, req.ParseForm())
+
+		callbackURL := "http://" + req.Host + req.URL.String()
+		if twilio.ValidateSignature("auth-token", callbackURL, req.PostForm, req.Header.Get(twilio.SignatureHeader)) {
+			callbacks <- req.PostForm
+		}
+
+		rw.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(callbackServer.Close)
+
+	client, err := twilio.New(config.Twilio{
+		AccountSID:        "AC123",
+		AuthToken:         "auth-token",
+		SMSFrom:           "+15550000000",
+		WhatsAppFrom:      "+15550000001",
+		StatusCallbackURL: callbackServer.URL + "/status",
+		BaseURL:           server.URL,
+		Timeout:           time.Second,
+	}, nil)
+	require.NoError(t, err)
+
+	sid, err := client.Send(ctx, entity.Message{
+		Channel:   entity.MessageChannelWhatsApp,
+		To:        "+5511999999999",
+		Template:  types.QuickResponseTemplate,
+		Variables: map[string]string{"1": "Ana", "2": "https://sho.rt/abc"},
+	})
+	require.NoError(t, err)
+
+	messages := server.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, sid, messages[0].SID)
+	assert.Equal(t, "whatsapp:+15550000001", messages[0].From)
+	assert.Equal(t, "whatsapp:+5511999999999", messages[0].To)
+	assert.Equal(t, string(types.QuickResponseTemplate), messages[0].ContentSID)
+	assert.Equal(t, map[string]string{"1": "Ana", "2": "https://sho.rt/abc"}, messages[0].ContentVariables)
+
+	resp, err := server.SendStatus(ctx, sid, "delivered")
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	select {
+	case params := <-callbacks:
+		assert.Equal(t, sid, params.Get("MessageSid"))
+		assert.Equal(t, "delivered", params.Get("MessageStatus"))
+	case <-time.After(time.Second):
+		t.Fatal("status callback not received or not signed")
+	}
+
+	server.FailNext(http.StatusBadRequest, 21211, "Invalid 'To' Phone Number")
+
+	_, err = client.Send(ctx, entity.Message{Channel: entity.MessageChannelSMS, To: "+1", Template: types.ListTemplate})
+	require.ErrorIs(t, err, erring.ErrMessageRejected)
+
+	server.FailNext(http.StatusServiceUnavailable, 20503, "Service unavailable")
+
+	_, err = client.Send(ctx, entity.Message{Channel: entity.MessageChannelSMS, To: "+15551234567", Template: types.ListTemplate})
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, erring.ErrMessageRejected)
+}
@@ -0,0 +1,185 @@
+// Package twiliotest provides an in-process fake of the Twilio Messaging API
+// for tests.
+package twiliotest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/go-api-template/app/gateway/twilio"
+)
+
+// Message is a message received by the fake server.
+type Message struct {
+	SID              string
+	From             string
+	To               string
+	ContentSID       string
+	ContentVariables map[string]string
+	StatusCallback   string
+}
+
+type failure struct {
+	status  int
+	code    int
+	message string
+}
+
+// Server fakes the Messages endpoint of the Twilio API. Point the client's
+// TWILIO_BASE_URL to Server.URL.
+type Server struct {
+	*httptest.Server
+
+	AccountSID string
+	AuthToken  string
+
+	mu       sync.Mutex
+	messages []Message
+	failures []failure
+}
+
+func NewServer(accountSID, authToken string) *Server {
+	s := &Server{
+		AccountSID: accountSID,
+		AuthToken:  authToken,
+	}
+
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+
+	return s
+}
+
+// Messages returns the messages received so far.
+func (s *Server) Messages() []Message {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]Message(nil), s.messages...)
+}
+
+// FailNext makes the next message fail with an API error.
+func (s *Server) FailNext(status, code int, message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.failures = append(s.failures, failure{status: status, code: code, message: message})
+}
+
+// SendStatus calls the status callback of a received message, signed like
+// Twilio does.
+func (s *Server) SendStatus(ctx context.Context, sid, status string) (*http.Response, error) {
+	var message *Message
+
+	for _, m := range s.Messages() {
+		if m.SID == sid {
+			message = &m
+
+			break
+		}
+	}
+
+	if message == nil || message.StatusCallback == "" {
+		return nil, fmt.Errorf("no status callback for message %q", sid)
+	}
+
+	params := url.Values{
+		"AccountSid":    {s.AccountSID},
+		"MessageSid":    {sid},
+		"MessageStatus": {status},
+		"To":            {message.To},
+		"From":          {message.From},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, message.StatusCallback, strings.NewReader(params.Encode()))
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set(twilio.SignatureHeader, twilio.Signature(s.AuthToken, message.StatusCallback, params))
+
+	return http.DefaultClient.Do(req) //nolint:wrapcheck
+}
+
+func (s *Server) handle(rw http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost || req.URL.Path != "/2010-04-01/Accounts/"+s.AccountSID+"/Messages.json" {
+		writeError(rw, http.StatusNotFound, 20404, "The requested resource was not found")
+
+		return
+	}
+
+	if user, password, ok := req.BasicAuth(); !ok || user != s.AccountSID || password != s.AuthToken {
+		writeError(rw, http.StatusUnauthorized, 20003, "Authenticate")
+
+		return
+	}
+
+	if err := req.ParseForm(); err != nil {
+		writeError(rw, http.StatusBadRequest, 21000, err.Error())
+
+		return
+	}
+
+	message := Message{
+		From:           req.PostForm.Get("From"),
+		To:             req.PostForm.Get("To"),
+		ContentSID:     req.PostForm.Get("ContentSid"),
+		StatusCallback: req.PostForm.Get("StatusCallback"),
+	}
+
+	if message.To == "" || message.From == "" || message.ContentSID == "" {
+		writeError(rw, http.StatusBadRequest, 21604, "A 'To', 'From' and 'ContentSid' are required")
+
+		return
+	}
+
+	if variables := req.PostForm.Get("ContentVariables"); variables != "" {
+		if err := json.Unmarshal([]byte(variables), &message.ContentVariables); err != nil {
+			writeError(rw, http.StatusBadRequest, 21656, "ContentVariables must be a JSON object")
+
+			return
+		}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if len(s.failures) > 0 {
+		f := s.failures[0]
+		s.failures = s.failures[1:]
+
+		writeError(rw, f.status, f.code, f.message)
+
+		return
+	}
+
+	message.SID = fmt.Sprintf("SM%032d", len(s.messages)+1)
+	s.messages = append(s.messages, message)
+
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(http.StatusCreated)
+
+	_ = json.NewEncoder(rw).Encode(map[string]any{
+		"sid":    message.SID,
+		"status": "queued",
+		"to":     message.To,
+		"from":   message.From,
+	})
+}
+
+func writeError(rw http.ResponseWriter, status, code int, message string) {
+	rw.Header().Set("Content-Type", "application/json")
+	rw.WriteHeader(status)
+
+	_ = json.NewEncoder(rw).Encode(map[string]any{
+		"code":    code,
+		"message": message,
+		"status":  status,
+	})
+}
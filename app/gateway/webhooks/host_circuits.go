@@ -0,0 +1,82 @@
+package webhooks
+
+import (
+	"sync"
+
+	"github.com/cep21/circuit/v4"
+
+	"github.com/go-api-template/app/library/circuitbreaker"
+)
+
+// maxHostCircuits bounds the receiver hosts with a circuit. Past it, the host
+// used the longest ago loses its circuit, and starts over closed.
+const maxHostCircuits = 1024
+
+// hostCircuits holds a circuit per receiver host. They aren't registered with
+// the circuit manager, which would keep the circuit of every host ever
+// delivered to.
+type hostCircuits struct {
+	manager  *circuit.Manager
+	maxHosts int
+
+	mu       sync.Mutex
+	circuits map[string]*hostCircuit
+	uses     uint64
+}
+
+type hostCircuit struct {
+	circ *circuit.Circuit
+	// lastUse orders the hosts by their last delivery.
+	lastUse uint64
+}
+
+func newHostCircuits(manager *circuit.Manager, maxHosts int) *hostCircuits {
+	return &hostCircuits{
+		manager:  manager,
+		maxHosts: maxHosts,
+		circuits: make(map[string]*hostCircuit),
+	}
+}
+
+// get returns the circuit of host, creating it if needed.
+func (h *hostCircuits) get(host string) *circuit.Circuit {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.uses++
+
+	if hc, ok := h.circuits[host]; ok {
+		hc.lastUse = h.uses
+
+		return hc.circ
+	}
+
+	if len(h.circuits) >= h.maxHosts {
+		h.evictLeastRecentlyUsed()
+	}
+
+	hc := &hostCircuit{
+		circ:    circuitbreaker.NewUnmanaged(h.manager, "webhook:"+host),
+		lastUse: h.uses,
+	}
+	h.circuits[host] = hc
+
+	return hc.circ
+}
+
+// evictLeastRecentlyUsed must be called with mu held. It scans every host,
+// which only happens once maxHosts are reached.
+func (h *hostCircuits) evictLeastRecentlyUsed() {
+	var (
+		oldest    string
+		oldestUse uint64
+	)
+
+	for host, hc := range h.circuits {
+		if oldest == "" || hc.lastUse < oldestUse {
+			oldest, oldestUse = host, hc.lastUse
+		}
+	}
+
+	delete(h.circuits, oldest)
+}
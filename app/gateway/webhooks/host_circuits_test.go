@@ -0,0 +1,36 @@
+package webhooks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/library/circuitbreaker"
+)
+
+func TestHostCircuits(t *testing.T) {
+	t.Parallel()
+
+	manager := circuitbreaker.NewManager(config.CircuitBreaker{MaxConcurrentRequests: 10})
+	circuits := newHostCircuits(manager, 2)
+
+	a := circuits.get("a.example.com")
+	require.NotNil(t, a)
+	assert.Equal(t, "webhook:a.example.com", a.Name())
+	assert.Same(t, a, circuits.get("a.example.com"))
+
+	b := circuits.get("b.example.com")
+
+	// a was used last, so b makes room for c.
+	circuits.get("a.example.com")
+	circuits.get("c.example.com")
+
+	assert.Len(t, circuits.circuits, 2)
+	assert.Same(t, a, circuits.get("a.example.com"))
+	assert.NotSame(t, b, circuits.get("b.example.com"), "b starts over")
+
+	assert.Empty(t, manager.AllCircuits(), "host circuits are not registered")
+	assert.Nil(t, newHostCircuits(nil, 2).get("a.example.com"))
+}
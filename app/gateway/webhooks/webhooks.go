@@ -0,0 +1,191 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/cep21/circuit/v4"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/library/circuitbreaker"
+	"github.com/go-api-template/app/library/urlcheck"
+	"github.com/go-api-template/app/library/webhook"
+	"github.com/go-api-template/app/telemetry"
+)
+
+// Bytes of the receiver's response body kept in the delivery log.
+const maxResponseBody = 1024
+
+var (
+	// ErrResponseStatus is returned for deliveries answered with a non 2xx
+	// status.
+	ErrResponseStatus = errors.New("webhook responded with an unexpected status")
+
+	errAddressNotAllowed = errors.New("webhook address is not public")
+)
+
+// Sender posts signed events to webhook URLs. Each receiver host has its own
+// circuit, so a receiver that is down fails fast without affecting others.
+type Sender struct {
+	userAgent  string
+	httpClient *http.Client
+	circuits   *hostCircuits
+}
+
+func New(cfg config.Webhooks, appName string, circuitManager *circuit.Manager) *Sender {
+	dialer := &net.Dialer{Timeout: cfg.Timeout}
+
+	// Checking the address being dialed, instead of the URL host, also
+	// catches hosts resolving to internal addresses.
+	if !cfg.AllowPrivateNetworks {
+		dialer.Control = publicAddressesOnly
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &Sender{
+		userAgent: appName + "-webhooks/1.0",
+		httpClient: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: transport,
+			// Redirects are answers like any other: following them would
+			// send the event somewhere the user never registered.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		circuits: newHostCircuits(circuitManager, maxHostCircuits),
+	}
+}
+
+// Deliver posts the delivery payload to the webhook URL, signed with its
+// secret, and returns the response status and the start of its body. Non 2xx
+// responses also fail with ErrResponseStatus.
+func (s *Sender) Deliver(ctx context.Context, hook entity.Webhook, delivery entity.WebhookDelivery) (int, string, error) {
+	const operation = "Webhooks.Deliver"
+
+	ctx, span := telemetry.StartClientSpan(ctx, "webhook.deliver")
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("webhook.id", hook.ID),
+		attribute.String("webhook.delivery_id", delivery.ID),
+		attribute.String("webhook.event", string(delivery.EventType)),
+	)
+
+	target, err := url.Parse(hook.URL)
+	if err != nil {
+		return 0, "", fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	circ := s.circuits.get(target.Host)
+
+	var (
+		status int
+		body   string
+	)
+
+	err = circuitbreaker.Execute(ctx, circ, func(ctx context.Context) error {
+		status, body, err = s.post(ctx, hook, delivery)
+
+		return err
+	}, isExpectedError)
+
+	span.SetAttributes(attribute.Int("http.response.status_code", status))
+
+	if err != nil {
+		span.RecordError(err)
+
+		return status, body, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return status, body, nil
+}
+
+func (s *Sender) post(ctx context.Context, hook entity.Webhook, delivery entity.WebhookDelivery) (int, string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, "", err //nolint:wrapcheck
+	}
+
+	now := time.Now()
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", s.userAgent)
+	req.Header.Set(webhook.IDHeader, delivery.EventID)
+	req.Header.Set(webhook.TimestampHeader, fmt.Sprint(now.Unix()))
+	req.Header.Set(webhook.SignatureHeader, webhook.Sign(hook.Secret, now, delivery.Payload))
+
+	httpResp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, "", err //nolint:wrapcheck
+	}
+	defer httpResp.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(httpResp.Body, maxResponseBody))
+
+	// Drains a bit more, so the connection can be reused.
+	_, _ = io.CopyN(io.Discard, httpResp.Body, 64<<10)
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode > 299 {
+		return httpResp.StatusCode, toText(body), statusError{status: httpResp.StatusCode}
+	}
+
+	return httpResp.StatusCode, toText(body), nil
+}
+
+type statusError struct {
+	status int
+}
+
+func (e statusError) Error() string {
+	return fmt.Sprintf("%s: %d", ErrResponseStatus, e.status)
+}
+
+func (e statusError) Is(target error) bool {
+	return target == ErrResponseStatus //nolint:errorlint
+}
+
+// isExpectedError reports client errors answered by the receiver, which say
+// nothing about its health and must not open its circuit.
+func isExpectedError(err error) bool {
+	var statusErr statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.status < http.StatusInternalServerError
+	}
+
+	return errors.Is(err, errAddressNotAllowed)
+}
+
+func publicAddressesOnly(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errAddressNotAllowed, address)
+	}
+
+	if !urlcheck.IsPublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", errAddressNotAllowed, addrPort.Addr())
+	}
+
+	return nil
+}
+
+// toText keeps the body storable in a text column, even when truncated in the
+// middle of a character or binary.
+func toText(body []byte) string {
+	return strings.ReplaceAll(string(bytes.ToValidUTF8(body, []byte("\uFFFD"))), "\x00", "")
+}
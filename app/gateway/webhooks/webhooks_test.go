@@ -0,0 +1,105 @@
+package webhooks_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/domain/entity"
+	"github.com/go-api-template/app/gateway/webhooks"
+	"github.com/go-api-template/app/library/webhook"
+	"github.com/go-api-template/app/telemetry"
+)
+
+func TestSender_Deliver(t *testing.T) {
+	t.Parallel()
+
+	ctx := telemetry.ContextWithTracer(context.Background(), trace.NewNoopTracerProvider().Tracer("test"))
+
+	delivery := entity.WebhookDelivery{
+		ID:        "delivery-1",
+		EventID:   "event-1",
+		EventType: "link.created",
+		Payload:   []byte(`{"id":"event-1","type":"link.created"}`),
+	}
+
+	tests := []struct {
+		name         string
+		allowPrivate bool
+		status       int
+		body         string
+		wantStatus   int
+		wantBody     string
+		wantErr      bool
+	}{
+		{
+			name:         "should deliver a signed event",
+			allowPrivate: true,
+			status:       http.StatusOK,
+			body:         "ok",
+			wantStatus:   http.StatusOK,
+			wantBody:     "ok",
+		},
+		{
+			name:         "should fail on non 2xx and keep the truncated body",
+			allowPrivate: true,
+			status:       http.StatusInternalServerError,
+			body:         strings.Repeat("x", 2048),
+			wantStatus:   http.StatusInternalServerError,
+			wantBody:     strings.Repeat("x", 1024),
+			wantErr:      true,
+		},
+		{
+			name:         "should not follow redirects",
+			allowPrivate: true,
+			status:       http.StatusFound,
+			wantStatus:   http.StatusFound,
+			wantErr:      true,
+		},
+		{
+			name:    "should refuse private addresses",
+			status:  http.StatusOK,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			received := make(chan error, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				body, _ := io.ReadAll(req.Body)
+				received <- webhook.Verify("whsec_test", req.Header.Get(webhook.TimestampHeader), req.Header.Get(webhook.SignatureHeader), body, time.Now(), time.Minute)
+
+				if tt.status == http.StatusFound {
+					rw.Header().Set("Location", "/elsewhere")
+				}
+
+				rw.WriteHeader(tt.status)
+				_, _ = io.WriteString(rw, tt.body)
+			}))
+			t.Cleanup(server.Close)
+
+			sender := webhooks.New(config.Webhooks{Timeout: time.Second, AllowPrivateNetworks: tt.allowPrivate}, "test", nil)
+
+			status, body, err := sender.Deliver(ctx, entity.Webhook{ID: "hook-1", URL: server.URL, Secret: "whsec_test"}, delivery)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.wantStatus, status)
+			assert.Equal(t, tt.wantBody, body)
+
+			if tt.wantStatus != 0 {
+				require.NoError(t, <-received)
+			}
+		})
+	}
+}
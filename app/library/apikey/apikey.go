@@ -0,0 +1,54 @@
+// Package apikey issues the API keys users authenticate with. Only hashes of
+// the keys are stored, so a key is shown once, when issued.
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// Keys are the prefix and 32 random bytes, base64url encoded. The prefix
+// makes leaked keys easy to scan for.
+const (
+	prefix    = "sk_"
+	keyLength = 32
+)
+
+// New returns a new API key and its hash.
+func New() (key, hash string, err error) {
+	const operation = "APIKey.New"
+
+	b := make([]byte, keyLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	key = prefix + base64.RawURLEncoding.EncodeToString(b)
+
+	return key, Hash(key), nil
+}
+
+// Hash returns the hash keys are stored and looked up by. Keys are random, so
+// a fast hash is enough.
+func Hash(key string) string {
+	sum := sha256.Sum256([]byte(key))
+
+	return hex.EncodeToString(sum[:])
+}
+
+// IsWellFormed reports whether key could have been issued by New, to reject
+// garbage without a lookup.
+func IsWellFormed(key string) bool {
+	encoded, found := strings.CutPrefix(key, prefix)
+	if !found {
+		return false
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(encoded)
+
+	return err == nil && len(b) == keyLength
+}
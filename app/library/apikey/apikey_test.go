@@ -0,0 +1,47 @@
+package apikey
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	key, hash, err := New()
+	require.NoError(t, err)
+
+	assert.True(t, IsWellFormed(key))
+	assert.Equal(t, Hash(key), hash)
+	assert.NotContains(t, hash, key)
+
+	other, _, err := New()
+	require.NoError(t, err)
+	assert.NotEqual(t, key, other)
+}
+
+func TestIsWellFormed(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		key  string
+		want bool
+	}{
+		{name: "issued key", key: "sk_" + "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA", want: true},
+		{name: "missing prefix", key: "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA"},
+		{name: "too short", key: "sk_AAAA"},
+		{name: "not base64url", key: "sk_" + "AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA+"},
+		{name: "empty", key: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, IsWellFormed(tt.key))
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cep21/circuit/v4"
+	"github.com/cep21/circuit/v4/closers/hystrix"
+	"github.com/cep21/circuit/v4/metrics/rolling"
+
+	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/domain/erring"
+)
+
+// NewManager creates the circuit manager shared by the HTTP handlers and the
+// gateways, so every circuit of the application is configured the same way
+// and can be inspected from a single place.
+func NewManager(cfg config.CircuitBreaker) *circuit.Manager {
+	hystrixFactory := hystrix.Factory{
+		ConfigureOpener: hystrix.ConfigureOpener{
+			ErrorThresholdPercentage: int64(cfg.ErrorPercentThreshold),
+			RequestVolumeThreshold:   int64(cfg.RequestVolumeThreshold),
+		},
+		ConfigureCloser: hystrix.ConfigureCloser{
+			SleepWindow: cfg.SleepWindow,
+		},
+	}
+
+	defaultFactory := func(_ string) circuit.Config {
+		return circuit.Config{
+			Execution: circuit.ExecutionConfig{
+				MaxConcurrentRequests: int64(cfg.MaxConcurrentRequests),
+				Timeout:               cfg.Timeout,
+			},
+		}
+	}
+
+	// Rolling stats are what the admin API reports as error rates.
+	statsFactory := &rolling.StatFactory{}
+
+	return &circuit.Manager{
+		DefaultCircuitProperties: []circuit.CommandPropertiesConstructor{
+			defaultFactory,
+			hystrixFactory.Configure,
+			statsFactory.CreateConfig,
+		},
+	}
+}
+
+// GetOrCreate returns the circuit registered with the given name, creating it
+// when it does not exist yet. A nil manager returns a nil circuit, which the
+// circuit library runs without any protection.
+func GetOrCreate(manager *circuit.Manager, name string) (*circuit.Circuit, error) {
+	const operation = "CircuitBreaker.GetOrCreate"
+
+	if manager == nil {
+		return nil, nil //nolint:nilnil
+	}
+
+	if circ := manager.GetCircuit(name); circ != nil {
+		return circ, nil
+	}
+
+	circ, err := manager.CreateCircuit(name)
+	if err != nil {
+		return nil, fmt.Errorf("%s (%s) -> %w", operation, name, err)
+	}
+
+	return circ, nil
+}
+
+// NewUnmanaged creates a circuit configured like the circuits of manager, but
+// not registered with it, for circuits that come and go, e.g. one per remote
+// host: the manager never forgets its circuits. A nil manager returns a nil
+// circuit.
+func NewUnmanaged(manager *circuit.Manager, name string) *circuit.Circuit {
+	if manager == nil {
+		return nil
+	}
+
+	// Same order as circuit.Manager.CreateCircuit.
+	var cfg circuit.Config
+	for i := len(manager.DefaultCircuitProperties) - 1; i >= 0; i-- {
+		cfg.Merge(manager.DefaultCircuitProperties[i](name))
+	}
+
+	return circuit.NewCircuitFromConfig(name, cfg)
+}
+
+// Execute runs fn inside circ. Errors for which isExpected returns true are
+// returned as they are but don't count as failures of the dependency. When the
+// circuit rejects the call (open or concurrency limit reached) the returned
+// error wraps erring.ErrDependencyUnavailable, so callers can fall back to
+// another dependency.
+func Execute(ctx context.Context, circ *circuit.Circuit, fn func(context.Context) error, isExpected func(error) bool) error {
+	err := Run(ctx, circ, func(ctx context.Context) error {
+		err := fn(ctx)
+		if err != nil && isExpected != nil && isExpected(err) {
+			return circuit.SimpleBadRequest{Err: err}
+		}
+
+		return err
+	})
+	if err == nil {
+		return nil
+	}
+
+	var expected circuit.SimpleBadRequest
+	if errors.As(err, &expected) {
+		return expected.Cause()
+	}
+
+	var cbErr circuit.Error
+	if errors.As(err, &cbErr) {
+		return fmt.Errorf("%w: %s: %w", erring.ErrDependencyUnavailable, circ.Name(), err)
+	}
+
+	return err
+}
+
+// Run executes fn within circ like circuit.Circuit.Run, but also keeps
+// forced-open circuits shut: the library still lets half-open probes through
+// them after every sleep window, which would defeat maintenance mode.
+func Run(ctx context.Context, circ *circuit.Circuit, fn func(context.Context) error) error {
+	if circ != nil && circ.Config().General.ForceOpen {
+		return errForcedOpen
+	}
+
+	return circ.Run(ctx, fn) //nolint:wrapcheck
+}
+
+var errForcedOpen circuit.Error = forcedOpenError{}
+
+type forcedOpenError struct{}
+
+func (forcedOpenError) Error() string                 { return "circuit is forced open" }
+func (forcedOpenError) CircuitOpen() bool             { return true }
+func (forcedOpenError) ConcurrencyLimitReached() bool { return false }
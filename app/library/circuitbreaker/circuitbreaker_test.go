@@ -0,0 +1,110 @@
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/domain/erring"
+)
+
+func TestExecute(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	manager := NewManager(config.CircuitBreaker{
+		Timeout:                time.Second,
+		SleepWindow:            time.Minute,
+		MaxConcurrentRequests:  10,
+		RequestVolumeThreshold: 1,
+		ErrorPercentThreshold:  1,
+	})
+
+	circ, err := GetOrCreate(manager, "test")
+	require.NoError(t, err)
+
+	same, err := GetOrCreate(manager, "test")
+	require.NoError(t, err)
+	assert.Same(t, circ, same)
+
+	isExpected := func(err error) bool { return errors.Is(err, erring.ErrUserNotFound) }
+
+	// Expected errors are returned untouched and never open the circuit.
+	for range 5 {
+		err = Execute(ctx, circ, func(context.Context) error { return erring.ErrUserNotFound }, isExpected)
+		require.ErrorIs(t, err, erring.ErrUserNotFound)
+	}
+
+	assert.False(t, circ.IsOpen())
+
+	failure := errors.New("connection refused")
+
+	for range 5 {
+		_ = Execute(ctx, circ, func(context.Context) error { return failure }, isExpected)
+	}
+
+	assert.True(t, circ.IsOpen())
+
+	err = Execute(ctx, circ, func(context.Context) error { return nil }, isExpected)
+	require.ErrorIs(t, err, erring.ErrDependencyUnavailable)
+}
+
+func TestExecute_NilCircuit(t *testing.T) {
+	t.Parallel()
+
+	circ, err := GetOrCreate(nil, "test")
+	require.NoError(t, err)
+
+	called := false
+	err = Execute(context.Background(), circ, func(context.Context) error {
+		called = true
+
+		return nil
+	}, nil)
+
+	require.NoError(t, err)
+	assert.True(t, called)
+}
+
+func TestSetMode(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	manager := NewManager(config.CircuitBreaker{
+		Timeout:                time.Second,
+		SleepWindow:            time.Minute,
+		MaxConcurrentRequests:  10,
+		RequestVolumeThreshold: 20,
+		ErrorPercentThreshold:  50,
+	})
+
+	circ, err := GetOrCreate(manager, "test")
+	require.NoError(t, err)
+
+	SetMode(ctx, circ, ModeForcedOpen)
+
+	state := Snapshot(circ)
+	assert.True(t, state.IsOpen)
+	assert.Equal(t, ModeForcedOpen, state.Mode)
+	assert.Equal(t, int64(50), state.ErrorThresholdPercentage)
+	assert.Equal(t, time.Minute, state.SleepWindow)
+
+	err = Execute(ctx, circ, func(context.Context) error { return nil }, nil)
+	require.ErrorIs(t, err, erring.ErrDependencyUnavailable)
+
+	SetMode(ctx, circ, ModeForcedClosed)
+	assert.False(t, circ.IsOpen())
+	assert.Equal(t, ModeForcedClosed, ModeOf(circ))
+
+	SetMode(ctx, circ, ModeAuto)
+	assert.False(t, circ.IsOpen())
+	assert.Equal(t, ModeAuto, ModeOf(circ))
+
+	require.NoError(t, Execute(ctx, circ, func(context.Context) error { return nil }, nil))
+	assert.Equal(t, int64(1), Snapshot(circ).Attempts)
+}
@@ -0,0 +1,120 @@
+package circuitbreaker
+
+import (
+	"context"
+	"time"
+
+	"github.com/cep21/circuit/v4"
+	"github.com/cep21/circuit/v4/closers/hystrix"
+	"github.com/cep21/circuit/v4/metrics/rolling"
+)
+
+// Mode tells whether a circuit follows its error thresholds or was pinned
+// open or closed by an operator.
+type Mode string
+
+const (
+	ModeAuto         Mode = "auto"
+	ModeForcedOpen   Mode = "forced-open"
+	ModeForcedClosed Mode = "forced-closed"
+)
+
+func (m Mode) IsValid() bool {
+	switch m {
+	case ModeAuto, ModeForcedOpen, ModeForcedClosed:
+		return true
+	}
+
+	return false
+}
+
+// State is a point-in-time view of a circuit, its rolling stats and config.
+type State struct {
+	Name   string
+	IsOpen bool
+	Mode   Mode
+
+	ConcurrentRequests int64
+
+	// Rolling window stats.
+	Attempts           int64
+	Errors             int64
+	ErrorPercentage    float64
+	Timeouts           int64
+	ShortCircuits      int64
+	ConcurrencyRejects int64
+
+	// Config.
+	Timeout                  time.Duration
+	MaxConcurrentRequests    int64
+	ErrorThresholdPercentage int64
+	RequestVolumeThreshold   int64
+	SleepWindow              time.Duration
+}
+
+func Snapshot(circ *circuit.Circuit) State {
+	now := time.Now()
+	cfg := circ.Config()
+
+	state := State{
+		Name:                  circ.Name(),
+		IsOpen:                circ.IsOpen(),
+		Mode:                  ModeOf(circ),
+		ConcurrentRequests:    circ.ConcurrentCommands(),
+		Timeout:               cfg.Execution.Timeout,
+		MaxConcurrentRequests: cfg.Execution.MaxConcurrentRequests,
+	}
+
+	if stats := rolling.FindCommandMetrics(circ); stats != nil {
+		state.Attempts = stats.LegitimateAttemptsAt(now)
+		state.Errors = stats.ErrorsAt(now)
+		state.ErrorPercentage = stats.ErrorPercentageAt(now) * 100 //nolint:mnd
+		state.Timeouts = stats.ErrTimeouts.RollingSumAt(now)
+		state.ShortCircuits = stats.ErrShortCircuits.RollingSumAt(now)
+		state.ConcurrencyRejects = stats.ErrConcurrencyLimitRejects.RollingSumAt(now)
+	}
+
+	if opener, ok := circ.ClosedToOpen.(*hystrix.Opener); ok {
+		openerCfg := opener.Config()
+		state.ErrorThresholdPercentage = openerCfg.ErrorThresholdPercentage
+		state.RequestVolumeThreshold = openerCfg.RequestVolumeThreshold
+	}
+
+	if closer, ok := circ.OpenToClose.(*hystrix.Closer); ok {
+		state.SleepWindow = closer.Config().SleepWindow
+	}
+
+	return state
+}
+
+func ModeOf(circ *circuit.Circuit) Mode {
+	cfg := circ.Config()
+
+	switch {
+	case cfg.General.ForceOpen:
+		return ModeForcedOpen
+	case cfg.General.ForcedClosed:
+		return ModeForcedClosed
+	default:
+		return ModeAuto
+	}
+}
+
+// SetMode pins a circuit open (e.g. during maintenance), pins it closed, or
+// hands it back to its error thresholds.
+func SetMode(ctx context.Context, circ *circuit.Circuit, mode Mode) {
+	// Open before pinning, otherwise the closers are never told the circuit opened.
+	if mode == ModeForcedOpen {
+		circ.OpenCircuit(ctx)
+	}
+
+	cfg := circ.Config()
+	cfg.General.ForceOpen = mode == ModeForcedOpen
+	cfg.General.ForcedClosed = mode == ModeForcedClosed
+
+	circ.SetConfigThreadSafe(cfg)
+
+	if mode != ModeForcedOpen {
+		circ.CloseCircuit(ctx)
+	}
+}
@@ -0,0 +1,72 @@
+// Package clientip tells the address of the client of a request served
+// behind proxies.
+package clientip
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// FromRequest returns the address of the client of req, using the same header
+// precedence as telemetry.LogAttrsFromHTTP: True-Client-Ip, X-Real-Ip, then
+// X-Forwarded-For.
+//
+// The headers are only read from trustedProxies, and X-Forwarded-For is read
+// from the right, up to the first address that isn't a trusted proxy.
+// Without trusted proxies, clients could claim any address, so the peer is
+// the client.
+func FromRequest(req *http.Request, trustedProxies []netip.Prefix) string {
+	peer := remoteAddr(req)
+
+	addr, err := netip.ParseAddr(peer)
+	if err != nil || !contains(trustedProxies, addr) {
+		return peer
+	}
+
+	if ip := req.Header.Get("True-Client-Ip"); ip != "" {
+		return strings.TrimSpace(ip)
+	}
+
+	if ip := req.Header.Get("X-Real-Ip"); ip != "" {
+		return strings.TrimSpace(ip)
+	}
+
+	forwardedFor := strings.Split(strings.Join(req.Header.Values("X-Forwarded-For"), ","), ",")
+	if len(forwardedFor) == 1 && strings.TrimSpace(forwardedFor[0]) == "" {
+		return peer
+	}
+
+	for i := len(forwardedFor) - 1; i > 0; i-- {
+		ip := strings.TrimSpace(forwardedFor[i])
+
+		addr, err := netip.ParseAddr(ip)
+		if err != nil || !contains(trustedProxies, addr) {
+			return ip
+		}
+	}
+
+	return strings.TrimSpace(forwardedFor[0])
+}
+
+func remoteAddr(req *http.Request) string {
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		return req.RemoteAddr
+	}
+
+	return host
+}
+
+func contains(prefixes []netip.Prefix, addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,86 @@
+package clientip
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromRequest(t *testing.T) {
+	t.Parallel()
+
+	trusted := []netip.Prefix{
+		netip.MustParsePrefix("10.0.0.0/8"),
+		netip.MustParsePrefix("2001:db8::/32"),
+	}
+
+	tests := []struct {
+		name           string
+		remoteAddr     string
+		headers        map[string]string
+		trustedProxies []netip.Prefix
+		want           string
+	}{
+		{
+			name:       "should use the peer without headers",
+			remoteAddr: "203.0.113.7:51234",
+			want:       "203.0.113.7",
+		},
+		{
+			name:       "should ignore headers without trusted proxies",
+			remoteAddr: "10.0.0.1:51234",
+			headers:    map[string]string{"True-Client-Ip": "198.51.100.2", "X-Forwarded-For": "198.51.100.1, 10.0.0.2"},
+			want:       "10.0.0.1",
+		},
+		{
+			name:       "should prefer True-Client-Ip over X-Real-Ip and X-Forwarded-For",
+			remoteAddr: "10.0.0.1:51234",
+			headers: map[string]string{
+				"True-Client-Ip":  "198.51.100.1",
+				"X-Real-Ip":       "198.51.100.2",
+				"X-Forwarded-For": "198.51.100.3",
+			},
+			trustedProxies: trusted,
+			want:           "198.51.100.1",
+		},
+		{
+			name:           "should ignore headers from untrusted peers",
+			remoteAddr:     "203.0.113.7:51234",
+			headers:        map[string]string{"True-Client-Ip": "198.51.100.1"},
+			trustedProxies: trusted,
+			want:           "203.0.113.7",
+		},
+		{
+			name:           "should skip trusted proxies in X-Forwarded-For",
+			remoteAddr:     "10.0.0.1:51234",
+			headers:        map[string]string{"X-Forwarded-For": "192.0.2.66, 198.51.100.1, 10.0.0.3, 10.0.0.2"},
+			trustedProxies: trusted,
+			want:           "198.51.100.1",
+		},
+		{
+			name:           "should use the first address when all are trusted",
+			remoteAddr:     "[2001:db8::1]:51234",
+			headers:        map[string]string{"X-Forwarded-For": "10.0.0.3, 10.0.0.2"},
+			trustedProxies: trusted,
+			want:           "10.0.0.3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/abc123", nil)
+			req.RemoteAddr = tt.remoteAddr
+
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			assert.Equal(t, tt.want, FromRequest(req, tt.trustedProxies))
+		})
+	}
+}
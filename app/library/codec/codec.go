@@ -0,0 +1,242 @@
+// Package codec serializes cached values. Every encoded value starts with a
+// header byte naming its codec and compression, so values are decoded with
+// what they were encoded with, and the configured codec can change without
+// flushing the cache.
+package codec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/golang/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/vmihailenco/msgpack/v5"
+	"google.golang.org/protobuf/proto"
+)
+
+// Headers have the high bit set, which no JSON text starts with, telling
+// them apart from values written before headers existed, which are JSON.
+const (
+	headerFlag      = 0x80
+	codecShift      = 4
+	codecMask       = 0x07
+	compressionMask = 0x0f
+)
+
+var (
+	ErrUnknownCodec       = errors.New("unknown codec")
+	ErrUnknownCompression = errors.New("unknown compression")
+	errNotProtoMessage    = errors.New("value is not a proto.Message")
+)
+
+// Codec serializes values. Its ID goes into the header of what it encodes,
+// so it must never change.
+type Codec interface {
+	ID() byte
+	Name() string
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+var (
+	JSON        Codec = jsonCodec{}
+	MessagePack Codec = msgpackCodec{}
+
+	// Protobuf only encodes proto.Message values; Encoder falls back to JSON
+	// for the others.
+	Protobuf Codec = protobufCodec{}
+)
+
+var codecs = []Codec{JSON, MessagePack, Protobuf}
+
+type jsonCodec struct{}
+
+func (jsonCodec) ID() byte                           { return 0 }
+func (jsonCodec) Name() string                       { return "json" }
+func (jsonCodec) Marshal(v any) ([]byte, error)      { return json.Marshal(v) }         //nolint:wrapcheck
+func (jsonCodec) Unmarshal(data []byte, v any) error { return json.Unmarshal(data, v) } //nolint:wrapcheck
+
+type msgpackCodec struct{}
+
+func (msgpackCodec) ID() byte     { return 1 }
+func (msgpackCodec) Name() string { return "msgpack" }
+
+// JSON tags keep field names the same across codecs.
+func (msgpackCodec) Marshal(v any) ([]byte, error) {
+	enc := msgpack.GetEncoder()
+	defer msgpack.PutEncoder(enc)
+
+	var buf bytes.Buffer
+
+	enc.Reset(&buf)
+	enc.SetCustomStructTag("json")
+	enc.UseCompactInts(true)
+
+	if err := enc.Encode(v); err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (msgpackCodec) Unmarshal(data []byte, v any) error {
+	dec := msgpack.GetDecoder()
+	defer msgpack.PutDecoder(dec)
+
+	dec.Reset(bytes.NewReader(data))
+	dec.SetCustomStructTag("json")
+
+	return dec.Decode(v) //nolint:wrapcheck
+}
+
+type protobufCodec struct{}
+
+func (protobufCodec) ID() byte     { return 2 }
+func (protobufCodec) Name() string { return "protobuf" }
+
+func (protobufCodec) Marshal(v any) ([]byte, error) {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return nil, fmt.Errorf("%w: %T", errNotProtoMessage, v)
+	}
+
+	return proto.Marshal(msg) //nolint:wrapcheck
+}
+
+func (protobufCodec) Unmarshal(data []byte, v any) error {
+	msg, ok := v.(proto.Message)
+	if !ok {
+		return fmt.Errorf("%w: %T", errNotProtoMessage, v)
+	}
+
+	return proto.Unmarshal(data, msg) //nolint:wrapcheck
+}
+
+// Compression of encoded values. Like codec IDs, the values must never
+// change.
+type Compression byte
+
+const (
+	CompressionNone   Compression = 0
+	CompressionSnappy Compression = 1
+	CompressionZstd   Compression = 2
+)
+
+// Shared by all encoders; both are safe for concurrent EncodeAll/DecodeAll.
+var (
+	zstdEncoder, _ = zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedFastest))
+	zstdDecoder, _ = zstd.NewReader(nil, zstd.WithDecoderConcurrency(0))
+)
+
+// ParseCodec returns the codec named name.
+func ParseCodec(name string) (Codec, error) {
+	for _, c := range codecs {
+		if c.Name() == name {
+			return c, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", ErrUnknownCodec, name)
+}
+
+// ParseCompression returns the compression named name: none, snappy or zstd.
+func ParseCompression(name string) (Compression, error) {
+	switch name {
+	case "", "none":
+		return CompressionNone, nil
+	case "snappy":
+		return CompressionSnappy, nil
+	case "zstd":
+		return CompressionZstd, nil
+	}
+
+	return 0, fmt.Errorf("%w: %s", ErrUnknownCompression, name)
+}
+
+// Encoder encodes values with a codec, compressing the ones of at least
+// threshold bytes, and decodes values encoded with any codec and compression.
+type Encoder struct {
+	codec       Codec
+	compression Compression
+	threshold   int
+}
+
+func NewEncoder(codec Codec, compression Compression, threshold int) *Encoder {
+	return &Encoder{
+		codec:       codec,
+		compression: compression,
+		threshold:   threshold,
+	}
+}
+
+func (e *Encoder) Encode(v any) ([]byte, error) {
+	codec := e.codec
+	if _, ok := v.(proto.Message); !ok && codec == Protobuf {
+		codec = JSON
+	}
+
+	data, err := codec.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	compression := e.compression
+	if len(data) < e.threshold {
+		compression = CompressionNone
+	}
+
+	header := headerFlag | codec.ID()<<codecShift | byte(compression)
+
+	switch compression {
+	case CompressionNone:
+		return append([]byte{header}, data...), nil
+	case CompressionSnappy:
+		return append([]byte{header}, snappy.Encode(nil, data)...), nil
+	case CompressionZstd:
+		return zstdEncoder.EncodeAll(data, []byte{header}), nil
+	}
+
+	return nil, fmt.Errorf("%w: %d", ErrUnknownCompression, compression)
+}
+
+// Decode decodes data into v, which must be a pointer.
+func (e *Encoder) Decode(data []byte, v any) error {
+	if len(data) == 0 || data[0]&headerFlag == 0 {
+		return JSON.Unmarshal(data, v)
+	}
+
+	header, data := data[0], data[1:]
+
+	codec, err := codecByID(header >> codecShift & codecMask)
+	if err != nil {
+		return err
+	}
+
+	switch compression := Compression(header & compressionMask); compression {
+	case CompressionNone:
+	case CompressionSnappy:
+		data, err = snappy.Decode(nil, data)
+	case CompressionZstd:
+		data, err = zstdDecoder.DecodeAll(data, nil)
+	default:
+		return fmt.Errorf("%w: %d", ErrUnknownCompression, compression)
+	}
+
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	return codec.Unmarshal(data, v)
+}
+
+func codecByID(id byte) (Codec, error) {
+	for _, c := range codecs {
+		if c.ID() == id {
+			return c, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %d", ErrUnknownCodec, id)
+}
@@ -0,0 +1,104 @@
+package codec
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type cachedValue struct {
+	Code      string    `json:"code"`
+	Tags      []string  `json:"tags"`
+	Count     int64     `json:"count"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+func TestEncoder(t *testing.T) {
+	t.Parallel()
+
+	value := cachedValue{
+		Code:      "abc123",
+		Tags:      []string{strings.Repeat("tag", 100)},
+		Count:     42,
+		ExpiresAt: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	for _, c := range []Codec{JSON, MessagePack} {
+		for name, compression := range map[string]Compression{"none": CompressionNone, "snappy": CompressionSnappy, "zstd": CompressionZstd} {
+			t.Run(c.Name()+" "+name, func(t *testing.T) {
+				t.Parallel()
+
+				encoder := NewEncoder(c, compression, 64)
+
+				data, err := encoder.Encode(value)
+				require.NoError(t, err)
+				assert.Equal(t, byte(headerFlag)|c.ID()<<codecShift|byte(compression), data[0])
+
+				var got cachedValue
+				require.NoError(t, encoder.Decode(data, &got))
+
+				// MessagePack decodes times in the local time zone.
+				got.ExpiresAt = got.ExpiresAt.UTC()
+				assert.Equal(t, value, got)
+			})
+		}
+	}
+
+	t.Run("should not compress values under the threshold", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := NewEncoder(JSON, CompressionZstd, 1024).Encode(value)
+		require.NoError(t, err)
+		assert.Equal(t, byte(headerFlag)|byte(CompressionNone), data[0])
+	})
+
+	t.Run("should decode values of other codecs and legacy JSON", func(t *testing.T) {
+		t.Parallel()
+
+		data, err := NewEncoder(MessagePack, CompressionSnappy, 0).Encode(value)
+		require.NoError(t, err)
+
+		var got cachedValue
+
+		encoder := NewEncoder(JSON, CompressionNone, 0)
+		require.NoError(t, encoder.Decode(data, &got))
+		assert.Equal(t, value.Code, got.Code)
+		assert.True(t, value.ExpiresAt.Equal(got.ExpiresAt))
+
+		got = cachedValue{}
+		require.NoError(t, encoder.Decode([]byte(`{"code":"abc123","count":42}`), &got))
+		assert.Equal(t, cachedValue{Code: "abc123", Count: 42}, got)
+	})
+
+	t.Run("should encode proto messages with protobuf and others with JSON", func(t *testing.T) {
+		t.Parallel()
+
+		encoder := NewEncoder(Protobuf, CompressionNone, 0)
+
+		data, err := encoder.Encode(wrapperspb.String("abc123"))
+		require.NoError(t, err)
+		assert.Equal(t, byte(headerFlag)|Protobuf.ID()<<codecShift, data[0])
+
+		got := &wrapperspb.StringValue{}
+		require.NoError(t, encoder.Decode(data, got))
+		assert.True(t, proto.Equal(wrapperspb.String("abc123"), got))
+
+		data, err = encoder.Encode(value)
+		require.NoError(t, err)
+		assert.Equal(t, byte(headerFlag)|JSON.ID()<<codecShift, data[0])
+	})
+
+	t.Run("should reject unknown codecs", func(t *testing.T) {
+		t.Parallel()
+
+		var got cachedValue
+
+		err := NewEncoder(JSON, CompressionNone, 0).Decode([]byte{headerFlag | 7<<codecShift, '{', '}'}, &got)
+		assert.ErrorIs(t, err, ErrUnknownCodec)
+	})
+}
@@ -0,0 +1,75 @@
+// Package consistency tracks the database writes of a request or session, so
+// its later reads can skip replicas that may not have caught up with them.
+package consistency
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+type ctxKey int
+
+const (
+	keyTracker ctxKey = iota
+	keyPrimary
+)
+
+type tracker struct {
+	// Unix nanoseconds of the last write, 0 before any.
+	lastWrite atomic.Int64
+}
+
+// NewContext returns a context recording the writes made with it and its
+// children. lastWrite seeds it with a write of a previous request of the same
+// session; pass the zero time when there is none.
+func NewContext(ctx context.Context, lastWrite time.Time) context.Context {
+	t := &tracker{}
+	if !lastWrite.IsZero() {
+		t.lastWrite.Store(lastWrite.UnixNano())
+	}
+
+	return context.WithValue(ctx, keyTracker, t)
+}
+
+// MarkWrite records a write made with ctx. It does nothing when ctx doesn't
+// come from NewContext.
+func MarkWrite(ctx context.Context) {
+	if t, ok := ctx.Value(keyTracker).(*tracker); ok {
+		t.lastWrite.Store(time.Now().UnixNano())
+	}
+}
+
+// Detach returns a context whose writes are no longer recorded, for work in
+// the background of a request that must not keep its session on the primary.
+func Detach(ctx context.Context) context.Context {
+	return context.WithValue(ctx, keyTracker, nil)
+}
+
+// LastWrite returns the time of the last write recorded for ctx, or the zero
+// time.
+func LastWrite(ctx context.Context) time.Time {
+	t, ok := ctx.Value(keyTracker).(*tracker)
+	if !ok || t.lastWrite.Load() == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, t.lastWrite.Load())
+}
+
+// WithPrimary returns a context whose reads always go to the primary.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, keyPrimary, true)
+}
+
+// RequiresPrimary reports whether reads made with ctx must go to the primary:
+// when asked with WithPrimary or within window of a recorded write.
+func RequiresPrimary(ctx context.Context, window time.Duration) bool {
+	if primary, _ := ctx.Value(keyPrimary).(bool); primary {
+		return true
+	}
+
+	lastWrite := LastWrite(ctx)
+
+	return !lastWrite.IsZero() && time.Since(lastWrite) < window
+}
@@ -0,0 +1,93 @@
+package consistency_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/go-api-template/app/library/consistency"
+)
+
+func TestRequiresPrimary(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		ctx  func() context.Context
+		want bool
+	}{
+		{
+			name: "should read from replicas without writes",
+			ctx: func() context.Context {
+				return consistency.NewContext(context.Background(), time.Time{})
+			},
+			want: false,
+		},
+		{
+			name: "should read from the primary after a write of the same request",
+			ctx: func() context.Context {
+				ctx := consistency.NewContext(context.Background(), time.Time{})
+
+				// Writes are recorded from child contexts too.
+				child, cancel := context.WithCancel(ctx)
+				defer cancel()
+
+				consistency.MarkWrite(child)
+
+				return ctx
+			},
+			want: true,
+		},
+		{
+			name: "should read from the primary after a recent write of the session",
+			ctx: func() context.Context {
+				return consistency.NewContext(context.Background(), time.Now().Add(-time.Second))
+			},
+			want: true,
+		},
+		{
+			name: "should read from replicas once the window passed",
+			ctx: func() context.Context {
+				return consistency.NewContext(context.Background(), time.Now().Add(-time.Minute))
+			},
+			want: false,
+		},
+		{
+			name: "should read from the primary when asked to",
+			ctx: func() context.Context {
+				return consistency.WithPrimary(context.Background())
+			},
+			want: true,
+		},
+		{
+			name: "should ignore writes without a tracker",
+			ctx: func() context.Context {
+				ctx := context.Background()
+				consistency.MarkWrite(ctx)
+
+				return ctx
+			},
+			want: false,
+		},
+		{
+			name: "should ignore writes of detached contexts",
+			ctx: func() context.Context {
+				ctx := consistency.NewContext(context.Background(), time.Time{})
+				consistency.MarkWrite(consistency.Detach(ctx))
+
+				return ctx
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, consistency.RequiresPrimary(tt.ctx(), 5*time.Second))
+		})
+	}
+}
@@ -10,6 +10,8 @@ const (
 	keyAuthorizationHeader ctxKey = iota
 	keyIdempotencyKey
 	keyRequestID
+	keyJobLastAttempt
+	keyOutboxEventID
 )
 
 func GetAuthorizationHeader(ctx context.Context) (string, bool) {
@@ -47,3 +49,31 @@ func GetRequestID(ctx context.Context) (string, bool) {
 func PutRequestID(ctx context.Context, requestID string) context.Context {
 	return context.WithValue(ctx, keyRequestID, requestID)
 }
+
+// GetJobLastAttempt reports whether the background job running with ctx will
+// be dead-lettered if it fails.
+func GetJobLastAttempt(ctx context.Context) (bool, bool) {
+	if b, ok := ctx.Value(keyJobLastAttempt).(bool); ok {
+		return b, true
+	}
+
+	return false, false
+}
+
+func PutJobLastAttempt(ctx context.Context, lastAttempt bool) context.Context {
+	return context.WithValue(ctx, keyJobLastAttempt, lastAttempt)
+}
+
+// GetOutboxEventID returns the ID of the outbox event being handled with ctx,
+// which stays the same when the event is relayed again.
+func GetOutboxEventID(ctx context.Context) (string, bool) {
+	if s, ok := ctx.Value(keyOutboxEventID).(string); ok {
+		return s, true
+	}
+
+	return "", false
+}
+
+func PutOutboxEventID(ctx context.Context, eventID string) context.Context {
+	return context.WithValue(ctx, keyOutboxEventID, eventID)
+}
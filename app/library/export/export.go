@@ -0,0 +1,182 @@
+// Package export writes tabular data as CSV, NDJSON or Parquet.
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-api-template/app/library/parquet"
+)
+
+type Format string
+
+const (
+	FormatCSV     Format = "csv"
+	FormatNDJSON  Format = "ndjson"
+	FormatParquet Format = "parquet"
+)
+
+var ErrFormatInvalid = errors.New("invalid export format")
+
+func (f Format) IsValid() bool {
+	switch f {
+	case FormatCSV, FormatNDJSON, FormatParquet:
+		return true
+	default:
+		return false
+	}
+}
+
+// Streamable reports whether rows can be sent as they are written. Parquet
+// needs the whole file before it can be read.
+func (f Format) Streamable() bool {
+	return f == FormatCSV || f == FormatNDJSON
+}
+
+func (f Format) ContentType() string {
+	switch f {
+	case FormatCSV:
+		return "text/csv; charset=utf-8"
+	case FormatNDJSON:
+		return "application/x-ndjson"
+	default:
+		return "application/vnd.apache.parquet"
+	}
+}
+
+func (f Format) Extension() string {
+	return "." + string(f)
+}
+
+type (
+	ColumnType = parquet.ColumnType
+	Column     = parquet.Column
+)
+
+const (
+	String    = parquet.String
+	Int64     = parquet.Int64
+	Timestamp = parquet.Timestamp
+)
+
+// Writer writes rows with one value per column, in column order: string,
+// int64 and time.Time, or nil for optional columns.
+type Writer interface {
+	Write(row []any) error
+	// Close flushes buffered rows. It doesn't close the underlying writer.
+	Close() error
+}
+
+func NewWriter(format Format, out io.Writer, columns []Column) (Writer, error) {
+	const operation = "Export.NewWriter"
+
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(out, columns)
+	case FormatNDJSON:
+		return &ndjsonWriter{encoder: json.NewEncoder(out), columns: columns}, nil
+	case FormatParquet:
+		writer, err := parquet.NewWriter(out, columns)
+		if err != nil {
+			return nil, fmt.Errorf("%s -> %w", operation, err)
+		}
+
+		return writer, nil
+	default:
+		return nil, fmt.Errorf("%s (%s) -> %w", operation, format, ErrFormatInvalid)
+	}
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+	record []string
+}
+
+func newCSVWriter(out io.Writer, columns []Column) (*csvWriter, error) {
+	w := &csvWriter{
+		writer: csv.NewWriter(out),
+		record: make([]string, len(columns)),
+	}
+
+	for i, column := range columns {
+		w.record[i] = column.Name
+	}
+
+	if err := w.writer.Write(w.record); err != nil {
+		return nil, fmt.Errorf("write csv header: %w", err)
+	}
+
+	return w, nil
+}
+
+func (w *csvWriter) Write(row []any) error {
+	for i, value := range row {
+		switch v := value.(type) {
+		case nil:
+			w.record[i] = ""
+		case string:
+			w.record[i] = escapeFormula(v)
+		case int64:
+			w.record[i] = strconv.FormatInt(v, 10)
+		case time.Time:
+			w.record[i] = v.UTC().Format(time.RFC3339)
+		default:
+			w.record[i] = fmt.Sprint(v)
+		}
+	}
+
+	if err := w.writer.Write(w.record); err != nil {
+		return fmt.Errorf("write csv: %w", err)
+	}
+
+	return nil
+}
+
+// escapeFormula keeps spreadsheets from running text that starts like a
+// formula, e.g. a target URL or referrer set by anyone, by prefixing it with
+// a quote.
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+
+	return s
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+
+	if err := w.writer.Error(); err != nil {
+		return fmt.Errorf("flush csv: %w", err)
+	}
+
+	return nil
+}
+
+type ndjsonWriter struct {
+	encoder *json.Encoder
+	columns []Column
+}
+
+func (w *ndjsonWriter) Write(row []any) error {
+	object := make(map[string]any, len(w.columns))
+	for i, column := range w.columns {
+		object[column.Name] = row[i]
+	}
+
+	if err := w.encoder.Encode(object); err != nil {
+		return fmt.Errorf("write ndjson: %w", err)
+	}
+
+	return nil
+}
+
+func (w *ndjsonWriter) Close() error {
+	return nil
+}
@@ -0,0 +1,65 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWriter(t *testing.T) {
+	t.Parallel()
+
+	columns := []Column{
+		{Name: "code", Type: String},
+		{Name: "clicks", Type: Int64},
+		{Name: "expires_at", Type: Timestamp, Optional: true},
+	}
+
+	expiresAt := time.Date(2026, 10, 19, 12, 0, 0, 0, time.FixedZone("BRT", -3*60*60))
+	rows := [][]any{
+		{"aZ3kP9q", int64(10), expiresAt},
+		{"with,comma", int64(0), nil},
+		{"=HYPERLINK(\"https://evil.example\")", int64(-1), nil},
+	}
+
+	tests := []struct {
+		format Format
+		want   string
+	}{
+		{
+			format: FormatCSV,
+			want: "code,clicks,expires_at\naZ3kP9q,10,2026-10-19T15:00:00Z\n\"with,comma\",0,\n" +
+				`"'=HYPERLINK(""https://evil.example"")",-1,` + "\n",
+		},
+		{
+			format: FormatNDJSON,
+			want: `{"clicks":10,"code":"aZ3kP9q","expires_at":"2026-10-19T12:00:00-03:00"}` + "\n" +
+				`{"clicks":0,"code":"with,comma","expires_at":null}` + "\n" +
+				`{"clicks":-1,"code":"=HYPERLINK(\"https://evil.example\")","expires_at":null}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+
+			w, err := NewWriter(tt.format, &buf, columns)
+			require.NoError(t, err)
+
+			for _, row := range rows {
+				require.NoError(t, w.Write(row))
+			}
+
+			require.NoError(t, w.Close())
+			assert.Equal(t, tt.want, buf.String())
+		})
+	}
+
+	_, err := NewWriter("xlsx", &bytes.Buffer{}, columns)
+	require.ErrorIs(t, err, ErrFormatInvalid)
+}
@@ -0,0 +1,177 @@
+// Package geoip locates IP addresses offline, with a MaxMind-format database
+// file reloaded whenever it changes.
+package geoip
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"sync/atomic"
+	"time"
+
+	"github.com/oschwald/maxminddb-golang"
+
+	"github.com/go-api-template/app/config"
+)
+
+// Location of an IP address. Fields missing from the database are empty.
+type Location struct {
+	// ISO 3166-1 code of the country, e.g. BR.
+	Country string
+	// ISO 3166-2 code of the region within the country, e.g. SP.
+	Region string
+	// English name of the city.
+	City string
+	// Number of the autonomous system announcing the address.
+	ASN uint32
+}
+
+// record has the fields of GeoLite2 and GeoIP2 City and ASN databases that
+// make up a Location. Databases combining both may keep the ASN in traits.
+type record struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+	Subdivisions []struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"subdivisions"`
+	City struct {
+		Names map[string]string `maxminddb:"names"`
+	} `maxminddb:"city"`
+	ASN    uint32 `maxminddb:"autonomous_system_number"`
+	Traits struct {
+		ASN uint32 `maxminddb:"autonomous_system_number"`
+	} `maxminddb:"traits"`
+}
+
+func (r record) location() Location {
+	location := Location{
+		Country: r.Country.ISOCode,
+		City:    r.City.Names["en"],
+		ASN:     r.ASN,
+	}
+
+	if len(r.Subdivisions) > 0 {
+		location.Region = r.Subdivisions[0].ISOCode
+	}
+
+	if location.ASN == 0 {
+		location.ASN = r.Traits.ASN
+	}
+
+	return location
+}
+
+// Locator locates IP addresses with the database at a path. Without a path,
+// every address is in an unknown location.
+type Locator struct {
+	// Nil until a database is loaded.
+	reader atomic.Pointer[maxminddb.Reader]
+
+	path           string
+	reloadInterval time.Duration
+	modTime        time.Time
+}
+
+// New creates a Locator and loads its database, if configured.
+func New(cfg config.GeoIP) (*Locator, error) {
+	const operation = "GeoIP.New"
+
+	locator := &Locator{
+		path:           cfg.DatabasePath,
+		reloadInterval: cfg.ReloadInterval,
+	}
+
+	if locator.path != "" {
+		if _, err := locator.reload(); err != nil {
+			return nil, fmt.Errorf("%s -> %w", operation, err)
+		}
+	}
+
+	return locator, nil
+}
+
+// Watch reloads the database whenever its file changes, until ctx is done.
+func (l *Locator) Watch(ctx context.Context) {
+	const operation = "GeoIP.Watch"
+
+	if l.path == "" || l.reloadInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(l.reloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := l.reload()
+			if err != nil {
+				// Keep serving the last good database.
+				slog.ErrorContext(ctx, fmt.Sprintf("%s -> %v", operation, err))
+
+				continue
+			}
+
+			if reloaded {
+				slog.InfoContext(ctx, "geoip database reloaded", slog.String("path", l.path))
+			}
+		}
+	}
+}
+
+// Locate returns the location of ip. Addresses that are invalid or missing
+// from the database are in an unknown location.
+func (l *Locator) Locate(ip string) Location {
+	reader := l.reader.Load()
+	if reader == nil {
+		return Location{}
+	}
+
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return Location{}
+	}
+
+	var rec record
+	if err := reader.Lookup(addr, &rec); err != nil {
+		return Location{}
+	}
+
+	return rec.location()
+}
+
+// reload reads the database file again if it changed since the last load.
+// It reports whether a new database was installed. The file is read into
+// memory, so it may be rewritten in place.
+func (l *Locator) reload() (bool, error) {
+	const operation = "GeoIP.reload"
+
+	info, err := os.Stat(l.path)
+	if err != nil {
+		return false, fmt.Errorf("%s (%s) -> %w", operation, l.path, err)
+	}
+
+	if info.ModTime().Equal(l.modTime) {
+		return false, nil
+	}
+
+	data, err := os.ReadFile(l.path)
+	if err != nil {
+		return false, fmt.Errorf("%s (%s) -> %w", operation, l.path, err)
+	}
+
+	reader, err := maxminddb.FromBytes(data)
+	if err != nil {
+		return false, fmt.Errorf("%s (%s) -> %w", operation, l.path, err)
+	}
+
+	l.reader.Store(reader)
+	l.modTime = info.ModTime()
+
+	return true, nil
+}
@@ -0,0 +1,278 @@
+package geoip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-api-template/app/config"
+)
+
+func TestLocator(t *testing.T) {
+	t.Parallel()
+
+	saoPaulo := map[string]any{
+		"country":                  map[string]any{"iso_code": "BR"},
+		"subdivisions":             []any{map[string]any{"iso_code": "SP"}},
+		"city":                     map[string]any{"names": map[string]any{"en": "São Paulo", "pt-BR": "São Paulo"}},
+		"autonomous_system_number": uint32(28573),
+	}
+	lisbon := map[string]any{
+		"country": map[string]any{"iso_code": "PT"},
+		"city":    map[string]any{"names": map[string]any{"en": "Lisbon"}},
+		"traits":  map[string]any{"autonomous_system_number": uint32(3243)},
+	}
+
+	path := filepath.Join(t.TempDir(), "test.mmdb")
+	writeTestDatabase(t, path, map[string]map[string]any{
+		"198.51.100.0/24": saoPaulo,
+		"2001:db8::/32":   lisbon,
+	})
+
+	locator, err := New(config.GeoIP{DatabasePath: path})
+	require.NoError(t, err)
+
+	t.Run("should locate addresses from their networks", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, Location{Country: "BR", Region: "SP", City: "São Paulo", ASN: 28573}, locator.Locate("198.51.100.7"))
+		assert.Equal(t, Location{Country: "PT", City: "Lisbon", ASN: 3243}, locator.Locate("2001:db8::1"))
+	})
+
+	t.Run("should not locate unknown and invalid addresses", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Zero(t, locator.Locate("203.0.113.7"))
+		assert.Zero(t, locator.Locate("not an ip"))
+	})
+
+	t.Run("should locate nothing without a database", func(t *testing.T) {
+		t.Parallel()
+
+		locator, err := New(config.GeoIP{})
+		require.NoError(t, err)
+		assert.Zero(t, locator.Locate("198.51.100.7"))
+	})
+
+	t.Run("should fail on invalid databases", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "invalid.mmdb")
+		require.NoError(t, os.WriteFile(path, []byte("not a database"), 0o600))
+
+		_, err := New(config.GeoIP{DatabasePath: path})
+		assert.Error(t, err)
+	})
+}
+
+func TestLocatorReload(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "test.mmdb")
+	writeTestDatabase(t, path, map[string]map[string]any{
+		"198.51.100.0/24": {"country": map[string]any{"iso_code": "BR"}},
+	})
+
+	locator, err := New(config.GeoIP{DatabasePath: path})
+	require.NoError(t, err)
+
+	reloaded, err := locator.reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+
+	writeTestDatabase(t, path, map[string]map[string]any{
+		"198.51.100.0/24": {"country": map[string]any{"iso_code": "AR"}},
+	})
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Minute)))
+
+	reloaded, err = locator.reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	assert.Equal(t, "AR", locator.Locate("198.51.100.7").Country)
+
+	require.NoError(t, os.WriteFile(path, []byte("truncated"), 0o600))
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(2*time.Minute)))
+
+	_, err = locator.reload()
+	require.Error(t, err)
+	assert.Equal(t, "AR", locator.Locate("198.51.100.7").Country, "should keep the last good database")
+}
+
+// writeTestDatabase writes an IPv6 MaxMind DB mapping non-overlapping
+// networks to records, with IPv4 networks under ::/96 like MaxMind's.
+func writeTestDatabase(t *testing.T, path string, networks map[string]map[string]any) {
+	t.Helper()
+
+	type node struct {
+		children [2]*node
+		// Offset of the record in the data section of leaves, -1 otherwise.
+		data int
+	}
+
+	var data bytes.Buffer
+
+	root := &node{data: -1}
+
+	cidrs := make([]string, 0, len(networks))
+	for cidr := range networks {
+		cidrs = append(cidrs, cidr)
+	}
+
+	sort.Strings(cidrs)
+
+	for _, cidr := range cidrs {
+		prefix := netip.MustParsePrefix(cidr)
+
+		var ip [16]byte
+
+		bits := prefix.Bits()
+		if prefix.Addr().Is4() {
+			v4 := prefix.Addr().As4()
+			copy(ip[12:], v4[:])
+
+			bits += 96
+		} else {
+			ip = prefix.Addr().As16()
+		}
+
+		current := root
+		for i := 0; i < bits; i++ {
+			bit := ip[i/8] >> (7 - i%8) & 1
+			if current.children[bit] == nil {
+				current.children[bit] = &node{data: -1}
+			}
+
+			current = current.children[bit]
+		}
+
+		current.data = data.Len()
+		writeValue(t, &data, networks[cidr])
+	}
+
+	// Number the nodes that aren't leaves, breadth first.
+	var nodes []*node
+
+	ids := map[*node]int{}
+
+	for queue := []*node{root}; len(queue) > 0; queue = queue[1:] {
+		n := queue[0]
+		ids[n] = len(nodes)
+		nodes = append(nodes, n)
+
+		for _, child := range n.children {
+			if child != nil && child.data < 0 {
+				queue = append(queue, child)
+			}
+		}
+	}
+
+	var db bytes.Buffer
+
+	for _, n := range nodes {
+		for _, child := range n.children {
+			value := len(nodes)
+
+			switch {
+			case child == nil:
+			case child.data >= 0:
+				value = len(nodes) + 16 + child.data
+			default:
+				value = ids[child]
+			}
+
+			db.Write([]byte{byte(value >> 16), byte(value >> 8), byte(value)})
+		}
+	}
+
+	db.Write(make([]byte, 16))
+	db.Write(data.Bytes())
+	db.WriteString("\xAB\xCD\xEFMaxMind.com")
+	writeValue(t, &db, map[string]any{
+		"binary_format_major_version": uint16(2),
+		"binary_format_minor_version": uint16(0),
+		"build_epoch":                 uint64(time.Now().Unix()),
+		"database_type":               "Test-City",
+		"description":                 map[string]any{"en": "Test database"},
+		"ip_version":                  uint16(6),
+		"languages":                   []any{"en"},
+		"node_count":                  uint32(len(nodes)),
+		"record_size":                 uint16(24),
+	})
+
+	require.NoError(t, os.WriteFile(path, db.Bytes(), 0o600))
+}
+
+// writeValue writes v in the MaxMind DB data format.
+func writeValue(t *testing.T, buf *bytes.Buffer, v any) {
+	t.Helper()
+
+	const (
+		typeString = 2
+		typeUint16 = 5
+		typeUint32 = 6
+		typeMap    = 7
+		typeUint64 = 9
+		typeArray  = 11
+	)
+
+	writeControl := func(kind, size int) {
+		require.Less(t, size, 29, "sizes over 28 are not supported")
+
+		if kind <= typeMap {
+			buf.WriteByte(byte(kind<<5 | size))
+		} else {
+			buf.WriteByte(byte(size))
+			buf.WriteByte(byte(kind - typeMap))
+		}
+	}
+
+	writeUint := func(kind int, n uint64) {
+		b := binary.BigEndian.AppendUint64(nil, n)
+		b = bytes.TrimLeft(b, "\x00")
+
+		writeControl(kind, len(b))
+		buf.Write(b)
+	}
+
+	switch v := v.(type) {
+	case string:
+		writeControl(typeString, len(v))
+		buf.WriteString(v)
+	case uint16:
+		writeUint(typeUint16, uint64(v))
+	case uint32:
+		writeUint(typeUint32, uint64(v))
+	case uint64:
+		writeUint(typeUint64, v)
+	case []any:
+		writeControl(typeArray, len(v))
+
+		for _, item := range v {
+			writeValue(t, buf, item)
+		}
+	case map[string]any:
+		writeControl(typeMap, len(v))
+
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			writeValue(t, buf, key)
+			writeValue(t, buf, v[key])
+		}
+	default:
+		t.Fatalf("unsupported type %T", v)
+	}
+}
@@ -0,0 +1,124 @@
+// Package lru is a size-bounded in-memory cache with per-entry TTL, evicting
+// the least recently used entries once it holds more than its byte limit.
+package lru
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// Bytes accounted for each entry besides its key and value: list element,
+// map slot and entry struct.
+const entryOverhead = 96
+
+type entry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+func (e *entry) size() int64 {
+	return int64(len(e.key) + len(e.value) + entryOverhead)
+}
+
+// Cache is safe for concurrent use. The zero value is not usable; create it
+// with New.
+type Cache struct {
+	mu       sync.Mutex
+	maxBytes int64
+	size     int64
+	order    *list.List
+	items    map[string]*list.Element
+
+	now func() time.Time
+}
+
+func New(maxBytes int64) *Cache {
+	return &Cache{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		items:    make(map[string]*list.Element),
+		now:      time.Now,
+	}
+}
+
+// Get returns the value of key, unless it is missing or expired. Callers must
+// not change the returned slice.
+func (c *Cache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[key]
+	if !ok {
+		return nil, false
+	}
+
+	e := elem.Value.(*entry) //nolint:forcetypeassert
+	if !c.now().Before(e.expiresAt) {
+		c.remove(elem)
+
+		return nil, false
+	}
+
+	c.order.MoveToFront(elem)
+
+	return e.value, true
+}
+
+// Set stores value under key for ttl, evicting the least recently used
+// entries to make room. Values larger than the whole cache are not stored.
+func (c *Cache) Set(key string, value []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.remove(elem)
+	}
+
+	e := &entry{key: key, value: value, expiresAt: c.now().Add(ttl)}
+	if ttl <= 0 || e.size() > c.maxBytes {
+		return
+	}
+
+	c.items[key] = c.order.PushFront(e)
+	c.size += e.size()
+
+	for c.size > c.maxBytes {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *Cache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.items[key]; ok {
+		c.remove(elem)
+	}
+}
+
+// Purge removes all entries.
+func (c *Cache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.order.Init()
+	c.items = make(map[string]*list.Element)
+	c.size = 0
+}
+
+// Len returns the number of entries, expired ones included until they are
+// looked up or evicted.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+func (c *Cache) remove(elem *list.Element) {
+	e := c.order.Remove(elem).(*entry) //nolint:forcetypeassert
+	delete(c.items, e.key)
+	c.size -= e.size()
+}
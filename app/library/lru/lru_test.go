@@ -0,0 +1,91 @@
+package lru
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should return stored values until they expire", func(t *testing.T) {
+		t.Parallel()
+
+		now := time.Now()
+		cache := New(1 << 10)
+		cache.now = func() time.Time { return now }
+
+		cache.Set("a", []byte("1"), time.Minute)
+
+		value, ok := cache.Get("a")
+		assert.True(t, ok)
+		assert.Equal(t, []byte("1"), value)
+
+		now = now.Add(time.Minute)
+
+		_, ok = cache.Get("a")
+		assert.False(t, ok)
+		assert.Equal(t, 0, cache.Len())
+	})
+
+	t.Run("should evict the least recently used entries past the byte limit", func(t *testing.T) {
+		t.Parallel()
+
+		// Room for two one-byte entries.
+		cache := New(2 * (entryOverhead + 2))
+
+		cache.Set("a", []byte("1"), time.Minute)
+		cache.Set("b", []byte("2"), time.Minute)
+
+		// Makes "b" the least recently used.
+		_, _ = cache.Get("a")
+
+		cache.Set("c", []byte("3"), time.Minute)
+
+		_, ok := cache.Get("b")
+		assert.False(t, ok)
+
+		_, ok = cache.Get("a")
+		assert.True(t, ok)
+
+		_, ok = cache.Get("c")
+		assert.True(t, ok)
+	})
+
+	t.Run("should not store values larger than the cache", func(t *testing.T) {
+		t.Parallel()
+
+		cache := New(entryOverhead + 2)
+		cache.Set("a", []byte("1"), time.Minute)
+		cache.Set("b", []byte("too large"), time.Minute)
+
+		_, ok := cache.Get("b")
+		assert.False(t, ok)
+
+		// Replacing a key with a value that doesn't fit drops the old one.
+		cache.Set("a", []byte("too large"), time.Minute)
+
+		_, ok = cache.Get("a")
+		assert.False(t, ok)
+		assert.Equal(t, 0, cache.Len())
+	})
+
+	t.Run("should remove deleted and purged entries", func(t *testing.T) {
+		t.Parallel()
+
+		cache := New(1 << 10)
+		cache.Set("a", []byte("1"), time.Minute)
+		cache.Set("b", []byte("2"), time.Minute)
+
+		cache.Delete("a")
+
+		_, ok := cache.Get("a")
+		assert.False(t, ok)
+		assert.Equal(t, 1, cache.Len())
+
+		cache.Purge()
+		assert.Equal(t, 0, cache.Len())
+	})
+}
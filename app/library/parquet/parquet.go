@@ -0,0 +1,206 @@
+// Package parquet writes flat tables to Apache Parquet files.
+//
+// It wraps parquet-go for what exports need: a flat schema of string, int64
+// and timestamp columns, kept in the given order. Rows are buffered in memory
+// until a row group is full.
+package parquet
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+)
+
+// DefaultRowGroupSize is the number of rows buffered before they are written.
+const DefaultRowGroupSize = 50_000
+
+type ColumnType int
+
+const (
+	String ColumnType = iota
+	Int64
+	Timestamp
+)
+
+type Column struct {
+	Name     string
+	Type     ColumnType
+	Optional bool
+}
+
+var ErrValueInvalid = errors.New("parquet: invalid value")
+
+// Writer writes rows to a Parquet file. Values of a row are given in column
+// order: string, int64 and time.Time (or nil for optional columns).
+type Writer struct {
+	writer  *parquet.Writer
+	columns []Column
+
+	rowGroupSize int
+	buffered     int
+	row          parquet.Row
+	closed       bool
+}
+
+func NewWriter(out io.Writer, columns []Column) (*Writer, error) {
+	const operation = "Parquet.NewWriter"
+
+	root := orderedGroup{Group: make(parquet.Group, len(columns))}
+
+	for _, column := range columns {
+		node, err := column.node()
+		if err != nil {
+			return nil, fmt.Errorf("%s (%s) -> %w", operation, column.Name, err)
+		}
+
+		root.Group[column.Name] = node
+		root.fields = append(root.fields, groupField{Node: node, name: column.Name})
+	}
+
+	return &Writer{
+		writer:       parquet.NewWriter(out, parquet.NewSchema("export", root)),
+		columns:      columns,
+		rowGroupSize: DefaultRowGroupSize,
+		row:          make(parquet.Row, len(columns)),
+	}, nil
+}
+
+func (w *Writer) Write(row []any) error {
+	const operation = "Parquet.Write"
+
+	if len(row) != len(w.columns) {
+		return fmt.Errorf("%s -> %w: %d values for %d columns", operation, ErrValueInvalid, len(row), len(w.columns))
+	}
+
+	for i, column := range w.columns {
+		value, err := column.value(row[i])
+		if err != nil {
+			return fmt.Errorf("%s (%s) -> %w", operation, column.Name, err)
+		}
+
+		w.row[i] = value.Level(0, column.definitionLevel(row[i]), i)
+	}
+
+	if _, err := w.writer.WriteRows([]parquet.Row{w.row}); err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	w.buffered++
+
+	if w.buffered == w.rowGroupSize {
+		w.buffered = 0
+
+		if err := w.writer.Flush(); err != nil {
+			return fmt.Errorf("%s -> %w", operation, err)
+		}
+	}
+
+	return nil
+}
+
+// Close writes the buffered rows and the file footer. It doesn't close the
+// underlying writer.
+func (w *Writer) Close() error {
+	const operation = "Parquet.Close"
+
+	if w.closed {
+		return nil
+	}
+
+	w.closed = true
+
+	if err := w.writer.Close(); err != nil {
+		return fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	return nil
+}
+
+func (c Column) node() (parquet.Node, error) {
+	var node parquet.Node
+
+	switch c.Type {
+	case String:
+		node = parquet.String()
+	case Int64:
+		node = parquet.Int(64)
+	case Timestamp:
+		node = parquet.Timestamp(parquet.Millisecond)
+	default:
+		return nil, fmt.Errorf("%w: column type %d", ErrValueInvalid, c.Type)
+	}
+
+	if c.Optional {
+		node = parquet.Optional(node)
+	}
+
+	return node, nil
+}
+
+func (c Column) value(value any) (parquet.Value, error) {
+	if value == nil {
+		if !c.Optional {
+			return parquet.Value{}, fmt.Errorf("%w: nil in required column", ErrValueInvalid)
+		}
+
+		return parquet.NullValue(), nil
+	}
+
+	switch c.Type {
+	case String:
+		s, ok := value.(string)
+		if !ok {
+			return parquet.Value{}, fmt.Errorf("%w: %T in string column", ErrValueInvalid, value)
+		}
+
+		return parquet.ByteArrayValue([]byte(s)), nil
+	case Int64:
+		n, ok := value.(int64)
+		if !ok {
+			return parquet.Value{}, fmt.Errorf("%w: %T in int64 column", ErrValueInvalid, value)
+		}
+
+		return parquet.Int64Value(n), nil
+	default:
+		t, ok := value.(time.Time)
+		if !ok {
+			return parquet.Value{}, fmt.Errorf("%w: %T in timestamp column", ErrValueInvalid, value)
+		}
+
+		return parquet.Int64Value(t.UnixMilli()), nil
+	}
+}
+
+// definitionLevel is 1 for the values of optional columns, which have a
+// single optional level, and 0 for nulls and required values.
+func (c Column) definitionLevel(value any) int {
+	if c.Optional && value != nil {
+		return 1
+	}
+
+	return 0
+}
+
+// orderedGroup is a parquet.Group whose fields keep the order of the
+// columns instead of being sorted by name.
+type orderedGroup struct {
+	parquet.Group
+	fields []parquet.Field
+}
+
+func (g orderedGroup) Fields() []parquet.Field { return g.fields }
+
+type groupField struct {
+	parquet.Node
+	name string
+}
+
+func (f groupField) Name() string { return f.name }
+
+func (f groupField) Value(base reflect.Value) reflect.Value {
+	return base.MapIndex(reflect.ValueOf(&f.name).Elem())
+}
@@ -0,0 +1,61 @@
+package parquet
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriter_Write(t *testing.T) {
+	t.Parallel()
+
+	columns := []Column{
+		{Name: "code", Type: String},
+		{Name: "clicks", Type: Int64},
+		{Name: "expires_at", Type: Timestamp, Optional: true},
+	}
+
+	tests := []struct {
+		name    string
+		row     []any
+		wantErr error
+	}{
+		{
+			name: "values of every column",
+			row:  []any{"aZ3kP9q", int64(10), time.Now()},
+		},
+		{
+			name: "nil in an optional column",
+			row:  []any{"launch", int64(0), nil},
+		},
+		{
+			name:    "missing value",
+			row:     []any{"x", int64(1)},
+			wantErr: ErrValueInvalid,
+		},
+		{
+			name:    "nil in a required column",
+			row:     []any{nil, int64(1), nil},
+			wantErr: ErrValueInvalid,
+		},
+		{
+			name:    "value of the wrong type",
+			row:     []any{"x", 1, nil},
+			wantErr: ErrValueInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			w, err := NewWriter(&bytes.Buffer{}, columns)
+			require.NoError(t, err)
+
+			require.ErrorIs(t, w.Write(tt.row), tt.wantErr)
+			require.NoError(t, w.Close())
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package parquet
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestWriter_ReadBack reads the files written by Writer back, checking the
+// column order, the row groups and the null values.
+func TestWriter_ReadBack(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+
+	w, err := NewWriter(&buf, []Column{
+		{Name: "code", Type: String},
+		{Name: "clicks", Type: Int64},
+		{Name: "expires_at", Type: Timestamp, Optional: true},
+	})
+	require.NoError(t, err)
+
+	w.rowGroupSize = 2
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	require.NoError(t, w.Write([]any{"aZ3kP9q", int64(10), now}))
+	require.NoError(t, w.Write([]any{"launch", int64(0), nil}))
+	require.NoError(t, w.Write([]any{"", int64(-1), nil}))
+	require.NoError(t, w.Close())
+
+	file, err := parquet.OpenFile(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(3), file.NumRows())
+	assert.Len(t, file.RowGroups(), 2)
+
+	fields := file.Schema().Fields()
+	require.Len(t, fields, 3)
+	assert.Equal(t, "code", fields[0].Name())
+	assert.Equal(t, parquet.ByteArray, fields[0].Type().Kind())
+	assert.True(t, fields[0].Required())
+	assert.Equal(t, "clicks", fields[1].Name())
+	assert.Equal(t, parquet.Int64, fields[1].Type().Kind())
+	assert.Equal(t, "expires_at", fields[2].Name())
+	assert.Equal(t, parquet.Int64, fields[2].Type().Kind())
+	assert.True(t, fields[2].Optional())
+
+	type row struct {
+		code      string
+		clicks    int64
+		expiresAt any
+	}
+
+	var got []row
+
+	for _, rowGroup := range file.RowGroups() {
+		rows := rowGroup.Rows()
+		buffer := make([]parquet.Row, rowGroup.NumRows())
+
+		n, err := rows.ReadRows(buffer)
+		if !errors.Is(err, io.EOF) {
+			require.NoError(t, err)
+		}
+
+		require.NoError(t, rows.Close())
+
+		for _, values := range buffer[:n] {
+			require.Len(t, values, 3)
+
+			r := row{code: string(values[0].ByteArray()), clicks: values[1].Int64()}
+			if !values[2].IsNull() {
+				r.expiresAt = values[2].Int64()
+			}
+
+			got = append(got, r)
+		}
+	}
+
+	assert.Equal(t, []row{
+		{code: "aZ3kP9q", clicks: 10, expiresAt: now.UnixMilli()},
+		{code: "launch", clicks: 0},
+		{code: "", clicks: -1},
+	}, got)
+}
@@ -0,0 +1,113 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Argon2id parameters, following the OWASP password storage recommendation.
+const (
+	argonMemory  = 19 * 1024
+	argonTime    = 2
+	argonThreads = 1
+	argonKeyLen  = 32
+	argonSaltLen = 16
+)
+
+var ErrHashInvalid = errors.New("password hash is invalid")
+
+// Hash returns an Argon2id hash of password in the PHC string format
+// ($argon2id$v=19$m=...,t=...,p=...$salt$hash).
+func Hash(password string) (string, error) {
+	const operation = "Password.Hash"
+
+	salt := make([]byte, argonSaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	key := argon2.IDKey([]byte(password), salt, argonTime, argonMemory, argonThreads, argonKeyLen)
+
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		argonMemory,
+		argonTime,
+		argonThreads,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify reports whether password matches encoded, which may be an Argon2id
+// hash produced by Hash or a bcrypt hash.
+func Verify(password, encoded string) (bool, error) {
+	const operation = "Password.Verify"
+
+	switch {
+	case strings.HasPrefix(encoded, "$argon2id$"):
+		ok, err := verifyArgon2id(password, encoded)
+		if err != nil {
+			return false, fmt.Errorf("%s -> %w", operation, err)
+		}
+
+		return ok, nil
+	case strings.HasPrefix(encoded, "$2a$"), strings.HasPrefix(encoded, "$2b$"), strings.HasPrefix(encoded, "$2y$"):
+		err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+
+		if err != nil {
+			return false, fmt.Errorf("%s -> %w", operation, err)
+		}
+
+		return true, nil
+	default:
+		return false, fmt.Errorf("%s -> %w", operation, ErrHashInvalid)
+	}
+}
+
+func verifyArgon2id(password, encoded string) (bool, error) {
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", salt, hash
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 { //nolint:mnd
+		return false, ErrHashInvalid
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, ErrHashInvalid
+	}
+
+	var (
+		memory  uint32
+		time    uint32
+		threads uint8
+	)
+
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return false, ErrHashInvalid
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, ErrHashInvalid
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false, ErrHashInvalid
+	}
+
+	otherKey := argon2.IDKey([]byte(password), salt, time, memory, threads, uint32(len(key)))
+
+	return subtle.ConstantTimeCompare(key, otherKey) == 1, nil
+}
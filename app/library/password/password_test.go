@@ -0,0 +1,54 @@
+package password
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestHashAndVerify(t *testing.T) {
+	t.Parallel()
+
+	hash, err := Hash("pre-release")
+	require.NoError(t, err)
+	assert.Contains(t, hash, "$argon2id$v=19$")
+
+	ok, err := Verify("pre-release", hash)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = Verify("wrong", hash)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	other, err := Hash("pre-release")
+	require.NoError(t, err)
+	assert.NotEqual(t, hash, other, "salts must differ")
+}
+
+func TestVerify_Bcrypt(t *testing.T) {
+	t.Parallel()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("legacy"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	ok, err := Verify("legacy", string(hash))
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = Verify("wrong", string(hash))
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
+
+func TestVerify_InvalidHash(t *testing.T) {
+	t.Parallel()
+
+	_, err := Verify("x", "plaintext")
+	require.ErrorIs(t, err, ErrHashInvalid)
+
+	_, err = Verify("x", "$argon2id$v=19$m=1$bad")
+	require.ErrorIs(t, err, ErrHashInvalid)
+}
@@ -0,0 +1,140 @@
+package qr
+
+import (
+	"errors"
+	"fmt"
+	"image/color"
+	"strconv"
+	"strings"
+
+	goqrcode "github.com/skip2/go-qrcode"
+)
+
+type Format string
+
+const (
+	FormatPNG Format = "png"
+	FormatSVG Format = "svg"
+)
+
+// ContentType returns the media type of images in format f.
+func (f Format) ContentType() string {
+	if f == FormatSVG {
+		return "image/svg+xml"
+	}
+
+	return "image/png"
+}
+
+var ErrOptionsInvalid = errors.New("invalid qr code options")
+
+// Error correction levels, named as in the QR code specification.
+var levels = map[string]goqrcode.RecoveryLevel{
+	"L": goqrcode.Low,
+	"M": goqrcode.Medium,
+	"Q": goqrcode.High,
+	"H": goqrcode.Highest,
+}
+
+// Options describe how a QR code is rendered. Colors are hex RGB strings like
+// "000000" or "#000000".
+type Options struct {
+	Format     Format
+	Size       int
+	Level      string
+	Foreground string
+	Background string
+}
+
+// Key identifies the rendered image, for caching.
+func (o Options) Key() string {
+	return strings.Join([]string{
+		string(o.Format),
+		strconv.Itoa(o.Size),
+		o.Level,
+		strings.ToLower(strings.TrimPrefix(o.Foreground, "#")),
+		strings.ToLower(strings.TrimPrefix(o.Background, "#")),
+	}, ":")
+}
+
+// Render encodes content as a QR code image of opts.Size pixels.
+func Render(content string, opts Options) ([]byte, error) {
+	const operation = "QR.Render"
+
+	level, ok := levels[opts.Level]
+	if !ok {
+		return nil, fmt.Errorf("%s -> %w: level %q", operation, ErrOptionsInvalid, opts.Level)
+	}
+
+	foreground, err := ParseColor(opts.Foreground)
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	background, err := ParseColor(opts.Background)
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	code, err := goqrcode.New(content, level)
+	if err != nil {
+		return nil, fmt.Errorf("%s -> %w", operation, err)
+	}
+
+	code.ForegroundColor = foreground
+	code.BackgroundColor = background
+
+	switch opts.Format {
+	case FormatPNG:
+		image, err := code.PNG(opts.Size)
+		if err != nil {
+			return nil, fmt.Errorf("%s -> %w", operation, err)
+		}
+
+		return image, nil
+	case FormatSVG:
+		return renderSVG(code.Bitmap(), opts.Size, foreground, background), nil
+	default:
+		return nil, fmt.Errorf("%s -> %w: format %q", operation, ErrOptionsInvalid, opts.Format)
+	}
+}
+
+// ParseColor parses a hex RGB color like "1a2b3c" or "#1a2b3c".
+func ParseColor(hex string) (color.RGBA, error) {
+	hex = strings.TrimPrefix(hex, "#")
+
+	value, err := strconv.ParseUint(hex, 16, 32)
+	if len(hex) != 6 || err != nil {
+		return color.RGBA{}, fmt.Errorf("%w: color %q", ErrOptionsInvalid, hex)
+	}
+
+	return color.RGBA{R: uint8(value >> 16), G: uint8(value >> 8), B: uint8(value), A: 0xff}, nil
+}
+
+// renderSVG draws one path with a unit square per dark module, scaled to
+// size by the viewBox.
+func renderSVG(bitmap [][]bool, size int, foreground, background color.RGBA) []byte {
+	var builder strings.Builder
+
+	modules := len(bitmap)
+
+	fmt.Fprintf(&builder, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" shape-rendering="crispEdges">`, size, size, modules, modules)
+	fmt.Fprintf(&builder, `<rect width="100%%" height="100%%" fill="%s"/>`, hexColor(background))
+	fmt.Fprintf(&builder, `<path fill="%s" d="`, hexColor(foreground))
+
+	for y, row := range bitmap {
+		for x, dark := range row {
+			if dark {
+				fmt.Fprintf(&builder, "M%d %dh1v1h-1z", x, y)
+			}
+		}
+	}
+
+	builder.WriteString(`"/></svg>`)
+
+	return []byte(builder.String())
+}
+
+func hexColor(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
@@ -0,0 +1,81 @@
+package qr
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRender(t *testing.T) {
+	t.Parallel()
+
+	opts := Options{
+		Format:     FormatPNG,
+		Size:       256,
+		Level:      "M",
+		Foreground: "#112233",
+		Background: "ffffff",
+	}
+
+	t.Run("png", func(t *testing.T) {
+		t.Parallel()
+
+		image, err := Render("https://sho.rt/aZ3kP9q", opts)
+		require.NoError(t, err)
+
+		decoded, err := png.Decode(bytes.NewReader(image))
+		require.NoError(t, err)
+		assert.Equal(t, 256, decoded.Bounds().Dx())
+
+		// The quiet zone around the code uses the background color.
+		r, g, b, _ := decoded.At(0, 0).RGBA()
+		assert.Equal(t, []uint32{0xffff, 0xffff, 0xffff}, []uint32{r, g, b})
+	})
+
+	t.Run("svg", func(t *testing.T) {
+		t.Parallel()
+
+		svgOpts := opts
+		svgOpts.Format = FormatSVG
+
+		image, err := Render("https://sho.rt/aZ3kP9q", svgOpts)
+		require.NoError(t, err)
+
+		svg := string(image)
+		assert.True(t, strings.HasPrefix(svg, "<svg "))
+		assert.Contains(t, svg, `width="256"`)
+		assert.Contains(t, svg, `fill="#112233"`)
+		assert.Contains(t, svg, `fill="#ffffff"`)
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		t.Parallel()
+
+		for _, invalid := range []Options{
+			{Format: "gif", Size: 256, Level: "M", Foreground: "000000", Background: "ffffff"},
+			{Format: FormatPNG, Size: 256, Level: "X", Foreground: "000000", Background: "ffffff"},
+			{Format: FormatPNG, Size: 256, Level: "M", Foreground: "black", Background: "ffffff"},
+		} {
+			_, err := Render("https://sho.rt/aZ3kP9q", invalid)
+			require.ErrorIs(t, err, ErrOptionsInvalid)
+		}
+	})
+}
+
+func TestParseColor(t *testing.T) {
+	t.Parallel()
+
+	c, err := ParseColor("#0A0b0C")
+	require.NoError(t, err)
+	assert.Equal(t, color.RGBA{R: 0x0a, G: 0x0b, B: 0x0c, A: 0xff}, c)
+
+	for _, invalid := range []string{"", "fff", "#12345", "1234567", "zzzzzz", "-12345"} {
+		_, err := ParseColor(invalid)
+		require.ErrorIs(t, err, ErrOptionsInvalid, invalid)
+	}
+}
@@ -10,6 +10,9 @@ const (
 	// User.
 	SrnResourceUser Resource = "srn:resource:user"
 
+	// Link.
+	SrnResourceLink Resource = "srn:resource:link"
+
 	// Error.
 	SrnErrorBadRequest          Resource = "srn:error:invalid_params"
 	SrnErrorUnauthorized        Resource = "srn:error:unauthorized"
@@ -18,6 +21,7 @@ const (
 	SrnErrorMethodNotAllowed    Resource = "srn:error:method_not_allowed"
 	SrnErrorRequestTimeout      Resource = "srn:error:request_timeout"
 	SrnErrorConflict            Resource = "srn:error:conflict"
+	SrnErrorGone                Resource = "srn:error:gone"
 	SrnErrorPreconditionFailed  Resource = "srn:error:precondition_failed"
 	SrnErrorUnprocessableEntity Resource = "srn:error:unprocessable_entity"
 	SrnErrorTooManyRequests     Resource = "srn:error:too_many_requests"
@@ -34,6 +38,7 @@ var statusCodeToResource = map[int]Resource{
 	http.StatusMethodNotAllowed:    SrnErrorMethodNotAllowed,
 	http.StatusRequestTimeout:      SrnErrorRequestTimeout,
 	http.StatusConflict:            SrnErrorConflict,
+	http.StatusGone:                SrnErrorGone,
 	http.StatusPreconditionFailed:  SrnErrorPreconditionFailed,
 	http.StatusUnprocessableEntity: SrnErrorUnprocessableEntity,
 	http.StatusTooManyRequests:     SrnErrorTooManyRequests,
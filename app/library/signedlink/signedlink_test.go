@@ -0,0 +1,145 @@
+package signedlink
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/domain/erring"
+)
+
+func TestSigner(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	newSigner := func(t *testing.T, cfg config.LinkSigning) *Signer {
+		t.Helper()
+
+		signer, err := New(cfg)
+		require.NoError(t, err)
+
+		signer.now = func() time.Time { return now }
+
+		return signer
+	}
+
+	oldSigner := newSigner(t, config.LinkSigning{
+		Keys:        map[string]string{"v1": "old-secret-0123456789"},
+		ActiveKeyID: "v1",
+	})
+
+	rotated := newSigner(t, config.LinkSigning{
+		Keys:        map[string]string{"v1": "old-secret-0123456789", "v2": "new-secret-0123456789"},
+		ActiveKeyID: "v2",
+		RetiredKeys: map[string]string{"v1": "2026-10-01"},
+		GracePeriod: 30 * 24 * time.Hour,
+	})
+
+	expired := newSigner(t, config.LinkSigning{
+		Keys:        map[string]string{"v1": "old-secret-0123456789", "v2": "new-secret-0123456789"},
+		ActiveKeyID: "v2",
+		RetiredKeys: map[string]string{"v1": "2026-09-01"},
+		GracePeriod: 30 * 24 * time.Hour,
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+
+		signed, keyID, err := rotated.Sign("abc1234", nil)
+		require.NoError(t, err)
+		assert.Equal(t, "v2", keyID)
+		assert.True(t, IsSigned(signed))
+
+		code, err := rotated.Verify(signed)
+		require.NoError(t, err)
+		assert.Equal(t, "abc1234", code)
+	})
+
+	t.Run("embedded expiry", func(t *testing.T) {
+		t.Parallel()
+
+		future := now.Add(time.Hour)
+		signed, _, err := rotated.Sign("abc1234", &future)
+		require.NoError(t, err)
+
+		code, err := rotated.Verify(signed)
+		require.NoError(t, err)
+		assert.Equal(t, "abc1234", code)
+
+		past := now.Add(-time.Second)
+		signed, _, err = rotated.Sign("abc1234", &past)
+		require.NoError(t, err)
+
+		_, err = rotated.Verify(signed)
+		require.ErrorIs(t, err, erring.ErrLinkExpired)
+	})
+
+	t.Run("tampering", func(t *testing.T) {
+		t.Parallel()
+
+		future := now.Add(time.Hour)
+		signed, _, err := rotated.Sign("abc1234", &future)
+		require.NoError(t, err)
+
+		parts := strings.Split(signed, ".")
+
+		for _, forged := range []string{
+			"xyz9876." + strings.Join(parts[1:], "."),
+			strings.Join([]string{parts[0], parts[1], "zzzzzz", parts[3]}, "."),
+			strings.Join([]string{parts[0], parts[1], parts[3]}, "."),
+			strings.Join([]string{parts[0], "v1", parts[2], parts[3]}, "."),
+			strings.Join([]string{parts[0], "v9", parts[2], parts[3]}, "."),
+			"abc1234.v2",
+			"abc1234.v2.a.b.c",
+		} {
+			_, err := rotated.Verify(forged)
+			require.ErrorIs(t, err, erring.ErrLinkSignatureInvalid, forged)
+		}
+	})
+
+	t.Run("retired key within grace period", func(t *testing.T) {
+		t.Parallel()
+
+		signed, _, err := oldSigner.Sign("abc1234", nil)
+		require.NoError(t, err)
+
+		code, err := rotated.Verify(signed)
+		require.NoError(t, err)
+		assert.Equal(t, "abc1234", code)
+
+		_, err = expired.Verify(signed)
+		require.ErrorIs(t, err, erring.ErrLinkSignatureInvalid)
+	})
+
+	t.Run("without keys", func(t *testing.T) {
+		t.Parallel()
+
+		signer := newSigner(t, config.LinkSigning{})
+
+		_, _, err := signer.Sign("abc1234", nil)
+		require.ErrorIs(t, err, erring.ErrLinkSigningNotConfigured)
+
+		_, err = signer.Verify("abc1234.v1.sig")
+		require.ErrorIs(t, err, erring.ErrLinkSignatureInvalid)
+	})
+}
+
+func TestNew_InvalidKeys(t *testing.T) {
+	t.Parallel()
+
+	for name, cfg := range map[string]config.LinkSigning{
+		"short secret":       {Keys: map[string]string{"v1": "short"}, ActiveKeyID: "v1"},
+		"bad key id":         {Keys: map[string]string{"v.1": "secret-0123456789"}, ActiveKeyID: "v.1"},
+		"missing active key": {Keys: map[string]string{"v1": "secret-0123456789"}, ActiveKeyID: "v2"},
+		"retired active key": {Keys: map[string]string{"v1": "secret-0123456789"}, ActiveKeyID: "v1", RetiredKeys: map[string]string{"v1": "2026-01-01"}},
+		"bad retirement":     {Keys: map[string]string{"v1": "secret-0123456789", "v2": "secret-0123456789"}, ActiveKeyID: "v2", RetiredKeys: map[string]string{"v1": "yesterday"}},
+	} {
+		_, err := New(cfg)
+		assert.ErrorIs(t, err, errKeysInvalid, name)
+	}
+}
@@ -0,0 +1,98 @@
+package urlcheck
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// domainSet matches a host against a set of domains and all of their
+// subdomains.
+type domainSet map[string]struct{}
+
+func newDomainSet(domains []string) domainSet {
+	set := make(domainSet, len(domains))
+
+	for _, domain := range domains {
+		domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+		domain = strings.TrimPrefix(domain, "*.")
+
+		if domain != "" {
+			set[domain] = struct{}{}
+		}
+	}
+
+	return set
+}
+
+func (s domainSet) match(host string) bool {
+	if len(s) == 0 {
+		return false
+	}
+
+	for {
+		if _, ok := s[host]; ok {
+			return true
+		}
+
+		_, parent, found := strings.Cut(host, ".")
+		if !found {
+			return false
+		}
+
+		host = parent
+	}
+}
+
+// reloadBlocklist reads the blocklist file again if it changed since the
+// last load. It reports whether a new blocklist was installed.
+func (c *Checker) reloadBlocklist() (bool, error) {
+	const operation = "URLCheck.reloadBlocklist"
+
+	info, err := os.Stat(c.blocklistPath)
+	if err != nil {
+		return false, fmt.Errorf("%s (%s) -> %w", operation, c.blocklistPath, err)
+	}
+
+	if info.ModTime().Equal(c.blocklistModTime) {
+		return false, nil
+	}
+
+	domains, err := readBlocklist(c.blocklistPath)
+	if err != nil {
+		return false, fmt.Errorf("%s (%s) -> %w", operation, c.blocklistPath, err)
+	}
+
+	set := newDomainSet(domains)
+	c.blocklist.Store(&set)
+	c.blocklistModTime = info.ModTime()
+
+	return true, nil
+}
+
+// readBlocklist reads one domain per line, ignoring blank lines and
+// comments starting with #.
+func readBlocklist(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open: %w", err)
+	}
+	defer file.Close()
+
+	domains := make([]string, 0)
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			domains = append(domains, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scan: %w", err)
+	}
+
+	return domains, nil
+}
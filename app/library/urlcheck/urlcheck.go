@@ -0,0 +1,294 @@
+package urlcheck
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/net/idna"
+
+	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/domain/erring"
+)
+
+// Hostname suffixes that only resolve inside private networks.
+var internalSuffixes = []string{
+	"localhost",
+	"local",
+	"localdomain",
+	"internal",
+	"intranet",
+	"lan",
+	"corp",
+	"home",
+	"home.arpa",
+}
+
+// Carrier-grade NAT range, not covered by netip.Addr.IsPrivate.
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+var defaultPorts = map[string]string{
+	"http":  "80",
+	"https": "443",
+}
+
+// Resolver resolves hostnames; net.Resolver is one.
+type Resolver interface {
+	LookupNetIP(ctx context.Context, network, host string) ([]netip.Addr, error)
+}
+
+// Checker normalizes destination URLs and rejects the ones that are unsafe to
+// redirect to: disallowed schemes, private or internal hosts, blocked domains
+// and URLs pointing back at this service.
+//
+// Hostnames resolving to private addresses are rejected too, but only as of
+// the check: DNS may point them elsewhere later, and hostnames that fail to
+// resolve are accepted, as they may be registered later.
+type Checker struct {
+	allowedSchemes map[string]struct{}
+	ownHosts       domainSet
+	blocklist      atomic.Pointer[domainSet]
+
+	resolver       Resolver
+	resolveTimeout time.Duration
+
+	blocklistPath           string
+	blocklistReloadInterval time.Duration
+	blocklistModTime        time.Time
+}
+
+// New creates a Checker and loads the blocklist file, if configured. ownHosts
+// are the hosts serving our short links.
+func New(cfg config.URLCheck, ownHosts ...string) (*Checker, error) {
+	const operation = "URLCheck.New"
+
+	checker := &Checker{
+		allowedSchemes:          make(map[string]struct{}, len(cfg.AllowedSchemes)),
+		ownHosts:                newDomainSet(append(ownHosts, cfg.OwnHosts...)),
+		blocklistPath:           cfg.BlocklistPath,
+		blocklistReloadInterval: cfg.BlocklistReloadInterval,
+		resolver:                net.DefaultResolver,
+		resolveTimeout:          cfg.ResolveTimeout,
+	}
+
+	for _, scheme := range cfg.AllowedSchemes {
+		checker.allowedSchemes[strings.ToLower(strings.TrimSpace(scheme))] = struct{}{}
+	}
+
+	checker.blocklist.Store(&domainSet{})
+
+	if checker.blocklistPath != "" {
+		if _, err := checker.reloadBlocklist(); err != nil {
+			return nil, fmt.Errorf("%s -> %w", operation, err)
+		}
+	}
+
+	return checker, nil
+}
+
+// Watch reloads the blocklist whenever its file changes, until ctx is done.
+func (c *Checker) Watch(ctx context.Context) {
+	const operation = "URLCheck.Watch"
+
+	if c.blocklistPath == "" || c.blocklistReloadInterval <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(c.blocklistReloadInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := c.reloadBlocklist()
+			if err != nil {
+				// Keep serving the last good blocklist.
+				slog.ErrorContext(ctx, fmt.Sprintf("%s -> %v", operation, err))
+
+				continue
+			}
+
+			if reloaded {
+				slog.InfoContext(ctx, "url blocklist reloaded", slog.String("path", c.blocklistPath))
+			}
+		}
+	}
+}
+
+// WithResolver makes the Checker resolve hostnames with resolver.
+func (c *Checker) WithResolver(resolver Resolver) *Checker {
+	c.resolver = resolver
+
+	return c
+}
+
+// Check validates rawURL and returns its normalized form.
+func (c *Checker) Check(ctx context.Context, rawURL string) (string, error) {
+	const operation = "URLCheck.Check"
+
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil || !parsed.IsAbs() {
+		return "", fmt.Errorf("%s -> %w", operation, erring.ErrURLInvalid)
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	if _, ok := c.allowedSchemes[parsed.Scheme]; !ok {
+		return "", fmt.Errorf("%s (%s) -> %w", operation, parsed.Scheme, erring.ErrURLSchemeNotAllowed)
+	}
+
+	// Opaque URLs have no host to check, and credentials in URLs are a classic
+	// phishing trick (https://bank.com@evil.com).
+	if parsed.Opaque != "" || parsed.User != nil {
+		return "", fmt.Errorf("%s -> %w", operation, erring.ErrURLInvalid)
+	}
+
+	host, err := normalizeHost(parsed.Hostname())
+	if err != nil {
+		return "", fmt.Errorf("%s -> %w", operation, erring.ErrURLInvalid)
+	}
+
+	if err := checkHost(host); err != nil {
+		return "", fmt.Errorf("%s (%s) -> %w", operation, host, err)
+	}
+
+	if c.ownHosts.match(host) {
+		return "", fmt.Errorf("%s (%s) -> %w", operation, host, erring.ErrURLRedirectLoop)
+	}
+
+	if c.blocklist.Load().match(host) {
+		return "", fmt.Errorf("%s (%s) -> %w", operation, host, erring.ErrURLBlocked)
+	}
+
+	if err := c.checkResolvedHost(ctx, host); err != nil {
+		return "", fmt.Errorf("%s (%s) -> %w", operation, host, err)
+	}
+
+	port := parsed.Port()
+	if port == defaultPorts[parsed.Scheme] {
+		port = ""
+	}
+
+	if ip, err := netip.ParseAddr(host); err == nil && ip.Is6() {
+		host = "[" + host + "]"
+	}
+
+	parsed.Host = host
+	if port != "" {
+		parsed.Host = net.JoinHostPort(strings.Trim(host, "[]"), port)
+	}
+
+	if parsed.Path == "" {
+		parsed.Path = "/"
+	}
+
+	return parsed.String(), nil
+}
+
+// normalizeHost lowercases the host, drops a trailing dot and converts
+// internationalized names to their ASCII (punycode) form.
+func normalizeHost(host string) (string, error) {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "" {
+		return "", erring.ErrURLInvalid
+	}
+
+	if _, err := netip.ParseAddr(host); err == nil {
+		return host, nil
+	}
+
+	ascii, err := idna.Lookup.ToASCII(host)
+	if err != nil {
+		return "", fmt.Errorf("idna: %w", err)
+	}
+
+	return ascii, nil
+}
+
+func checkHost(host string) error {
+	if addr, err := netip.ParseAddr(host); err == nil {
+		if !IsPublicAddr(addr) {
+			return erring.ErrURLHostNotAllowed
+		}
+
+		return nil
+	}
+
+	// Browsers also accept IPv4 addresses written as a single number or in
+	// hex/octal parts (http://2130706433, http://0x7f.1), which would bypass
+	// the checks above.
+	if isNumericHost(host) {
+		return erring.ErrURLHostNotAllowed
+	}
+
+	// Single label hosts (http://intranet) only resolve inside a network.
+	if !strings.Contains(host, ".") {
+		return erring.ErrURLHostNotAllowed
+	}
+
+	for _, suffix := range internalSuffixes {
+		if host == suffix || strings.HasSuffix(host, "."+suffix) {
+			return erring.ErrURLHostNotAllowed
+		}
+	}
+
+	return nil
+}
+
+// checkResolvedHost rejects hostnames with any private address.
+func (c *Checker) checkResolvedHost(ctx context.Context, host string) error {
+	if c.resolveTimeout <= 0 {
+		return nil
+	}
+
+	if _, err := netip.ParseAddr(host); err == nil {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.resolveTimeout)
+	defer cancel()
+
+	addrs, err := c.resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		// Unresolved hosts are not known to be private.
+		return nil //nolint:nilerr
+	}
+
+	for _, addr := range addrs {
+		if !IsPublicAddr(addr) {
+			return erring.ErrURLHostNotAllowed
+		}
+	}
+
+	return nil
+}
+
+// IsPublicAddr reports whether addr is reachable on the public internet,
+// i.e. not private, loopback, link-local or carrier-grade NAT.
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	return addr.IsGlobalUnicast() && !addr.IsPrivate() && !addr.IsLoopback() && !addr.IsLinkLocalUnicast() && !sharedAddressSpace.Contains(addr)
+}
+
+func isNumericHost(host string) bool {
+	for _, part := range strings.Split(host, ".") {
+		if part == "" {
+			continue
+		}
+
+		if _, err := strconv.ParseUint(part, 0, 64); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
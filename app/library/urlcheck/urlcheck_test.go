@@ -0,0 +1,165 @@
+package urlcheck
+
+import (
+	"context"
+	"net"
+	"net/netip"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/domain/erring"
+)
+
+func TestChecker_Check(t *testing.T) {
+	t.Parallel()
+
+	blocklistPath := filepath.Join(t.TempDir(), "blocklist.txt")
+	require.NoError(t, os.WriteFile(blocklistPath, []byte("# phishing\nevil.test\nbad.example.org # reported\n"), 0o600))
+
+	checker, err := New(config.URLCheck{
+		AllowedSchemes: []string{"http", "https"},
+		BlocklistPath:  blocklistPath,
+		OwnHosts:       []string{"go.example.net"},
+	}, "sho.rt")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		rawURL  string
+		want    string
+		wantErr error
+	}{
+		{name: "normalizes scheme, host and default port", rawURL: "  HTTPS://Example.COM:443/Path?q=1 ", want: "https://example.com/Path?q=1"},
+		{name: "adds root path", rawURL: "http://example.com", want: "http://example.com/"},
+		{name: "keeps custom port", rawURL: "https://example.com:8443/a", want: "https://example.com:8443/a"},
+		{name: "converts idn to punycode", rawURL: "https://bücher.de/", want: "https://xn--bcher-kva.de/"},
+		{name: "public ip literal", rawURL: "http://8.8.8.8/", want: "http://8.8.8.8/"},
+		{name: "relative url", rawURL: "/just/a/path", wantErr: erring.ErrURLInvalid},
+		{name: "garbage", rawURL: "http://exa mple.com", wantErr: erring.ErrURLInvalid},
+		{name: "credentials", rawURL: "https://bank.com@evil.test/", wantErr: erring.ErrURLInvalid},
+		{name: "javascript scheme", rawURL: "javascript:alert(1)", wantErr: erring.ErrURLSchemeNotAllowed},
+		{name: "ftp scheme", rawURL: "ftp://example.com/file", wantErr: erring.ErrURLSchemeNotAllowed},
+		{name: "loopback", rawURL: "http://127.0.0.1/admin", wantErr: erring.ErrURLHostNotAllowed},
+		{name: "private ipv4", rawURL: "http://10.1.2.3/", wantErr: erring.ErrURLHostNotAllowed},
+		{name: "link local metadata", rawURL: "http://169.254.169.254/latest/meta-data", wantErr: erring.ErrURLHostNotAllowed},
+		{name: "ipv6 loopback", rawURL: "http://[::1]/", wantErr: erring.ErrURLHostNotAllowed},
+		{name: "ipv4 mapped ipv6", rawURL: "http://[::ffff:127.0.0.1]/", wantErr: erring.ErrURLHostNotAllowed},
+		{name: "decimal ip", rawURL: "http://2130706433/", wantErr: erring.ErrURLHostNotAllowed},
+		{name: "hex ip", rawURL: "http://0x7f.1/", wantErr: erring.ErrURLHostNotAllowed},
+		{name: "localhost", rawURL: "http://localhost:8080/", wantErr: erring.ErrURLHostNotAllowed},
+		{name: "internal suffix", rawURL: "https://db.internal/", wantErr: erring.ErrURLHostNotAllowed},
+		{name: "single label host", rawURL: "http://intranet/", wantErr: erring.ErrURLHostNotAllowed},
+		{name: "blocked domain", rawURL: "https://evil.test/login", wantErr: erring.ErrURLBlocked},
+		{name: "blocked subdomain", rawURL: "https://www.evil.test/login", wantErr: erring.ErrURLBlocked},
+		{name: "blocked with trailing dot", rawURL: "https://EVIL.test./", wantErr: erring.ErrURLBlocked},
+		{name: "sibling of blocked domain is allowed", rawURL: "https://example.org/", want: "https://example.org/"},
+		{name: "own host", rawURL: "https://sho.rt/abc", wantErr: erring.ErrURLRedirectLoop},
+		{name: "extra own host", rawURL: "https://go.example.net/abc", wantErr: erring.ErrURLRedirectLoop},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := checker.Check(context.Background(), tt.rawURL)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestChecker_Watch(t *testing.T) {
+	t.Parallel()
+
+	blocklistPath := filepath.Join(t.TempDir(), "blocklist.txt")
+	require.NoError(t, os.WriteFile(blocklistPath, []byte("evil.test\n"), 0o600))
+
+	checker, err := New(config.URLCheck{
+		AllowedSchemes:          []string{"https"},
+		BlocklistPath:           blocklistPath,
+		BlocklistReloadInterval: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go checker.Watch(ctx)
+
+	_, err = checker.Check(ctx, "https://new-evil.test/")
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(blocklistPath, []byte("evil.test\nnew-evil.test\n"), 0o600))
+	require.NoError(t, os.Chtimes(blocklistPath, time.Now(), time.Now().Add(time.Second)))
+
+	assert.Eventually(t, func() bool {
+		_, err := checker.Check(ctx, "https://new-evil.test/")
+
+		return err != nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+type fakeResolver map[string][]netip.Addr
+
+func (r fakeResolver) LookupNetIP(_ context.Context, _, host string) ([]netip.Addr, error) {
+	addrs, ok := r[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+
+	return addrs, nil
+}
+
+func TestChecker_CheckResolvedHost(t *testing.T) {
+	t.Parallel()
+
+	checker, err := New(config.URLCheck{
+		AllowedSchemes: []string{"https"},
+		ResolveTimeout: time.Second,
+	})
+	require.NoError(t, err)
+
+	checker.WithResolver(fakeResolver{
+		"public.example.com":   {netip.MustParseAddr("93.184.215.14")},
+		"internal.example.com": {netip.MustParseAddr("10.0.0.5")},
+		"mixed.example.com":    {netip.MustParseAddr("93.184.215.14"), netip.MustParseAddr("::1")},
+	})
+
+	tests := []struct {
+		name    string
+		rawURL  string
+		wantErr error
+	}{
+		{name: "public host", rawURL: "https://public.example.com/"},
+		{name: "host resolving to a private address", rawURL: "https://internal.example.com/", wantErr: erring.ErrURLHostNotAllowed},
+		{name: "host with any private address", rawURL: "https://mixed.example.com/", wantErr: erring.ErrURLHostNotAllowed},
+		{name: "unresolved host", rawURL: "https://unregistered.example.com/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := checker.Check(context.Background(), tt.rawURL)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,199 @@
+// Package useragent tells the browser, operating system and device class of
+// user agents, and which ones are bots.
+package useragent
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/go-api-template/app/config"
+)
+
+// Bundled bot rules; see the file for its format.
+//
+//go:embed rules.txt
+var defaultRules string
+
+type Device string
+
+const (
+	DeviceUnknown Device = ""
+	DeviceDesktop Device = "desktop"
+	DeviceMobile  Device = "mobile"
+	DeviceTablet  Device = "tablet"
+	DeviceBot     Device = "bot"
+)
+
+// Agent is what a user agent tells about its client. Fields it doesn't tell
+// are empty.
+type Agent struct {
+	Browser string
+	OS      string
+	Device  Device
+
+	// Bot is set for every automated client, including link-preview
+	// fetchers, which also have Preview set.
+	Bot     bool
+	Preview bool
+}
+
+// family is a browser or operating system, told by any of its patterns.
+type family struct {
+	name     string
+	patterns []string
+}
+
+// Patterns are lowercase, and families checked in order: some clients
+// mention the ones they derive from too (e.g. every Chromium browser says
+// Safari).
+var (
+	browsers = []family{
+		{"Edge", []string{"edg/", "edge/", "edga/", "edgios/"}},
+		{"Opera", []string{"opr/", "opera"}},
+		{"Samsung Internet", []string{"samsungbrowser/"}},
+		{"Yandex Browser", []string{"yabrowser/"}},
+		{"UC Browser", []string{"ucbrowser/"}},
+		{"Firefox", []string{"firefox/", "fxios/"}},
+		{"Chrome", []string{"chrome/", "crios/", "chromium/"}},
+		{"Safari", []string{"safari/"}},
+		{"Internet Explorer", []string{"msie ", "trident/"}},
+	}
+
+	operatingSystems = []family{
+		{"Windows Phone", []string{"windows phone"}},
+		{"Windows", []string{"windows"}},
+		{"iOS", []string{"iphone", "ipad", "ipod"}},
+		{"Android", []string{"android"}},
+		{"ChromeOS", []string{"cros"}},
+		{"macOS", []string{"mac os x", "macintosh"}},
+		{"Linux", []string{"linux"}},
+	}
+)
+
+// Parser parses user agents, telling bots with a rules file.
+type Parser struct {
+	previews []string
+	bots     []string
+}
+
+// New creates a Parser with the rules file at cfg.RulesPath, or the bundled
+// one.
+func New(cfg config.UserAgent) (*Parser, error) {
+	const operation = "UserAgent.New"
+
+	rules := io.Reader(strings.NewReader(defaultRules))
+
+	if cfg.RulesPath != "" {
+		file, err := os.Open(cfg.RulesPath)
+		if err != nil {
+			return nil, fmt.Errorf("%s -> %w", operation, err)
+		}
+		defer file.Close()
+
+		rules = file
+	}
+
+	parser, err := parseRules(rules)
+	if err != nil {
+		return nil, fmt.Errorf("%s (%s) -> %w", operation, cfg.RulesPath, err)
+	}
+
+	return parser, nil
+}
+
+// parseRules reads the patterns of each section, ignoring blank lines and
+// comments starting with #.
+func parseRules(r io.Reader) (*Parser, error) {
+	parser := &Parser{}
+
+	var section *[]string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.ToLower(strings.TrimSpace(line)); line == "" {
+			continue
+		}
+
+		switch {
+		case line == "[preview]":
+			section = &parser.previews
+		case line == "[bot]":
+			section = &parser.bots
+		case section == nil:
+			return nil, fmt.Errorf("pattern %q outside of a section", line)
+		default:
+			*section = append(*section, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read rules: %w", err)
+	}
+
+	return parser, nil
+}
+
+// Parse parses a user agent. Clients without one are bots, as every browser
+// sends it.
+func (p *Parser) Parse(userAgent string) Agent {
+	ua := strings.ToLower(userAgent)
+
+	agent := Agent{
+		Browser: match(ua, browsers),
+		OS:      match(ua, operatingSystems),
+	}
+
+	switch {
+	case strings.TrimSpace(ua) == "":
+		agent.Bot = true
+	case containsAny(ua, p.previews):
+		agent.Bot, agent.Preview = true, true
+	case containsAny(ua, p.bots):
+		agent.Bot = true
+	}
+
+	agent.Device = device(ua, agent)
+
+	return agent
+}
+
+func device(ua string, agent Agent) Device {
+	switch {
+	case agent.Bot:
+		return DeviceBot
+	case strings.Contains(ua, "ipad"), strings.Contains(ua, "tablet"),
+		strings.Contains(ua, "android") && !strings.Contains(ua, "mobile"):
+		return DeviceTablet
+	case containsAny(ua, []string{"mobi", "iphone", "ipod", "windows phone"}):
+		return DeviceMobile
+	case agent.OS != "":
+		return DeviceDesktop
+	}
+
+	return DeviceUnknown
+}
+
+func match(ua string, families []family) string {
+	for _, f := range families {
+		if containsAny(ua, f.patterns) {
+			return f.name
+		}
+	}
+
+	return ""
+}
+
+func containsAny(s string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if strings.Contains(s, pattern) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,139 @@
+package useragent
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/go-api-template/app/config"
+)
+
+func TestParser(t *testing.T) {
+	t.Parallel()
+
+	parser, err := New(config.UserAgent{})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		userAgent string
+		want      Agent
+	}{
+		{
+			name:      "chrome on windows",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+			want:      Agent{Browser: "Chrome", OS: "Windows", Device: DeviceDesktop},
+		},
+		{
+			name:      "edge on windows",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36 Edg/124.0.2478.51",
+			want:      Agent{Browser: "Edge", OS: "Windows", Device: DeviceDesktop},
+		},
+		{
+			name:      "safari on iphone",
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Mobile/15E148 Safari/604.1",
+			want:      Agent{Browser: "Safari", OS: "iOS", Device: DeviceMobile},
+		},
+		{
+			name:      "chrome on ipad",
+			userAgent: "Mozilla/5.0 (iPad; CPU OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) CriOS/124.0.6367.88 Mobile/15E148 Safari/604.1",
+			want:      Agent{Browser: "Chrome", OS: "iOS", Device: DeviceTablet},
+		},
+		{
+			name:      "samsung internet on android phone",
+			userAgent: "Mozilla/5.0 (Linux; Android 14; SM-S921B) AppleWebKit/537.36 (KHTML, like Gecko) SamsungBrowser/24.0 Chrome/117.0.0.0 Mobile Safari/537.36",
+			want:      Agent{Browser: "Samsung Internet", OS: "Android", Device: DeviceMobile},
+		},
+		{
+			name:      "firefox on android tablet",
+			userAgent: "Mozilla/5.0 (Android 14; Tablet; rv:125.0) Gecko/125.0 Firefox/125.0",
+			want:      Agent{Browser: "Firefox", OS: "Android", Device: DeviceTablet},
+		},
+		{
+			name:      "firefox on linux",
+			userAgent: "Mozilla/5.0 (X11; Linux x86_64; rv:125.0) Gecko/20100101 Firefox/125.0",
+			want:      Agent{Browser: "Firefox", OS: "Linux", Device: DeviceDesktop},
+		},
+		{
+			name:      "safari on macos",
+			userAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_4_1) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4.1 Safari/605.1.15",
+			want:      Agent{Browser: "Safari", OS: "macOS", Device: DeviceDesktop},
+		},
+		{
+			name:      "slack link preview",
+			userAgent: "Slackbot-LinkExpanding 1.0 (+https://api.slack.com/robots)",
+			want:      Agent{Device: DeviceBot, Bot: true, Preview: true},
+		},
+		{
+			name:      "whatsapp link preview",
+			userAgent: "WhatsApp/2.24.6.77 A",
+			want:      Agent{Device: DeviceBot, Bot: true, Preview: true},
+		},
+		{
+			name:      "twitter link preview",
+			userAgent: "Twitterbot/1.0",
+			want:      Agent{Device: DeviceBot, Bot: true, Preview: true},
+		},
+		{
+			name:      "facebook link preview",
+			userAgent: "facebookexternalhit/1.1 (+http://www.facebook.com/externalhit_uatext.php)",
+			want:      Agent{Device: DeviceBot, Bot: true, Preview: true},
+		},
+		{
+			name:      "search crawler",
+			userAgent: "Mozilla/5.0 (Linux; Android 6.0.1; Nexus 5X Build/MMB29P) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.6367.91 Mobile Safari/537.36 (compatible; Googlebot/2.1; +http://www.google.com/bot.html)",
+			want:      Agent{Browser: "Chrome", OS: "Android", Device: DeviceBot, Bot: true},
+		},
+		{
+			name:      "http library",
+			userAgent: "curl/8.5.0",
+			want:      Agent{Device: DeviceBot, Bot: true},
+		},
+		{
+			name: "missing user agent",
+			want: Agent{Device: DeviceBot, Bot: true},
+		},
+		{
+			name:      "unknown client",
+			userAgent: "SomeApp/1.0",
+			want:      Agent{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, parser.Parse(tt.userAgent))
+		})
+	}
+}
+
+func TestParserRules(t *testing.T) {
+	t.Parallel()
+
+	t.Run("should use the rules file when set", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "rules.txt")
+		require.NoError(t, os.WriteFile(path, []byte("[preview]\nAcmePreview # Acme chat\n\n[bot]\nacme-monitor\n"), 0o600))
+
+		parser, err := New(config.UserAgent{RulesPath: path})
+		require.NoError(t, err)
+
+		assert.True(t, parser.Parse("AcmePreview/2.0").Preview)
+		assert.True(t, parser.Parse("Acme-Monitor/1.0").Bot)
+		assert.False(t, parser.Parse("curl/8.5.0").Bot)
+	})
+
+	t.Run("should reject patterns outside of a section", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := parseRules(strings.NewReader("curl/\n"))
+		assert.Error(t, err)
+	})
+}
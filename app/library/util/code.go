@@ -0,0 +1,25 @@
+package util
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+const base62Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+// RandomCode returns a cryptographically random base62 string of the given length.
+func RandomCode(length int) (string, error) {
+	code := make([]byte, length)
+	alphabetLen := big.NewInt(int64(len(base62Alphabet)))
+
+	for i := range code {
+		n, err := rand.Int(rand.Reader, alphabetLen)
+		if err != nil {
+			return "", err //nolint:wrapcheck
+		}
+
+		code[i] = base62Alphabet[n.Int64()]
+	}
+
+	return string(code), nil
+}
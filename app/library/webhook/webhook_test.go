@@ -0,0 +1,53 @@
+package webhook
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSignVerify(t *testing.T) {
+	t.Parallel()
+
+	secret, err := NewSecret()
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(secret, secretPrefix))
+
+	sentAt := time.Unix(1700000000, 0)
+	body := []byte(`{"type":"link.created"}`)
+	timestamp := strconv.FormatInt(sentAt.Unix(), 10)
+	signature := Sign(secret, sentAt, body)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		signature string
+		body      []byte
+		now       time.Time
+		wantErr   error
+	}{
+		{name: "valid", secret: secret, timestamp: timestamp, signature: signature, body: body, now: sentAt.Add(time.Minute)},
+		{name: "one of several signatures", secret: secret, timestamp: timestamp, signature: "v1=00ff " + signature, body: body, now: sentAt},
+		{name: "other secret", secret: "whsec_other", timestamp: timestamp, signature: signature, body: body, now: sentAt, wantErr: ErrSignatureInvalid},
+		{name: "tampered body", secret: secret, timestamp: timestamp, signature: signature, body: []byte(`{"type":"link.updated"}`), now: sentAt, wantErr: ErrSignatureInvalid},
+		{name: "tampered timestamp", secret: secret, timestamp: strconv.FormatInt(sentAt.Unix()+1, 10), signature: signature, body: body, now: sentAt, wantErr: ErrSignatureInvalid},
+		{name: "unknown version", secret: secret, timestamp: timestamp, signature: "v2" + strings.TrimPrefix(signature, "v1"), body: body, now: sentAt, wantErr: ErrSignatureInvalid},
+		{name: "too old", secret: secret, timestamp: timestamp, signature: signature, body: body, now: sentAt.Add(6 * time.Minute), wantErr: ErrTimestampInvalid},
+		{name: "from the future", secret: secret, timestamp: timestamp, signature: signature, body: body, now: sentAt.Add(-6 * time.Minute), wantErr: ErrTimestampInvalid},
+		{name: "malformed timestamp", secret: secret, timestamp: "yesterday", signature: signature, body: body, now: sentAt, wantErr: ErrTimestampInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := Verify(tt.secret, tt.timestamp, tt.signature, tt.body, tt.now, 5*time.Minute)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
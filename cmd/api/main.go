@@ -18,6 +18,7 @@ import (
 	"github.com/go-api-template/app/gateway/api"
 	"github.com/go-api-template/app/gateway/postgres"
 	"github.com/go-api-template/app/gateway/redis"
+	"github.com/go-api-template/app/library/circuitbreaker"
 	"github.com/go-api-template/app/telemetry"
 )
 
@@ -48,20 +49,26 @@ func main() {
 
 	ctx := telemetry.ContextWithTracer(mainCtx, otel.Tracer)
 
+	// Circuit Breakers
+	circuitManager := circuitbreaker.NewManager(cfg.CircuitBreaker)
+
 	// Postgres
-	postgresClient, err := postgres.New(ctx, cfg.Postgres)
+	postgresClient, err := postgres.New(ctx, cfg.Postgres, circuitManager)
 	if err != nil {
 		log.Fatalf("failed to start postgres: %v", err)
 	}
 
 	// Redis
-	redisClient, err := redis.New(ctx, cfg.Redis)
+	redisClient, err := redis.New(ctx, cfg.Redis, cfg.RedisNamespace(), circuitManager)
 	if err != nil {
 		log.Fatalf("failed to start redis: %v", err)
 	}
 
+	cache := redis.NewLayeredCache(redisClient, cfg.LocalCache)
+	go cache.Watch(ctx)
+
 	// Application
-	appl, err := app.New(cfg, postgresClient, redisClient)
+	appl, err := app.New(ctx, cfg, postgresClient, cache, circuitManager)
 	if err != nil {
 		log.Fatalf("failed to start application: %v", err)
 	}
@@ -70,7 +77,7 @@ func main() {
 	server := &http.Server{
 		Addr:         cfg.Server.APIAddress,
 		BaseContext:  func(_ net.Listener) context.Context { return ctx },
-		Handler:      api.New(cfg, redisClient, appl.UseCase).Handler,
+		Handler:      api.New(cfg, cache, appl.UseCase, circuitManager).Handler,
 		ReadTimeout:  cfg.Server.ReadTimeout,
 		WriteTimeout: cfg.Server.WriteTimeout,
 	}
@@ -0,0 +1,134 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/go-api-template/app"
+	"github.com/go-api-template/app/config"
+	"github.com/go-api-template/app/gateway/jobs"
+	"github.com/go-api-template/app/gateway/outbox"
+	"github.com/go-api-template/app/gateway/postgres"
+	"github.com/go-api-template/app/gateway/redis"
+	"github.com/go-api-template/app/library/circuitbreaker"
+	"github.com/go-api-template/app/telemetry"
+)
+
+// Injected on build via ldflags.
+var (
+	BuildTime   = "undefined"
+	BuildCommit = "undefined"
+	BuildTag    = "undefined"
+)
+
+func main() {
+	mainCtx := context.Background()
+
+	// Config
+	cfg, err := config.New()
+	if err != nil {
+		log.Fatalf("failed to load configurations: %v", err)
+	}
+
+	// Logger
+	telemetry.SetLogger(cfg, BuildTime, BuildCommit, BuildTag)
+
+	// Open Telemetry
+	otel, err := telemetry.NewOtel(mainCtx, cfg.Otel, string(cfg.Environment), BuildTag)
+	if err != nil {
+		log.Fatalf("failed to start otel: %v", err)
+	}
+
+	ctx := telemetry.ContextWithTracer(mainCtx, otel.Tracer)
+
+	// Circuit Breakers
+	circuitManager := circuitbreaker.NewManager(cfg.CircuitBreaker)
+
+	// Postgres
+	// Jobs act on rows written right before they were enqueued, which the
+	// replicas may not have yet.
+	cfg.Postgres.ReplicaDSNs = nil
+
+	postgresClient, err := postgres.New(ctx, cfg.Postgres, circuitManager)
+	if err != nil {
+		log.Fatalf("failed to start postgres: %v", err)
+	}
+
+	// Redis
+	redisClient, err := redis.New(ctx, cfg.Redis, cfg.RedisNamespace(), circuitManager)
+	if err != nil {
+		log.Fatalf("failed to start redis: %v", err)
+	}
+
+	cache := redis.NewLayeredCache(redisClient, cfg.LocalCache)
+	go cache.Watch(ctx)
+
+	// Application
+	// Clicks are only located by the API, so workers don't load the database.
+	cfg.GeoIP.DatabasePath = ""
+
+	appl, err := app.New(ctx, cfg, postgresClient, cache, circuitManager)
+	if err != nil {
+		log.Fatalf("failed to start application: %v", err)
+	}
+
+	// Worker
+	worker := jobs.NewWorker(postgres.NewJobsRepository(postgresClient), cfg.Jobs)
+	appl.RegisterJobHandlers(worker)
+
+	// Outbox
+	relay := outbox.NewRelay(postgres.NewOutboxRepository(postgresClient), cfg.Outbox)
+	appl.RegisterOutboxHandlers(relay)
+	worker.Schedule("outbox-relay", cfg.Outbox.RelayInterval, relay.Run)
+
+	// Graceful Shutdown
+	stopCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT)
+	group, groupCtx := errgroup.WithContext(stopCtx)
+
+	group.Go(func() error {
+		log.Printf("starting worker")
+
+		return worker.Run(ctx)
+	})
+
+	//nolint:contextcheck
+	group.Go(func() error {
+		<-groupCtx.Done()
+
+		log.Printf("stopping worker; interrupt signal received")
+
+		timeoutCtx, cancel := context.WithTimeout(context.Background(), cfg.App.GracefulShutdownTimeout)
+		defer cancel()
+
+		var errs error
+
+		if err := worker.Shutdown(timeoutCtx); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to stop worker: %w", err))
+		}
+
+		if err := otel.Close(timeoutCtx); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to stop otel: %w", err))
+		}
+
+		if err := redisClient.Close(); err != nil {
+			errs = errors.Join(errs, fmt.Errorf("failed to stop redis: %w", err))
+		}
+
+		postgresClient.Close()
+
+		return errs
+	})
+
+	if err := group.Wait(); err != nil {
+		log.Fatalf("worker exit reason: %v", err)
+	}
+
+	stop()
+}